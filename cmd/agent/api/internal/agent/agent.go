@@ -79,6 +79,7 @@ func SetupHandlers(
 	r.HandleFunc("/config/{setting}", settingshttp.Server.GetValue).Methods("GET")
 	r.HandleFunc("/config/{setting}", settingshttp.Server.SetValue).Methods("POST")
 	r.HandleFunc("/tagger-list", getTaggerList).Methods("GET")
+	r.HandleFunc("/tagger-dump-memory", getTaggerMemoryDump).Methods("GET")
 	r.HandleFunc("/workload-list", getWorkloadList).Methods("GET")
 	r.HandleFunc("/secrets", secretInfo).Methods("GET")
 	r.HandleFunc("/metadata/{payload}", metadataPayload).Methods("GET")
@@ -399,6 +400,17 @@ func getTaggerList(w http.ResponseWriter, r *http.Request) {
 	w.Write(jsonTags)
 }
 
+func getTaggerMemoryDump(w http.ResponseWriter, r *http.Request) {
+	response := tagger.DumpMemory()
+
+	jsonDump, err := json.Marshal(response)
+	if err != nil {
+		setJSONError(w, log.Errorf("Unable to marshal tagger memory dump response: %s", err), 500)
+		return
+	}
+	w.Write(jsonDump)
+}
+
 func getWorkloadList(w http.ResponseWriter, r *http.Request) {
 	verbose := false
 	params := r.URL.Query()
@@ -22,6 +22,7 @@ import (
 	"github.com/DataDog/datadog-agent/comp/core"
 	"github.com/DataDog/datadog-agent/comp/core/config"
 	"github.com/DataDog/datadog-agent/comp/core/log"
+	"github.com/DataDog/datadog-agent/pkg/networkdevice/profile/profiledefinition"
 	utilFunc "github.com/DataDog/datadog-agent/pkg/snmp/gosnmplib"
 	parse "github.com/DataDog/datadog-agent/pkg/snmp/snmpparse"
 	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
@@ -81,6 +82,14 @@ type cliParams struct {
 	unconnectedUDPSocket bool
 }
 
+// profileSchemaParams are the command-line arguments for the profile-schema subcommand
+type profileSchemaParams struct {
+	*command.GlobalParams
+
+	// output is the path of the file the schema is written to, stdout if empty
+	output string
+}
+
 // Commands returns a slice of subcommands for the 'agent' command.
 func Commands(globalParams *command.GlobalParams) []*cobra.Command {
 	cliParams := &cliParams{
@@ -131,9 +140,47 @@ func Commands(globalParams *command.GlobalParams) []*cobra.Command {
 	}
 	snmpCmd.AddCommand(snmpWalkCmd)
 
+	profileSchemaParams := &profileSchemaParams{
+		GlobalParams: globalParams,
+	}
+	profileSchemaCmd := &cobra.Command{
+		Use:   "profile-schema [OPTIONS]",
+		Short: "Print the JSON Schema of SNMP profiles",
+		Long:  ``,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fxutil.OneShot(profileSchema,
+				fx.Supply(profileSchemaParams),
+				fx.Supply(core.BundleParams{
+					ConfigParams: config.NewAgentParamsWithoutSecrets(globalParams.ConfFilePath),
+					LogParams:    log.LogForOneShot(command.LoggerName, "off", true)}),
+				core.Bundle,
+			)
+		},
+	}
+	profileSchemaCmd.Flags().StringVarP(&profileSchemaParams.output, "output", "o", "", "Write the schema to this file instead of stdout")
+	snmpCmd.AddCommand(profileSchemaCmd)
+
 	return []*cobra.Command{snmpCmd}
 }
 
+func profileSchema(params *profileSchemaParams) error {
+	schemaJSON, err := profiledefinition.GenerateJSONSchema()
+	if err != nil {
+		return fmt.Errorf("failed to generate profile JSON schema: %w", err)
+	}
+
+	if params.output == "" {
+		_, err = os.Stdout.Write(schemaJSON)
+		return err
+	}
+
+	if err := os.WriteFile(params.output, schemaJSON, 0644); err != nil {
+		return fmt.Errorf("failed to write profile JSON schema to %s: %w", params.output, err)
+	}
+	fmt.Printf("Profile JSON schema written to %s\n", params.output)
+	return nil
+}
+
 func snmpwalk(config config.Component, cliParams *cliParams) error {
 	var (
 		address      string
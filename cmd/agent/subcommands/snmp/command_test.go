@@ -36,3 +36,13 @@ func TestCommand(t *testing.T) {
 			require.True(t, cliParams.unconnectedUDPSocket)
 		})
 }
+
+func TestProfileSchemaCommand(t *testing.T) {
+	fxutil.TestOneShotSubcommand(t,
+		Commands(&command.GlobalParams{}),
+		[]string{"snmp", "profile-schema", "--output", "schema.json"},
+		profileSchema,
+		func(params *profileSchemaParams) {
+			require.Equal(t, "schema.json", params.output)
+		})
+}
@@ -31,6 +31,7 @@ import (
 	cmdstop "github.com/DataDog/datadog-agent/cmd/agent/subcommands/stop"
 	cmdstreamep "github.com/DataDog/datadog-agent/cmd/agent/subcommands/streamep"
 	cmdstreamlogs "github.com/DataDog/datadog-agent/cmd/agent/subcommands/streamlogs"
+	cmdtagger "github.com/DataDog/datadog-agent/cmd/agent/subcommands/tagger"
 	cmdtaggerlist "github.com/DataDog/datadog-agent/cmd/agent/subcommands/taggerlist"
 	cmdversion "github.com/DataDog/datadog-agent/cmd/agent/subcommands/version"
 	cmdworkloadlist "github.com/DataDog/datadog-agent/cmd/agent/subcommands/workloadlist"
@@ -59,6 +60,7 @@ func AgentSubcommands() []command.SubcommandFactory {
 		cmdstatus.Commands,
 		cmdstreamlogs.Commands,
 		cmdstreamep.Commands,
+		cmdtagger.Commands,
 		cmdtaggerlist.Commands,
 		cmdversion.Commands,
 		cmdworkloadlist.Commands,
@@ -0,0 +1,104 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package tagger implements 'agent tagger'.
+package tagger
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"go.uber.org/fx"
+
+	"github.com/DataDog/datadog-agent/cmd/agent/command"
+	"github.com/DataDog/datadog-agent/comp/core"
+	"github.com/DataDog/datadog-agent/comp/core/config"
+	"github.com/DataDog/datadog-agent/comp/core/log"
+	"github.com/DataDog/datadog-agent/pkg/api/util"
+	pkgconfig "github.com/DataDog/datadog-agent/pkg/config"
+	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
+
+	"github.com/spf13/cobra"
+)
+
+// dumpMemoryParams are the command-line arguments for the dump-memory subcommand
+type dumpMemoryParams struct {
+	*command.GlobalParams
+
+	// output is the path of the file the dump is written to
+	output string
+}
+
+// Commands returns a slice of subcommands for the 'agent' command.
+func Commands(globalParams *command.GlobalParams) []*cobra.Command {
+	dumpMemoryParams := &dumpMemoryParams{
+		GlobalParams: globalParams,
+	}
+
+	dumpMemoryCmd := &cobra.Command{
+		Use:   "dump-memory --output <path>",
+		Short: "Write the tag counts and memory estimate of the entities of a running agent's tagger to a JSON file",
+		Long:  ``,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return fxutil.OneShot(dumpMemory,
+				fx.Supply(dumpMemoryParams),
+				fx.Supply(command.GetDefaultCoreBundleParams(dumpMemoryParams.GlobalParams)),
+				core.Bundle,
+			)
+		},
+	}
+	dumpMemoryCmd.Flags().StringVarP(&dumpMemoryParams.output, "output", "o", "", "Path of the JSON file the dump is written to")
+	dumpMemoryCmd.MarkFlagRequired("output") //nolint:errcheck
+
+	taggerCmd := &cobra.Command{
+		Use:   "tagger",
+		Short: "Tagger tools",
+		Long:  ``,
+	}
+	taggerCmd.AddCommand(dumpMemoryCmd)
+
+	return []*cobra.Command{taggerCmd}
+}
+
+func dumpMemory(log log.Component, config config.Component, params *dumpMemoryParams) error {
+	c := util.GetClient(false) // FIX: get certificates right then make this true
+	ipcAddress, err := pkgconfig.GetIPCAddress()
+	if err != nil {
+		return err
+	}
+	urlstr := fmt.Sprintf("https://%v:%v/agent/tagger-dump-memory", ipcAddress, pkgconfig.Datadog.GetInt("cmd_port"))
+
+	// Set session token
+	if err := util.SetAuthToken(); err != nil {
+		return err
+	}
+
+	r, err := util.DoGet(c, urlstr, util.LeaveConnectionOpen)
+	if err != nil {
+		var errMap = make(map[string]string)
+		json.Unmarshal(r, &errMap) //nolint:errcheck
+		// If the error has been marshalled into a json object, check it and return it properly
+		if e, found := errMap["error"]; found {
+			err = fmt.Errorf(e)
+		}
+
+		fmt.Printf("Could not reach agent: %v \nMake sure the agent is running before requesting the tagger memory dump and contact support if you continue having issues. \n", err)
+		return err
+	}
+
+	var dump bytes.Buffer
+	if err := json.Indent(&dump, r, "", "  "); err != nil {
+		return fmt.Errorf("invalid tagger memory dump received from the agent: %w", err)
+	}
+
+	if err := os.WriteFile(params.output, dump.Bytes(), 0644); err != nil {
+		return fmt.Errorf("error while writing the file (is the location writable by the dd-agent user?): %w", err)
+	}
+
+	fmt.Println("Tagger memory dump written in:", params.output)
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 
 	"github.com/DataDog/datadog-agent/pkg/clusteragent/api"
 	"github.com/DataDog/datadog-agent/pkg/config"
+	"github.com/DataDog/datadog-agent/pkg/util/kubernetes"
 	as "github.com/DataDog/datadog-agent/pkg/util/kubernetes/apiserver"
 	apicommon "github.com/DataDog/datadog-agent/pkg/util/kubernetes/apiserver/common"
 	"github.com/DataDog/datadog-agent/pkg/util/log"
@@ -26,6 +27,7 @@ import (
 
 func installKubernetesMetadataEndpoints(r *mux.Router) {
 	r.HandleFunc("/annotations/node/{nodeName}", api.WithTelemetryWrapper("getNodeAnnotations", getNodeAnnotations)).Methods("GET")
+	r.HandleFunc("/annotations/namespace/{ns}", api.WithTelemetryWrapper("getNamespaceAnnotations", getNamespaceAnnotations)).Methods("GET")
 	r.HandleFunc("/tags/pod/{nodeName}/{ns}/{podName}", api.WithTelemetryWrapper("getPodMetadata", getPodMetadata)).Methods("GET")
 	r.HandleFunc("/tags/pod/{nodeName}", api.WithTelemetryWrapper("getPodMetadataForNode", getPodMetadataForNode)).Methods("GET")
 	r.HandleFunc("/tags/pod", api.WithTelemetryWrapper("getAllMetadata", getAllMetadata)).Methods("GET")
@@ -102,8 +104,8 @@ func getNodeAnnotations(w http.ResponseWriter, r *http.Request) {
 	getNodeMetadata(w, r, func(e *workloadmeta.KubernetesNode) map[string]string { return e.Annotations }, "annotations", config.Datadog.GetStringSlice("kubernetes_node_annotations_as_host_aliases"))
 }
 
-// getNamespaceLabels is only used when the node agent hits the DCA for the list of labels
-func getNamespaceLabels(w http.ResponseWriter, r *http.Request) {
+// getNamespaceMetadata is only used when the node agent hits the DCA for the list of labels or annotations
+func getNamespaceMetadata(w http.ResponseWriter, r *http.Request, f func(string) (map[string]string, error), what string, filterList []string) {
 	/*
 		Input
 			localhost:5001/api/v1/tags/namespace/default
@@ -122,27 +124,48 @@ func getNamespaceLabels(w http.ResponseWriter, r *http.Request) {
 	*/
 
 	vars := mux.Vars(r)
-	var labelBytes []byte
+	var dataBytes []byte
 	nsName := vars["ns"]
-	nsLabels, err := as.GetNamespaceLabels(nsName)
+	nsData, err := f(nsName)
 	if err != nil {
-		log.Errorf("Could not retrieve the namespace labels of %s: %v", nsName, err.Error()) //nolint:errcheck
+		log.Errorf("Could not retrieve the namespace %s of %s: %v", what, nsName, err.Error()) //nolint:errcheck
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	labelBytes, err = json.Marshal(nsLabels)
+
+	// Filter data to avoid returning too big useless data
+	if filterList != nil {
+		newNsData := make(map[string]string)
+		for _, key := range filterList {
+			if value, found := nsData[key]; found {
+				newNsData[key] = value
+			}
+		}
+		nsData = newNsData
+	}
+
+	dataBytes, err = json.Marshal(nsData)
 	if err != nil {
-		log.Errorf("Could not process the labels of the namespace %s from the informer's cache: %v", nsName, err.Error()) //nolint:errcheck
+		log.Errorf("Could not process the %s of the namespace %s from the informer's cache: %v", what, nsName, err.Error()) //nolint:errcheck
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
-	if len(labelBytes) > 0 {
+	if len(dataBytes) > 0 {
 		w.WriteHeader(http.StatusOK)
-		w.Write(labelBytes)
+		w.Write(dataBytes)
 		return
 	}
 	w.WriteHeader(http.StatusNotFound)
-	fmt.Fprintf(w, "Could not find labels on the namespace: %s", nsName)
+	fmt.Fprintf(w, "Could not find %s on the namespace: %s", what, nsName)
+}
+
+func getNamespaceLabels(w http.ResponseWriter, r *http.Request) {
+	getNamespaceMetadata(w, r, as.GetNamespaceLabels, "labels", nil)
+}
+
+// getNamespaceAnnotations only returns the annotations setting the standard tags of the pods of the namespace
+func getNamespaceAnnotations(w http.ResponseWriter, r *http.Request) {
+	getNamespaceMetadata(w, r, as.GetNamespaceAnnotations, "annotations", kubernetes.NamespaceStandardTagsAnnotKeys)
 }
 
 // getPodMetadata is only used when the node agent hits the DCA for the tags list.
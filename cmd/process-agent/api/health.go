@@ -0,0 +1,21 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package api
+
+import (
+	"net/http"
+)
+
+func healthHandler(deps APIServerDeps, w http.ResponseWriter, _ *http.Request) {
+	if deps.RTContainerCheck != nil {
+		if err := deps.RTContainerCheck.HealthCheck(); err != nil {
+			_ = deps.Log.Warn("realtime container check is unhealthy:", err)
+			writeError(err, http.StatusServiceUnavailable, w)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,74 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/DataDog/datadog-agent/comp/core/log"
+	"github.com/DataDog/datadog-agent/comp/process/rtcontainercheck"
+	"github.com/DataDog/datadog-agent/pkg/process/checks"
+	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
+)
+
+type fakeRTContainerCheck struct {
+	healthErr error
+}
+
+var _ rtcontainercheck.Component = (*fakeRTContainerCheck)(nil)
+
+func (f *fakeRTContainerCheck) Object() checks.Check { return nil }
+func (f *fakeRTContainerCheck) HealthCheck() error   { return f.healthErr }
+func (f *fakeRTContainerCheck) Pause()               {}
+func (f *fakeRTContainerCheck) Resume()              {}
+
+func TestHealthHandler(t *testing.T) {
+	logger := fxutil.Test[log.Component](t, log.MockModule)
+
+	tests := []struct {
+		name         string
+		rtCheck      rtcontainercheck.Component
+		expectedCode int
+	}{
+		{
+			name:         "no realtime container check",
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "healthy",
+			rtCheck:      &fakeRTContainerCheck{},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "unhealthy",
+			rtCheck:      &fakeRTContainerCheck{healthErr: errors.New("no rtcontainer check run completed")},
+			expectedCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deps := APIServerDeps{Log: logger}
+			if tt.rtCheck != nil {
+				deps.RTContainerCheck = tt.rtCheck
+			}
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/agent/health", nil)
+			injectDeps(deps, healthHandler)(rec, req)
+
+			assert.Equal(t, tt.expectedCode, rec.Code)
+			if tt.expectedCode != http.StatusOK {
+				assert.Contains(t, rec.Body.String(), "no rtcontainer check run completed")
+			}
+		})
+	}
+}
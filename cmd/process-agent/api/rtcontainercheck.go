@@ -0,0 +1,33 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package api
+
+import (
+	"errors"
+	"net/http"
+)
+
+var errRTContainerCheckNotAvailable = errors.New("realtime container check is not available")
+
+func pauseRTContainerCheckHandler(deps APIServerDeps, w http.ResponseWriter, _ *http.Request) {
+	if deps.RTContainerCheck == nil {
+		writeError(errRTContainerCheckNotAvailable, http.StatusNotFound, w)
+		return
+	}
+	deps.Log.Info("Pausing the realtime container check")
+	deps.RTContainerCheck.Pause()
+	w.WriteHeader(http.StatusOK)
+}
+
+func resumeRTContainerCheckHandler(deps APIServerDeps, w http.ResponseWriter, _ *http.Request) {
+	if deps.RTContainerCheck == nil {
+		writeError(errRTContainerCheckNotAvailable, http.StatusNotFound, w)
+		return
+	}
+	deps.Log.Info("Resuming the realtime container check")
+	deps.RTContainerCheck.Resume()
+	w.WriteHeader(http.StatusOK)
+}
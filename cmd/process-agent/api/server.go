@@ -13,6 +13,7 @@ import (
 
 	"github.com/DataDog/datadog-agent/comp/core/config"
 	"github.com/DataDog/datadog-agent/comp/core/log"
+	"github.com/DataDog/datadog-agent/comp/process/rtcontainercheck"
 	settingshttp "github.com/DataDog/datadog-agent/pkg/config/settings/http"
 )
 
@@ -21,6 +22,8 @@ type APIServerDeps struct {
 
 	Config config.Component
 	Log    log.Component
+
+	RTContainerCheck rtcontainercheck.Component `optional:"true"`
 }
 
 func injectDeps(deps APIServerDeps, handler func(APIServerDeps, http.ResponseWriter, *http.Request)) http.HandlerFunc {
@@ -36,6 +39,9 @@ func SetupAPIServerHandlers(deps APIServerDeps, r *mux.Router) {
 	r.HandleFunc("/config/{setting}", settingshttp.Server.GetValue).Methods("GET")
 	r.HandleFunc("/config/{setting}", settingshttp.Server.SetValue).Methods("POST")
 	r.HandleFunc("/agent/status", injectDeps(deps, statusHandler)).Methods("GET")
+	r.HandleFunc("/agent/health", injectDeps(deps, healthHandler)).Methods("GET")
+	r.HandleFunc("/agent/rtcontainercheck/pause", injectDeps(deps, pauseRTContainerCheckHandler)).Methods("POST")
+	r.HandleFunc("/agent/rtcontainercheck/resume", injectDeps(deps, resumeRTContainerCheckHandler)).Methods("POST")
 	r.HandleFunc("/agent/tagger-list", injectDeps(deps, getTaggerList)).Methods("GET")
 	r.HandleFunc("/agent/workload-list/short", getShortWorkloadList).Methods("GET")
 	r.HandleFunc("/agent/workload-list/verbose", getVerboseWorkloadList).Methods("GET")
@@ -0,0 +1,221 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// trapsender is a development tool sending SNMP traps to the traps listener of the Agent,
+// in place of the `snmptrap` command of net-snmp.
+//
+// This binary is not part of the Datadog Agent package, nor is it meant to be used as such.
+//
+// Example, sending an SNMPv2c trap with an integer variable:
+//
+//	go run ./cmd/trapsender -oid 1.3.6.1.4.1.8072.2.3.2.1 -type integer -value 42 -count 10
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+
+	"github.com/DataDog/datadog-agent/pkg/snmp/gosnmplib"
+	"github.com/DataDog/datadog-agent/pkg/snmp/traps"
+)
+
+const (
+	sysUpTimeInstanceOID = "1.3.6.1.2.1.1.3.0"
+	snmpTrapOID          = "1.3.6.1.6.3.1.1.4.1.0"
+	// snmpTrapsOID is the prefix of the generic traps, eg. coldStart
+	snmpTrapsOID = "1.3.6.1.6.3.1.1.5"
+)
+
+var (
+	host     = flag.String("host", "127.0.0.1", "host of the traps listener")
+	port     = flag.Uint("port", 9162, "port of the traps listener")
+	version  = flag.String("version", "2c", "SNMP version of the traps: 1, 2c or 3")
+	trapOID  = flag.String("trap-oid", "1.3.6.1.4.1.8072.2.3.0.1", "OID of the trap, converted to enterprise and specific trap for SNMPv1")
+	oid      = flag.String("oid", "1.3.6.1.4.1.8072.2.3.2.1", "OID of the trap variable")
+	varType  = flag.String("type", "integer", "type of the trap variable: integer, string, oid, ipaddress, counter32, counter64, gauge32 or timeticks")
+	value    = flag.String("value", "1", "value of the trap variable")
+	count    = flag.Int("count", 1, "number of traps to send")
+	interval = flag.Duration("interval", 0, "delay between two traps")
+
+	community = flag.String("community", "public", "community string, for SNMPv1 and SNMPv2c")
+
+	user          = flag.String("user", "", "SNMPv3 user")
+	authProtocol  = flag.String("auth-protocol", "", "SNMPv3 authentication protocol: MD5, SHA, SHA224, SHA256, SHA384 or SHA512")
+	authKey       = flag.String("auth-key", "", "SNMPv3 authentication key")
+	privProtocol  = flag.String("priv-protocol", "", "SNMPv3 privacy protocol: DES, AES, AES192, AES192C, AES256 or AES256C")
+	privKey       = flag.String("priv-key", "", "SNMPv3 privacy key")
+	agentHostname = flag.String("agent-hostname", "", "hostname of the Agent, used to compute the SNMPv3 authoritative engine ID of its listener")
+)
+
+func main() {
+	flag.Parse()
+
+	params, err := buildParams()
+	if err != nil {
+		log.Fatal(err)
+	}
+	variable, err := buildVariable(*oid, *varType, *value)
+	if err != nil {
+		log.Fatal(err)
+	}
+	trap, err := buildTrap(params.Version, *trapOID, variable)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if err := params.Connect(); err != nil {
+		log.Fatalf("unable to connect to %s:%d: %s", params.Target, params.Port, err)
+	}
+	defer params.Conn.Close()
+
+	for i := 0; i < *count; i++ {
+		if i > 0 && *interval > 0 {
+			time.Sleep(*interval)
+		}
+		if _, err := params.SendTrap(trap); err != nil {
+			log.Fatalf("unable to send trap: %s", err)
+		}
+	}
+	log.Printf("sent %d SNMPv%s trap(s) to %s:%d", *count, *version, params.Target, params.Port)
+}
+
+func buildParams() (*gosnmp.GoSNMP, error) {
+	params := &gosnmp.GoSNMP{
+		Target:    *host,
+		Port:      uint16(*port),
+		Transport: "udp",
+		Community: *community,
+		Timeout:   1 * time.Second, // Must be non-zero when sending traps.
+		Retries:   1,               // Must be non-zero when sending traps.
+	}
+	switch *version {
+	case "1":
+		params.Version = gosnmp.Version1
+	case "2c":
+		params.Version = gosnmp.Version2c
+	case "3":
+		params.Version = gosnmp.Version3
+		if *user == "" {
+			return nil, fmt.Errorf("-user is required for SNMPv3")
+		}
+		auth, err := gosnmplib.GetAuthProtocol(*authProtocol)
+		if err != nil {
+			return nil, err
+		}
+		priv, err := gosnmplib.GetPrivProtocol(*privProtocol)
+		if err != nil {
+			return nil, err
+		}
+		params.SecurityModel = gosnmp.UserSecurityModel
+		params.MsgFlags = gosnmp.NoAuthNoPriv
+		if auth != gosnmp.NoAuth {
+			params.MsgFlags = gosnmp.AuthNoPriv
+			if priv != gosnmp.NoPriv {
+				params.MsgFlags = gosnmp.AuthPriv
+			}
+		}
+		params.SecurityParameters = &gosnmp.UsmSecurityParameters{
+			UserName:                 *user,
+			AuthoritativeEngineID:    traps.AuthoritativeEngineID(*agentHostname),
+			AuthenticationProtocol:   auth,
+			AuthenticationPassphrase: *authKey,
+			PrivacyProtocol:          priv,
+			PrivacyPassphrase:        *privKey,
+		}
+	default:
+		return nil, fmt.Errorf("unsupported SNMP version %q, expected 1, 2c or 3", *version)
+	}
+	return params, nil
+}
+
+// buildVariable parses the value of a trap variable according to its type
+func buildVariable(oid string, varType string, value string) (gosnmp.SnmpPDU, error) {
+	variable := gosnmp.SnmpPDU{Name: oid}
+	var err error
+	switch strings.ToLower(varType) {
+	case "integer":
+		variable.Type = gosnmp.Integer
+		variable.Value, err = strconv.Atoi(value)
+	case "string":
+		variable.Type = gosnmp.OctetString
+		variable.Value = value
+	case "oid":
+		variable.Type = gosnmp.ObjectIdentifier
+		variable.Value = value
+	case "ipaddress":
+		variable.Type = gosnmp.IPAddress
+		if net.ParseIP(value).To4() == nil {
+			err = fmt.Errorf("invalid IPv4 address %q", value)
+		}
+		variable.Value = value
+	case "counter32", "gauge32", "timeticks":
+		variable.Type = map[string]gosnmp.Asn1BER{
+			"counter32": gosnmp.Counter32,
+			"gauge32":   gosnmp.Gauge32,
+			"timeticks": gosnmp.TimeTicks,
+		}[strings.ToLower(varType)]
+		var v uint64
+		v, err = strconv.ParseUint(value, 10, 32)
+		variable.Value = uint32(v)
+	case "counter64":
+		variable.Type = gosnmp.Counter64
+		variable.Value, err = strconv.ParseUint(value, 10, 64)
+	default:
+		return variable, fmt.Errorf("unsupported variable type %q", varType)
+	}
+	if err != nil {
+		return variable, fmt.Errorf("invalid %s value %q: %w", varType, value, err)
+	}
+	return variable, nil
+}
+
+// buildTrap builds a trap with the given OID and variable. SNMPv1 traps are built
+// from the trap OID as described in RFC 3584, section 3.2.
+func buildTrap(version gosnmp.SnmpVersion, oid string, variable gosnmp.SnmpPDU) (gosnmp.SnmpTrap, error) {
+	oid = traps.NormalizeOID(oid)
+	if !traps.IsValidOID(oid) || !strings.Contains(oid, ".") {
+		return gosnmp.SnmpTrap{}, fmt.Errorf("invalid trap OID %q", oid)
+	}
+	uptime := uint32(time.Now().Unix() % 100000)
+
+	if version != gosnmp.Version1 {
+		return gosnmp.SnmpTrap{
+			Variables: []gosnmp.SnmpPDU{
+				{Name: sysUpTimeInstanceOID, Type: gosnmp.TimeTicks, Value: uptime},
+				{Name: snmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: oid},
+				variable,
+			},
+		}, nil
+	}
+
+	lastDot := strings.LastIndex(oid, ".")
+	enterprise := oid[:lastDot]
+	specificTrap, err := strconv.Atoi(oid[lastDot+1:])
+	if err != nil {
+		return gosnmp.SnmpTrap{}, fmt.Errorf("invalid trap OID %q: %w", oid, err)
+	}
+	trap := gosnmp.SnmpTrap{
+		Enterprise:   enterprise,
+		AgentAddress: "127.0.0.1",
+		Timestamp:    uint(uptime),
+		Variables:    []gosnmp.SnmpPDU{variable},
+	}
+	if enterprise == snmpTrapsOID && specificTrap >= 1 && specificTrap <= 6 {
+		// generic traps, eg. coldStart (1.3.6.1.6.3.1.1.5.1) is generic trap 0
+		trap.GenericTrap = specificTrap - 1
+		return trap, nil
+	}
+	trap.GenericTrap = 6 // enterpriseSpecific
+	trap.SpecificTrap = specificTrap
+	trap.Enterprise = strings.TrimSuffix(enterprise, ".0")
+	return trap, nil
+}
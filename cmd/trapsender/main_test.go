@@ -0,0 +1,81 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package main
+
+import (
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildVariable(t *testing.T) {
+	tests := []struct {
+		varType       string
+		value         string
+		expectedType  gosnmp.Asn1BER
+		expectedValue interface{}
+	}{
+		{"integer", "-12", gosnmp.Integer, -12},
+		{"string", "totoro", gosnmp.OctetString, "totoro"},
+		{"oid", "1.3.6.1.2.1.2.2.1.1", gosnmp.ObjectIdentifier, "1.3.6.1.2.1.2.2.1.1"},
+		{"ipaddress", "10.0.0.1", gosnmp.IPAddress, "10.0.0.1"},
+		{"Counter32", "42", gosnmp.Counter32, uint32(42)},
+		{"gauge32", "42", gosnmp.Gauge32, uint32(42)},
+		{"timeticks", "42", gosnmp.TimeTicks, uint32(42)},
+		{"counter64", "18446744073709551615", gosnmp.Counter64, uint64(18446744073709551615)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.varType, func(t *testing.T) {
+			variable, err := buildVariable("1.3.6.1.4.1.8072.2.3.2.1", tt.varType, tt.value)
+			require.NoError(t, err)
+			assert.Equal(t, "1.3.6.1.4.1.8072.2.3.2.1", variable.Name)
+			assert.Equal(t, tt.expectedType, variable.Type)
+			assert.Equal(t, tt.expectedValue, variable.Value)
+		})
+	}
+}
+
+func TestBuildVariableInvalid(t *testing.T) {
+	for _, tt := range [][2]string{
+		{"integer", "one"},
+		{"ipaddress", "::1"},
+		{"counter32", "4294967296"},
+		{"bits", "0x01"},
+	} {
+		_, err := buildVariable("1.3.6.1.4.1.8072.2.3.2.1", tt[0], tt[1])
+		assert.Error(t, err, tt)
+	}
+}
+
+func TestBuildTrap(t *testing.T) {
+	variable := gosnmp.SnmpPDU{Name: "1.3.6.1.4.1.8072.2.3.2.1", Type: gosnmp.Integer, Value: 1}
+
+	trap, err := buildTrap(gosnmp.Version2c, ".1.3.6.1.4.1.8072.2.3.0.1", variable)
+	require.NoError(t, err)
+	require.Len(t, trap.Variables, 3)
+	assert.Equal(t, sysUpTimeInstanceOID, trap.Variables[0].Name)
+	assert.Equal(t, gosnmp.SnmpPDU{Name: snmpTrapOID, Type: gosnmp.ObjectIdentifier, Value: "1.3.6.1.4.1.8072.2.3.0.1"}, trap.Variables[1])
+	assert.Equal(t, variable, trap.Variables[2])
+
+	trap, err = buildTrap(gosnmp.Version1, "1.3.6.1.4.1.8072.2.3.0.1", variable)
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.6.1.4.1.8072.2.3", trap.Enterprise)
+	assert.Equal(t, 6, trap.GenericTrap)
+	assert.Equal(t, 1, trap.SpecificTrap)
+	assert.Equal(t, []gosnmp.SnmpPDU{variable}, trap.Variables)
+
+	// linkDown
+	trap, err = buildTrap(gosnmp.Version1, "1.3.6.1.6.3.1.1.5.3", variable)
+	require.NoError(t, err)
+	assert.Equal(t, snmpTrapsOID, trap.Enterprise)
+	assert.Equal(t, 2, trap.GenericTrap)
+	assert.Equal(t, 0, trap.SpecificTrap)
+
+	_, err = buildTrap(gosnmp.Version2c, "not-an-oid", variable)
+	assert.Error(t, err)
+}
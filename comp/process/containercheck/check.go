@@ -6,6 +6,8 @@
 package containercheck
 
 import (
+	"time"
+
 	"go.uber.org/fx"
 
 	"github.com/DataDog/datadog-agent/comp/core/config"
@@ -23,6 +25,7 @@ type dependencies struct {
 	fx.In
 
 	Config config.Component
+	Params Params `optional:"true"`
 }
 
 type result struct {
@@ -32,10 +35,24 @@ type result struct {
 	Component Component
 }
 
+// Option customizes the container check
+type Option func(*check)
+
+// WithInterval runs the container check every interval, regardless of the configured interval.
+// It is supplied to the component with NewParams.
+func WithInterval(interval time.Duration) Option {
+	return func(c *check) {
+		c.containerCheck.SetIntervalOverride(interval)
+	}
+}
+
 func newCheck(deps dependencies) result {
 	c := &check{
 		containerCheck: checks.NewContainerCheck(deps.Config),
 	}
+	for _, opt := range deps.Params.Options {
+		opt(c)
+	}
 	return result{
 		Check: types.ProvidesCheck{
 			CheckComponent: c,
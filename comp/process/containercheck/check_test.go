@@ -0,0 +1,41 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package containercheck
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/fx"
+
+	"github.com/DataDog/datadog-agent/comp/core/config"
+	"github.com/DataDog/datadog-agent/pkg/process/checks"
+	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
+)
+
+func TestCheckInterval(t *testing.T) {
+	cfg := fxutil.Test[config.Component](t, config.MockModule)
+
+	t.Run("default", func(t *testing.T) {
+		c := newCheck(dependencies{Config: cfg}).Component
+		assert.Equal(t, checks.ContainerCheckDefaultInterval, checks.GetCheckInterval(cfg, c.Object()))
+	})
+
+	t.Run("override", func(t *testing.T) {
+		c := newCheck(dependencies{Config: cfg, Params: NewParams(WithInterval(500 * time.Millisecond))}).Component
+		assert.Equal(t, 500*time.Millisecond, checks.GetCheckInterval(cfg, c.Object()))
+	})
+
+	t.Run("override supplied to the module", func(t *testing.T) {
+		c := fxutil.Test[Component](t, fx.Options(
+			config.MockModule,
+			Module,
+			fx.Supply(NewParams(WithInterval(500*time.Millisecond))),
+		))
+		assert.Equal(t, 500*time.Millisecond, checks.GetCheckInterval(cfg, c.Object()))
+	})
+}
@@ -0,0 +1,19 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package containercheck
+
+// Params defines the parameters for the container check component. They are
+// optional: without them, the check uses the configured interval.
+type Params struct {
+	// Options customize the container check
+	Options []Option
+}
+
+// NewParams creates Params applying the given options to the container check,
+// e.g. fx.Supply(containercheck.NewParams(containercheck.WithInterval(time.Second)))
+func NewParams(opts ...Option) Params {
+	return Params{Options: opts}
+}
@@ -6,6 +6,9 @@
 package rtcontainercheck
 
 import (
+	"fmt"
+	"time"
+
 	"go.uber.org/fx"
 
 	"github.com/DataDog/datadog-agent/comp/core/config"
@@ -15,8 +18,16 @@ import (
 
 var _ types.CheckComponent = (*check)(nil)
 
+// healthCheckMaxMissedIntervals is the number of check intervals a run can go on for
+// without the previous run having completed before the check is considered unhealthy
+const healthCheckMaxMissedIntervals = 3
+
 type check struct {
 	rtContainerCheck *checks.RTContainerCheck
+	config           config.Component
+	// overridable for tests
+	lastRun func() (start, end time.Time)
+	now     func() time.Time
 }
 
 type dependencies struct {
@@ -35,7 +46,10 @@ type result struct {
 func newCheck(deps dependencies) result {
 	c := &check{
 		rtContainerCheck: checks.NewRTContainerCheck(deps.Config),
+		config:           deps.Config,
+		now:              time.Now,
 	}
+	c.lastRun = c.rtContainerCheck.LastRun
 	return result{
 		Check: types.ProvidesCheck{
 			CheckComponent: c,
@@ -47,3 +61,37 @@ func newCheck(deps dependencies) result {
 func (c *check) Object() checks.Check {
 	return c.rtContainerCheck
 }
+
+// HealthCheck implements Component#HealthCheck.
+// The runner only schedules the check in realtime mode, and runs it synchronously: a collection
+// loop falling behind or stuck shows up as a run in progress with no run completed for a while.
+func (c *check) HealthCheck() error {
+	start, end := c.lastRun()
+	if !start.After(end) {
+		// not running, either between two runs or because realtime mode is disabled
+		return nil
+	}
+
+	lastCompleted := end
+	if lastCompleted.IsZero() {
+		// the first run is in progress
+		lastCompleted = start
+	}
+
+	interval := checks.GetInterval(c.config, checks.RTContainerCheckName)
+	if sinceCompleted := c.now().Sub(lastCompleted); sinceCompleted > healthCheckMaxMissedIntervals*interval {
+		return fmt.Errorf("no %s check run completed in the last %s, more than %d times its %s interval",
+			checks.RTContainerCheckName, sinceCompleted.Truncate(time.Millisecond), healthCheckMaxMissedIntervals, interval)
+	}
+	return nil
+}
+
+// Pause implements Component#Pause
+func (c *check) Pause() {
+	c.rtContainerCheck.Pause()
+}
+
+// Resume implements Component#Resume
+func (c *check) Resume() {
+	c.rtContainerCheck.Resume()
+}
@@ -0,0 +1,83 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package rtcontainercheck
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/DataDog/datadog-agent/comp/core/config"
+	"github.com/DataDog/datadog-agent/pkg/process/checks"
+	"github.com/DataDog/datadog-agent/pkg/util/fxutil"
+)
+
+func TestHealthCheck(t *testing.T) {
+	cfg := fxutil.Test[config.Component](t, config.MockModule)
+	now := time.Now()
+	interval := checks.RTContainerCheckDefaultInterval
+
+	tests := []struct {
+		name          string
+		start         time.Time
+		end           time.Time
+		expectHealthy bool
+	}{
+		{
+			name:          "never ran",
+			expectHealthy: true,
+		},
+		{
+			name:          "first run in progress",
+			start:         now.Add(-interval),
+			expectHealthy: true,
+		},
+		{
+			name:          "first run stuck",
+			start:         now.Add(-4 * interval),
+			expectHealthy: false,
+		},
+		{
+			name:          "between runs",
+			start:         now.Add(-interval),
+			end:           now.Add(-interval / 2),
+			expectHealthy: true,
+		},
+		{
+			name:          "realtime mode disabled long ago",
+			start:         now.Add(-time.Hour),
+			end:           now.Add(-time.Hour + time.Second),
+			expectHealthy: true,
+		},
+		{
+			name:          "run in progress after a recent completion",
+			start:         now.Add(-interval),
+			end:           now.Add(-2 * interval),
+			expectHealthy: true,
+		},
+		{
+			name:          "no run completed within 3 intervals",
+			start:         now.Add(-interval),
+			end:           now.Add(-4 * interval),
+			expectHealthy: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newCheck(dependencies{Config: cfg}).Component.(*check)
+			c.lastRun = func() (time.Time, time.Time) { return tt.start, tt.end }
+			c.now = func() time.Time { return now }
+
+			if tt.expectHealthy {
+				assert.NoError(t, c.HealthCheck())
+			} else {
+				assert.Error(t, c.HealthCheck())
+			}
+		})
+	}
+}
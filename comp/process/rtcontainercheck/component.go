@@ -17,6 +17,15 @@ import (
 
 type Component interface {
 	types.CheckComponent
+
+	// HealthCheck returns an error when a run of the realtime container check is in progress
+	// and no run completed within the last 3 collection intervals.
+	HealthCheck() error
+
+	// Pause stops the realtime container data collection until Resume is called
+	Pause()
+	// Resume restarts the realtime container data collection after Pause
+	Resume()
 }
 
 // Module defines the fx options for this component.
@@ -505,8 +505,14 @@ func NewCheckConfig(rawInstance integration.Data, rawInitConfig integration.Data
 		}
 		profiles = defaultProfiles
 	}
-	for _, profileDef := range profiles {
-		profiledefinition.NormalizeMetrics(profileDef.Definition.Metrics)
+	for name, profileDef := range profiles {
+		warnings, err := profiledefinition.NormalizeMetrics(profileDef.Definition.Metrics)
+		for _, warning := range warnings {
+			log.Warnf("profile `%s`: %s", name, warning)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to normalize metrics of profile `%s`: %s", name, err)
+		}
 	}
 	c.Profiles = profiles
 
@@ -529,9 +535,16 @@ func NewCheckConfig(rawInstance integration.Data, rawInitConfig integration.Data
 	}
 	// Always request uptime
 	c.RequestedMetrics = append(c.RequestedMetrics, uptimeMetricConfig)
-	profiledefinition.NormalizeMetrics(c.RequestedMetrics)
 	c.RequestedMetricTags = instance.MetricTags
-	errors := ValidateEnrichMetrics(c.RequestedMetrics)
+	var errors []string
+	warnings, err := profiledefinition.NormalizeMetrics(c.RequestedMetrics)
+	for _, warning := range warnings {
+		log.Warn(warning)
+	}
+	if err != nil {
+		errors = append(errors, err.Error())
+	}
+	errors = append(errors, ValidateEnrichMetrics(c.RequestedMetrics)...)
 	errors = append(errors, ValidateEnrichMetricTags(c.RequestedMetricTags)...)
 	if len(errors) > 0 {
 		return nil, fmt.Errorf("validation errors: %s", strings.Join(errors, "\n"))
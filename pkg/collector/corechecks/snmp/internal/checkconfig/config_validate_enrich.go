@@ -8,6 +8,7 @@ package checkconfig
 import (
 	"fmt"
 	"regexp"
+	"strings"
 
 	"github.com/DataDog/datadog-agent/pkg/util/log"
 
@@ -52,7 +53,13 @@ const (
 func ValidateEnrichMetricTags(metricTags []profiledefinition.MetricTagConfig) []string {
 	var errors []string
 	for i := range metricTags {
-		errors = append(errors, validateEnrichMetricTag(&metricTags[i])...)
+		metricTag := &metricTags[i]
+		if err := metricTag.Validate(); err != nil {
+			for _, errMsg := range strings.Split(err.Error(), "\n") {
+				errors = append(errors, fmt.Sprintf("metric tag `%s`: %s", metricTagName(metricTag), errMsg))
+			}
+		}
+		errors = append(errors, validateEnrichMetricTag(metricTag)...)
 	}
 	return errors
 }
@@ -72,10 +79,12 @@ func ValidateEnrichMetrics(metrics []profiledefinition.MetricsConfig) []string {
 		}
 		if metricConfig.IsScalar() {
 			errors = append(errors, validateEnrichSymbol(&metricConfig.Symbol, ScalarSymbol)...)
+			errors = append(errors, validateFlagStreamSymbol(metricConfig, metricConfig.Symbol)...)
 		}
 		if metricConfig.IsColumn() {
 			for j := range metricConfig.Symbols {
 				errors = append(errors, validateEnrichSymbol(&metricConfig.Symbols[j], ColumnSymbol)...)
+				errors = append(errors, validateFlagStreamSymbol(metricConfig, metricConfig.Symbols[j])...)
 			}
 			if len(metricConfig.MetricTags) == 0 {
 				errors = append(errors, fmt.Sprintf("column symbols doesn't have a 'metric_tags' section (%+v), all its metrics will use the same tags; "+
@@ -134,13 +143,25 @@ func validateEnrichMetadata(metadata profiledefinition.MetadataConfig) []string
 	return errors
 }
 
+// validateFlagStreamSymbol checks the format of the flag stream symbols that
+// don't use `options.placement`
+func validateFlagStreamSymbol(metricConfig *profiledefinition.MetricsConfig, symbol profiledefinition.SymbolConfig) []string {
+	if metricConfig.Options.Placement != 0 || !metricConfig.IsFlagStream(symbol) {
+		return nil
+	}
+	if err := profiledefinition.ValidateFlagStreamConfig(symbol); err != nil {
+		return []string{err.Error()}
+	}
+	return nil
+}
+
 func validateEnrichSymbol(symbol *profiledefinition.SymbolConfig, symbolContext SymbolContext) []string {
 	var errors []string
 	if symbol.Name == "" {
 		errors = append(errors, fmt.Sprintf("symbol name missing: name=`%s` oid=`%s`", symbol.Name, symbol.OID))
 	}
 	if symbol.OID == "" {
-		if symbolContext == ColumnSymbol && !symbol.ConstantValueOne {
+		if symbolContext == ColumnSymbol && !symbol.IsConstantValueOne() {
 			errors = append(errors, fmt.Sprintf("symbol oid or send_as_one missing: name=`%s` oid=`%s`", symbol.Name, symbol.OID))
 		} else if symbolContext != ColumnSymbol {
 			errors = append(errors, fmt.Sprintf("symbol oid missing: name=`%s` oid=`%s`", symbol.Name, symbol.OID))
@@ -162,7 +183,7 @@ func validateEnrichSymbol(symbol *profiledefinition.SymbolConfig, symbolContext
 			symbol.MatchPatternCompiled = pattern
 		}
 	}
-	if symbolContext != ColumnSymbol && symbol.ConstantValueOne {
+	if symbolContext != ColumnSymbol && symbol.IsConstantValueOne() {
 		errors = append(errors, "`constant_value_one` cannot be used outside of tables")
 	}
 	if (symbolContext != ColumnSymbol && symbolContext != ScalarSymbol) && symbol.MetricType != "" {
@@ -196,3 +217,17 @@ func validateEnrichMetricTag(metricTag *profiledefinition.MetricTagConfig) []str
 	}
 	return errors
 }
+
+// metricTagName returns a name identifying the metric tag in error messages
+func metricTagName(metricTag *profiledefinition.MetricTagConfig) string {
+	switch {
+	case metricTag.Tag != "":
+		return metricTag.Tag
+	case metricTag.Column.Name != "":
+		return metricTag.Column.Name
+	case metricTag.Name != "":
+		return metricTag.Name
+	default:
+		return metricTag.SymbolTag
+	}
+}
@@ -107,6 +107,36 @@ func Test_ValidateEnrichMetrics(t *testing.T) {
 				"either a table symbol or a scalar symbol must be provided",
 			},
 		},
+		{
+			name: "flag stream symbols",
+			metrics: []profiledefinition.MetricsConfig{
+				{
+					Symbol:     profiledefinition.SymbolConfig{OID: "1.2.3", Name: "withPlacement"},
+					MetricType: profiledefinition.ProfileMetricTypeFlagStream,
+					Options:    profiledefinition.MetricsConfigOption{Placement: 1, MetricSuffix: "foo"},
+				},
+				{
+					Symbol:     profiledefinition.SymbolConfig{OID: "1.2.3", Name: "withFormat", Format: "flags:0:2"},
+					MetricType: profiledefinition.ProfileMetricTypeFlagStream,
+				},
+				{
+					Symbol:     profiledefinition.SymbolConfig{OID: "1.2.3", Name: "withoutFormat"},
+					MetricType: profiledefinition.ProfileMetricTypeFlagStream,
+				},
+				{
+					Symbols: []profiledefinition.SymbolConfig{
+						{OID: "1.2.4", Name: "withInvalidFormat", Format: "flags:3:1", MetricType: profiledefinition.ProfileMetricTypeFlagStream},
+					},
+					MetricTags: profiledefinition.MetricTagConfigList{
+						profiledefinition.MetricTagConfig{Tag: "index", Index: 1},
+					},
+				},
+			},
+			expectedErrors: []string{
+				"symbol `withoutFormat`: flag stream format is missing",
+				"symbol `withInvalidFormat`: invalid flag stream format `flags:3:1`: start position 3 must be lower than end position 1",
+			},
+		},
 		{
 			name: "table column symbol name missing",
 			metrics: []profiledefinition.MetricsConfig{
@@ -813,3 +843,68 @@ func Test_validateEnrichMetadata(t *testing.T) {
 		})
 	}
 }
+
+func Test_ValidateEnrichMetricTags(t *testing.T) {
+	tests := []struct {
+		name           string
+		metricTags     []profiledefinition.MetricTagConfig
+		expectedErrors []string
+	}{
+		{
+			name: "valid metric tags",
+			metricTags: []profiledefinition.MetricTagConfig{
+				{Tag: "snmp_host", OID: "1.3.6.1.2.1.1.5.0", Name: "sysName"},
+				{OID: "1.3.6.1.2.1.1.5.0", Name: "sysName", Match: "(\\w+)", Tags: map[string]string{"host": "\\1"}},
+			},
+		},
+		{
+			name: "OID without symbol",
+			metricTags: []profiledefinition.MetricTagConfig{
+				{Tag: "snmp_host", OID: "1.3.6.1.2.1.1.5.0"},
+			},
+			expectedErrors: []string{
+				"metric tag `snmp_host`: `OID` (`1.3.6.1.2.1.1.5.0`) is set without `symbol`",
+			},
+		},
+		{
+			name: "column and symbol both set",
+			metricTags: []profiledefinition.MetricTagConfig{
+				{
+					Tag:    "snmp_host",
+					OID:    "1.3.6.1.2.1.1.5.0",
+					Name:   "sysName",
+					Column: profiledefinition.SymbolConfig{OID: "1.3.6.1.2.1.31.1.1.1.1", Name: "ifName"},
+				},
+			},
+			expectedErrors: []string{
+				"metric tag `snmp_host`: both `column` (`ifName`) and `symbol` (`sysName`) are set",
+			},
+		},
+		{
+			name: "empty tag",
+			metricTags: []profiledefinition.MetricTagConfig{
+				{OID: "1.3.6.1.2.1.1.5.0", Name: "sysName"},
+			},
+			expectedErrors: []string{
+				"metric tag `sysName`: `tag` is empty",
+			},
+		},
+		{
+			// only a warning is logged, as before tags were validated
+			name: "mapping without tag",
+			metricTags: []profiledefinition.MetricTagConfig{
+				{
+					OID:     "1.3.6.1.2.1.1.7.0",
+					Name:    "sysServices",
+					Mapping: profiledefinition.ListMap[string]{"72": "application"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errors := ValidateEnrichMetricTags(tt.metricTags)
+			assert.Equal(t, tt.expectedErrors, errors)
+		})
+	}
+}
@@ -0,0 +1,295 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package checkconfig
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/config"
+	httputils "github.com/DataDog/datadog-agent/pkg/util/http"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+
+	"github.com/DataDog/datadog-agent/pkg/networkdevice/profile/profiledefinition"
+)
+
+const (
+	remoteProfilesTimeout = 30 * time.Second
+
+	// state and decompressed copy of the last loaded profiles bundle, stored in run_path
+	profilesBundleStateFile = "snmp_profiles_bundle_state.json"
+	profilesBundleCacheFile = "snmp_profiles_bundle.json"
+
+	profileBundleVersionKey = "profile_bundle_version"
+)
+
+// profilesBundleState identifies the profiles bundle stored in the cache file
+type profilesBundleState struct {
+	Version string `json:"version"`
+	// SHA-256 checksum of the cache file
+	Checksum string `json:"checksum"`
+}
+
+// loadRemoteProfilesFromConfig loads the profiles bundle from bundleURL, using
+// the agent proxy settings.
+func loadRemoteProfilesFromConfig(bundleURL string) (profileConfigMap, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), remoteProfilesTimeout)
+	defer cancel()
+
+	httpClient := &http.Client{
+		Transport: httputils.CreateHTTPTransport(),
+	}
+	return loadRemoteProfiles(ctx, bundleURL, httpClient)
+}
+
+// loadRemoteProfiles fetches a gzipped JSON profiles bundle from url, and verifies
+// it against the SHA-256 checksum served at `snmp_profiles_bundle_sha256_url`
+// (defaults to the bundle url with a `.sha256` suffix).
+// The decompressed bundle is cached in run_path. It is used instead of decompressing the
+// bundle again as long as its version doesn't change, and when no valid bundle can be
+// fetched.
+func loadRemoteProfiles(ctx context.Context, url string, httpClient *http.Client) (profileConfigMap, error) {
+	bundle, err := fetchProfilesBundle(ctx, url, httpClient)
+	if err != nil {
+		if profiles, state, ok := loadCachedProfilesBundle(); ok {
+			log.Warnf("%s, using the cached profiles bundle version `%s`", err, state.Version)
+			return profiles, nil
+		}
+		return nil, err
+	}
+
+	version, err := readProfilesBundleVersion(bundle)
+	if err != nil {
+		return nil, err
+	}
+	// bundles without version can't be told apart, they are always decompressed
+	if version != "" {
+		if profiles, state, ok := loadCachedProfilesBundle(); ok && state.Version == version {
+			log.Debugf("loaded profiles bundle version `%s` from cache", version)
+			return profiles, nil
+		}
+	}
+
+	bundleJSON, err := decompressProfilesBundle(bundle)
+	if err != nil {
+		return nil, err
+	}
+	profiles, version, err := parseProfilesBundle(bundleJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := saveProfilesBundleCache(bundleJSON, version); err != nil {
+		log.Warnf("failed to cache profiles bundle: %s", err)
+	}
+	return profiles, nil
+}
+
+// fetchProfilesBundle fetches the gzipped profiles bundle and verifies its checksum
+func fetchProfilesBundle(ctx context.Context, url string, httpClient *http.Client) ([]byte, error) {
+	checksumURL := config.Datadog.GetString("snmp_profiles_bundle_sha256_url")
+	if checksumURL == "" {
+		checksumURL = url + ".sha256"
+	}
+
+	checksumFile, err := fetchURL(ctx, checksumURL, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch profiles bundle checksum: %w", err)
+	}
+	// the checksum file can be in the `sha256sum` format: `<checksum>  <file name>`
+	checksumFields := strings.Fields(string(checksumFile))
+	if len(checksumFields) == 0 {
+		return nil, fmt.Errorf("empty profiles bundle checksum from `%s`", checksumURL)
+	}
+	expectedChecksum := strings.ToLower(checksumFields[0])
+
+	bundle, err := fetchURL(ctx, url, httpClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch profiles bundle: %w", err)
+	}
+
+	checksum := sha256.Sum256(bundle)
+	if actualChecksum := hex.EncodeToString(checksum[:]); actualChecksum != expectedChecksum {
+		return nil, fmt.Errorf("profiles bundle checksum mismatch: expected `%s`, got `%s`", expectedChecksum, actualChecksum)
+	}
+	return bundle, nil
+}
+
+// readProfilesBundleVersion returns the version of a gzipped profiles bundle. Bundles
+// serialized from ProfileBundleResponse start with their version, so that only the
+// beginning of the bundle is decompressed.
+func readProfilesBundleVersion(gzippedBundle []byte) (string, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(gzippedBundle))
+	if err != nil {
+		return "", fmt.Errorf("failed to decompress profiles bundle: %w", err)
+	}
+	defer reader.Close()
+
+	decoder := json.NewDecoder(reader)
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return "", fmt.Errorf("failed to read profiles bundle version: the bundle is not a JSON object")
+	}
+	for decoder.More() {
+		key, err := decoder.Token()
+		if err != nil {
+			return "", fmt.Errorf("failed to read profiles bundle version: %w", err)
+		}
+		if key == profileBundleVersionKey {
+			var version string
+			if err := decoder.Decode(&version); err != nil {
+				return "", fmt.Errorf("failed to read profiles bundle version: %w", err)
+			}
+			return version, nil
+		}
+		var skipped json.RawMessage
+		if err := decoder.Decode(&skipped); err != nil {
+			return "", fmt.Errorf("failed to read profiles bundle version: %w", err)
+		}
+	}
+	return "", nil
+}
+
+func profilesBundleCacheRoot() string {
+	return config.Datadog.GetString("run_path")
+}
+
+// loadCachedProfilesBundle returns the profiles and the state of the cached bundle,
+// if the cache file matches the checksum of the state.
+func loadCachedProfilesBundle() (profileConfigMap, profilesBundleState, bool) {
+	var state profilesBundleState
+	stateJSON, err := os.ReadFile(filepath.Join(profilesBundleCacheRoot(), profilesBundleStateFile))
+	if err != nil {
+		return nil, state, false
+	}
+	if err := json.Unmarshal(stateJSON, &state); err != nil {
+		log.Debugf("ignoring invalid profiles bundle cache state: %s", err)
+		return nil, state, false
+	}
+
+	bundleJSON, err := os.ReadFile(filepath.Join(profilesBundleCacheRoot(), profilesBundleCacheFile))
+	if err != nil {
+		return nil, state, false
+	}
+	checksum := sha256.Sum256(bundleJSON)
+	if hex.EncodeToString(checksum[:]) != state.Checksum {
+		log.Debugf("ignoring profiles bundle cache: checksum mismatch")
+		return nil, state, false
+	}
+	profiles, _, err := parseProfilesBundle(bundleJSON)
+	if err != nil {
+		log.Debugf("ignoring invalid profiles bundle cache: %s", err)
+		return nil, state, false
+	}
+	return profiles, state, true
+}
+
+func saveProfilesBundleCache(bundleJSON []byte, version string) error {
+	cacheRoot := profilesBundleCacheRoot()
+	if err := os.MkdirAll(cacheRoot, 0755); err != nil {
+		return err
+	}
+
+	checksum := sha256.Sum256(bundleJSON)
+	stateJSON, err := json.Marshal(profilesBundleState{Version: version, Checksum: hex.EncodeToString(checksum[:])})
+	if err != nil {
+		return err
+	}
+	// write the bundle first, so that the state never references a bundle that wasn't written
+	if err := os.WriteFile(filepath.Join(cacheRoot, profilesBundleCacheFile), bundleJSON, 0644); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(cacheRoot, profilesBundleStateFile), stateJSON, 0644)
+}
+
+// ForceReload drops the profiles loaded in memory and the profiles bundle cache,
+// then loads the profiles again.
+func ForceReload() error {
+	defaultProfilesMu.Lock()
+	globalProfileConfigMap = nil
+	err := os.Remove(filepath.Join(profilesBundleCacheRoot(), profilesBundleStateFile))
+	defaultProfilesMu.Unlock()
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove profiles bundle state: %w", err)
+	}
+
+	_, err = loadDefaultProfiles()
+	return err
+}
+
+func fetchURL(ctx context.Context, url string, httpClient *http.Client) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code %d from `%s`", resp.StatusCode, url)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func decompressProfilesBundle(gzippedBundle []byte) ([]byte, error) {
+	reader, err := gzip.NewReader(bytes.NewReader(gzippedBundle))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress profiles bundle: %w", err)
+	}
+	defer reader.Close()
+
+	bundleJSON, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress profiles bundle: %w", err)
+	}
+	return bundleJSON, nil
+}
+
+// parseProfilesBundle parses a JSON profiles bundle, and returns its profiles and
+// version. Invalid profiles are skipped, like invalid profile files are.
+func parseProfilesBundle(bundleJSON []byte) (profileConfigMap, string, error) {
+	var bundle profiledefinition.ProfileBundleResponse
+	if err := json.Unmarshal(bundleJSON, &bundle); err != nil {
+		return nil, "", fmt.Errorf("failed to unmarshall profiles bundle: %w", err)
+	}
+	for _, err := range profiledefinition.ValidateProfileBundle(&bundle) {
+		log.Warnf("profiles bundle: %s", err)
+	}
+
+	profiles := make(profileConfigMap, len(bundle.Profiles))
+	for _, item := range bundle.Profiles {
+		definition := item.Profile
+		if definition.Name == "" {
+			log.Warnf("skipping profile without name in profiles bundle")
+			continue
+		}
+		if definition.Metadata == nil {
+			definition.Metadata = make(profiledefinition.MetadataConfig)
+		}
+		if err := normalizeAndValidateProfileDefinition(&definition); err != nil {
+			log.Warnf("failed to validate profile `%s` of profiles bundle: %s", definition.Name, err)
+			continue
+		}
+		profiles[definition.Name] = profileConfig{
+			Definition:    definition,
+			isUserProfile: item.Metadata.IsUserProfile,
+		}
+	}
+	return profiles, bundle.ProfileBundleVersion, nil
+}
@@ -0,0 +1,422 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package checkconfig
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/config"
+	"github.com/DataDog/datadog-agent/pkg/networkdevice/profile/profiledefinition"
+)
+
+func newTestProfilesBundle(t *testing.T) []byte {
+	return newTestProfilesBundleWithVersion(t, "")
+}
+
+func newTestProfilesBundleWithVersion(t *testing.T, version string) []byte {
+	bundle := profiledefinition.ProfileBundleResponse{
+		ProfileBundleVersion: version,
+		Profiles: []profiledefinition.ProfileBundleProfileItem{
+			{
+				Profile: profiledefinition.ProfileDefinition{
+					Name:         "my-profile",
+					SysObjectIds: profiledefinition.StringArray{"1.3.6.1.4.1.3375.2.1.3.4.*"},
+					Metrics: []profiledefinition.MetricsConfig{
+						{Symbol: profiledefinition.SymbolConfig{OID: "1.3.6.1.2.1.1.3.0", Name: "sysUpTimeInstance"}},
+					},
+				},
+			},
+			{
+				Metadata: profiledefinition.ProfileBundleProfileMetadata{IsUserProfile: true},
+				Profile: profiledefinition.ProfileDefinition{
+					Name: "my-user-profile",
+					Metrics: []profiledefinition.MetricsConfig{
+						{Symbol: profiledefinition.SymbolConfig{OID: "1.3.6.1.2.1.1.7.0", Name: "sysServices"}},
+					},
+				},
+			},
+			{
+				Profile: profiledefinition.ProfileDefinition{
+					Name: "invalid-profile",
+					Metrics: []profiledefinition.MetricsConfig{
+						{Symbol: profiledefinition.SymbolConfig{OID: "1.3.6.1.2.1.1.7.0", Name: "sysServices"}, MetricType: "histogram"},
+					},
+				},
+			},
+		},
+	}
+	return gzipTestProfilesBundle(t, bundle)
+}
+
+func gzipTestProfilesBundle(t *testing.T, bundle profiledefinition.ProfileBundleResponse) []byte {
+	bundleJSON, err := json.Marshal(bundle)
+	require.NoError(t, err)
+	return gzipTestContent(t, bundleJSON)
+}
+
+func gzipTestContent(t *testing.T, content []byte) []byte {
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func newTestProfilesBundleServer(t *testing.T, bundle []byte, checksum string) *httptest.Server {
+	return newCountingTestProfilesBundleServer(t, bundle, checksum, new(atomic.Int32))
+}
+
+// newCountingTestProfilesBundleServer serves the bundle, and counts the bundle downloads in bundleRequests
+func newCountingTestProfilesBundleServer(t *testing.T, bundle []byte, checksum string, bundleRequests *atomic.Int32) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/profiles.json.gz", func(w http.ResponseWriter, r *http.Request) {
+		bundleRequests.Add(1)
+		w.Write(bundle)
+	})
+	mux.HandleFunc("/profiles.json.gz.sha256", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(checksum + "  profiles.json.gz\n"))
+	})
+	mux.HandleFunc("/checksums/profiles.sha256", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(checksum))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func sha256Hex(content []byte) string {
+	checksum := sha256.Sum256(content)
+	return hex.EncodeToString(checksum[:])
+}
+
+// setTempRunPath uses an empty run path, so that the profiles bundle cache
+// is written in a temporary directory
+func setTempRunPath(t *testing.T) string {
+	runPath := t.TempDir()
+	previousRunPath := config.Datadog.GetString("run_path")
+	config.Datadog.Set("run_path", runPath)
+	t.Cleanup(func() { config.Datadog.Set("run_path", previousRunPath) })
+	return runPath
+}
+
+func Test_loadRemoteProfiles(t *testing.T) {
+	setTempRunPath(t)
+	bundle := newTestProfilesBundle(t)
+	server := newTestProfilesBundleServer(t, bundle, sha256Hex(bundle))
+
+	profiles, err := loadRemoteProfiles(context.Background(), server.URL+"/profiles.json.gz", server.Client())
+	require.NoError(t, err)
+
+	require.Len(t, profiles, 2)
+	assert.NotContains(t, profiles, "invalid-profile")
+
+	profile := profiles["my-profile"]
+	assert.False(t, profile.isUserProfile)
+	assert.Equal(t, profiledefinition.StringArray{"1.3.6.1.4.1.3375.2.1.3.4.*"}, profile.Definition.SysObjectIds)
+	assert.Equal(t, []profiledefinition.MetricsConfig{
+		{Symbol: profiledefinition.SymbolConfig{OID: "1.3.6.1.2.1.1.3.0", Name: "sysUpTimeInstance"}},
+	}, profile.Definition.Metrics)
+
+	assert.True(t, profiles["my-user-profile"].isUserProfile)
+}
+
+func Test_loadRemoteProfiles_checksumURL(t *testing.T) {
+	setTempRunPath(t)
+	bundle := newTestProfilesBundle(t)
+	server := newTestProfilesBundleServer(t, bundle, sha256Hex(bundle))
+
+	config.Datadog.Set("snmp_profiles_bundle_sha256_url", server.URL+"/checksums/profiles.sha256")
+	t.Cleanup(func() { config.Datadog.Set("snmp_profiles_bundle_sha256_url", "") })
+
+	profiles, err := loadRemoteProfiles(context.Background(), server.URL+"/profiles.json.gz", server.Client())
+	require.NoError(t, err)
+	assert.Len(t, profiles, 2)
+}
+
+func Test_loadRemoteProfiles_errors(t *testing.T) {
+	bundle := newTestProfilesBundle(t)
+
+	tests := []struct {
+		name          string
+		bundle        []byte
+		checksum      string
+		path          string
+		expectedError string
+	}{
+		{
+			name:          "checksum mismatch",
+			bundle:        bundle,
+			checksum:      sha256Hex([]byte("another bundle")),
+			path:          "/profiles.json.gz",
+			expectedError: "profiles bundle checksum mismatch",
+		},
+		{
+			name:          "missing bundle",
+			bundle:        bundle,
+			checksum:      sha256Hex(bundle),
+			path:          "/missing.json.gz",
+			expectedError: "failed to fetch profiles bundle checksum: unexpected status code 404",
+		},
+		{
+			name:          "not gzipped",
+			bundle:        []byte(`{"profiles": []}`),
+			checksum:      sha256Hex([]byte(`{"profiles": []}`)),
+			path:          "/profiles.json.gz",
+			expectedError: "failed to decompress profiles bundle",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setTempRunPath(t)
+			server := newTestProfilesBundleServer(t, tt.bundle, tt.checksum)
+
+			profiles, err := loadRemoteProfiles(context.Background(), server.URL+tt.path, server.Client())
+			assert.Nil(t, profiles)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.expectedError)
+		})
+	}
+}
+
+func Test_loadDefaultProfiles_remoteBundle(t *testing.T) {
+	bundle := newTestProfilesBundle(t)
+	server := newTestProfilesBundleServer(t, bundle, sha256Hex(bundle))
+
+	SetConfdPathAndCleanProfiles()
+	setTempRunPath(t)
+	config.Datadog.Set("snmp_profiles_bundle_url", server.URL+"/profiles.json.gz")
+	t.Cleanup(func() {
+		config.Datadog.Set("snmp_profiles_bundle_url", "")
+		globalProfileConfigMap = nil
+	})
+
+	profiles, err := loadDefaultProfiles()
+	require.NoError(t, err)
+	assert.Len(t, profiles, 2)
+	assert.Contains(t, profiles, "my-profile")
+
+	// falls back to the cached bundle when the bundle can't be loaded
+	globalProfileConfigMap = nil
+	config.Datadog.Set("snmp_profiles_bundle_url", server.URL+"/missing.json.gz")
+
+	profiles, err = loadDefaultProfiles()
+	require.NoError(t, err)
+	assert.Contains(t, profiles, "my-profile")
+
+	// then to local profiles when there is no cached bundle
+	globalProfileConfigMap = nil
+	setTempRunPath(t)
+
+	profiles, err = loadDefaultProfiles()
+	require.NoError(t, err)
+	assert.NotContains(t, profiles, "my-profile")
+	assert.Contains(t, profiles, "f5-big-ip")
+}
+
+func readTestProfilesBundleState(t *testing.T, runPath string) profilesBundleState {
+	stateJSON, err := os.ReadFile(filepath.Join(runPath, profilesBundleStateFile))
+	require.NoError(t, err)
+	var state profilesBundleState
+	require.NoError(t, json.Unmarshal(stateJSON, &state))
+	return state
+}
+
+func Test_loadRemoteProfiles_cache(t *testing.T) {
+	runPath := setTempRunPath(t)
+	bundleURL := "/profiles.json.gz"
+
+	bundleV1 := newTestProfilesBundleWithVersion(t, "v1")
+	server := newTestProfilesBundleServer(t, bundleV1, sha256Hex(bundleV1))
+
+	profiles, err := loadRemoteProfiles(context.Background(), server.URL+bundleURL, server.Client())
+	require.NoError(t, err)
+	assert.Len(t, profiles, 2)
+
+	cacheJSON, err := os.ReadFile(filepath.Join(runPath, profilesBundleCacheFile))
+	require.NoError(t, err)
+	assert.Equal(t, profilesBundleState{Version: "v1", Checksum: sha256Hex(cacheJSON)}, readTestProfilesBundleState(t, runPath))
+
+	// same version: the cached bundle is used, even if the content differs
+	sameVersionBundle := gzipTestProfilesBundle(t, profiledefinition.ProfileBundleResponse{ProfileBundleVersion: "v1"})
+	server = newTestProfilesBundleServer(t, sameVersionBundle, sha256Hex(sameVersionBundle))
+
+	cachedProfiles, err := loadRemoteProfiles(context.Background(), server.URL+bundleURL, server.Client())
+	require.NoError(t, err)
+	assert.Equal(t, profiles, cachedProfiles)
+
+	// new version: the cache is refreshed
+	bundleV2 := gzipTestProfilesBundle(t, profiledefinition.ProfileBundleResponse{ProfileBundleVersion: "v2"})
+	server = newTestProfilesBundleServer(t, bundleV2, sha256Hex(bundleV2))
+
+	profiles, err = loadRemoteProfiles(context.Background(), server.URL+bundleURL, server.Client())
+	require.NoError(t, err)
+	assert.Empty(t, profiles)
+	assert.Equal(t, "v2", readTestProfilesBundleState(t, runPath).Version)
+}
+
+func Test_loadRemoteProfiles_noVersion(t *testing.T) {
+	runPath := setTempRunPath(t)
+
+	bundle := newTestProfilesBundle(t)
+	server := newTestProfilesBundleServer(t, bundle, sha256Hex(bundle))
+	_, err := loadRemoteProfiles(context.Background(), server.URL+"/profiles.json.gz", server.Client())
+	require.NoError(t, err)
+	assert.Equal(t, "", readTestProfilesBundleState(t, runPath).Version)
+
+	// bundles without version are always decompressed
+	otherBundle := gzipTestProfilesBundle(t, profiledefinition.ProfileBundleResponse{})
+	server = newTestProfilesBundleServer(t, otherBundle, sha256Hex(otherBundle))
+	profiles, err := loadRemoteProfiles(context.Background(), server.URL+"/profiles.json.gz", server.Client())
+	require.NoError(t, err)
+	assert.Empty(t, profiles)
+}
+
+func Test_loadRemoteProfiles_cacheFallback(t *testing.T) {
+	setTempRunPath(t)
+
+	bundle := newTestProfilesBundleWithVersion(t, "v1")
+	server := newTestProfilesBundleServer(t, bundle, sha256Hex(bundle))
+	profiles, err := loadRemoteProfiles(context.Background(), server.URL+"/profiles.json.gz", server.Client())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		path     string
+		checksum string
+	}{
+		{
+			name:     "checksum can't be fetched",
+			path:     "/missing.json.gz",
+			checksum: sha256Hex(bundle),
+		},
+		{
+			name:     "checksum mismatch",
+			path:     "/profiles.json.gz",
+			checksum: sha256Hex([]byte("another bundle")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestProfilesBundleServer(t, bundle, tt.checksum)
+			cachedProfiles, err := loadRemoteProfiles(context.Background(), server.URL+tt.path, server.Client())
+			require.NoError(t, err)
+			assert.Equal(t, profiles, cachedProfiles)
+		})
+	}
+}
+
+func Test_loadRemoteProfiles_invalidCache(t *testing.T) {
+	runPath := setTempRunPath(t)
+
+	bundle := newTestProfilesBundleWithVersion(t, "v1")
+	server := newTestProfilesBundleServer(t, bundle, sha256Hex(bundle))
+
+	_, err := loadRemoteProfiles(context.Background(), server.URL+"/profiles.json.gz", server.Client())
+	require.NoError(t, err)
+
+	cacheFile := filepath.Join(runPath, profilesBundleCacheFile)
+	require.NoError(t, os.WriteFile(cacheFile, []byte(`{"profiles": []}`), 0644))
+
+	// the cache file doesn't match its checksum anymore
+	_, _, ok := loadCachedProfilesBundle()
+	assert.False(t, ok)
+
+	profiles, err := loadRemoteProfiles(context.Background(), server.URL+"/profiles.json.gz", server.Client())
+	require.NoError(t, err)
+	assert.Len(t, profiles, 2)
+	_, _, ok = loadCachedProfilesBundle()
+	assert.True(t, ok)
+}
+
+func Test_readProfilesBundleVersion(t *testing.T) {
+	tests := []struct {
+		name            string
+		bundleJSON      string
+		expectedVersion string
+		expectedError   string
+	}{
+		{
+			name:            "version first",
+			bundleJSON:      `{"profile_bundle_version": "v1", "profiles": [{"profile": {"name": "a"}}]}`,
+			expectedVersion: "v1",
+		},
+		{
+			name:            "version last",
+			bundleJSON:      `{"profiles": [{"profile": {"name": "a"}}], "created_at": 1, "profile_bundle_version": "v2"}`,
+			expectedVersion: "v2",
+		},
+		{
+			name:       "no version",
+			bundleJSON: `{"profiles": []}`,
+		},
+		{
+			name:          "not an object",
+			bundleJSON:    `[]`,
+			expectedError: "the bundle is not a JSON object",
+		},
+		{
+			name:          "invalid version",
+			bundleJSON:    `{"profile_bundle_version": 1}`,
+			expectedError: "failed to read profiles bundle version",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := readProfilesBundleVersion(gzipTestContent(t, []byte(tt.bundleJSON)))
+			if tt.expectedError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedVersion, version)
+		})
+	}
+}
+
+func Test_ForceReload(t *testing.T) {
+	setTempRunPath(t)
+
+	bundle := newTestProfilesBundleWithVersion(t, "v1")
+	var bundleRequests atomic.Int32
+	server := newCountingTestProfilesBundleServer(t, bundle, sha256Hex(bundle), &bundleRequests)
+
+	globalProfileConfigMap = nil
+	config.Datadog.Set("snmp_profiles_bundle_url", server.URL+"/profiles.json.gz")
+	t.Cleanup(func() {
+		config.Datadog.Set("snmp_profiles_bundle_url", "")
+		globalProfileConfigMap = nil
+	})
+
+	_, err := loadDefaultProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), bundleRequests.Load())
+
+	// profiles are kept in memory
+	_, err = loadDefaultProfiles()
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), bundleRequests.Load())
+
+	require.NoError(t, ForceReload())
+	assert.Equal(t, int32(2), bundleRequests.Load())
+	assert.Contains(t, globalProfileConfigMap, "my-profile")
+}
@@ -45,6 +45,15 @@ func loadDefaultProfiles() (profileConfigMap, error) {
 	}
 	log.Debugf("build default profiles")
 
+	if bundleURL := config.Datadog.GetString("snmp_profiles_bundle_url"); bundleURL != "" {
+		profiles, err := loadRemoteProfilesFromConfig(bundleURL)
+		if err == nil {
+			globalProfileConfigMap = profiles
+			return profiles, nil
+		}
+		log.Warnf("failed to load profiles bundle from `%s`, falling back to local profiles: %s", bundleURL, err)
+	}
+
 	pConfig, err := getDefaultProfilesDefinitionFiles()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get default profile definitions: %s", err)
@@ -137,14 +146,30 @@ func readProfileDefinition(definitionFile string) (*profiledefinition.ProfileDef
 	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshall %q: %v", filePath, err)
 	}
-	profiledefinition.NormalizeMetrics(profileDefinition.Metrics)
-	errors := validateEnrichMetadata(profileDefinition.Metadata)
+	if err := normalizeAndValidateProfileDefinition(profileDefinition); err != nil {
+		return nil, err
+	}
+	return profileDefinition, nil
+}
+
+// normalizeAndValidateProfileDefinition normalizes, validates and enriches the metrics,
+// metric tags and metadata of a profile definition.
+func normalizeAndValidateProfileDefinition(profileDefinition *profiledefinition.ProfileDefinition) error {
+	var errors []string
+	warnings, err := profiledefinition.NormalizeMetrics(profileDefinition.Metrics)
+	for _, warning := range warnings {
+		log.Warn(warning)
+	}
+	if err != nil {
+		errors = append(errors, err.Error())
+	}
+	errors = append(errors, validateEnrichMetadata(profileDefinition.Metadata)...)
 	errors = append(errors, ValidateEnrichMetrics(profileDefinition.Metrics)...)
 	errors = append(errors, ValidateEnrichMetricTags(profileDefinition.MetricTags)...)
 	if len(errors) > 0 {
-		return nil, fmt.Errorf("validation errors: %s", strings.Join(errors, "\n"))
+		return fmt.Errorf("validation errors: %s", strings.Join(errors, "\n"))
 	}
-	return profileDefinition, nil
+	return nil
 }
 
 func resolveProfileDefinitionPath(definitionFile string) string {
@@ -7,6 +7,7 @@ package report
 
 import (
 	"fmt"
+	"strconv"
 
 	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
 	"github.com/DataDog/datadog-agent/pkg/metrics/servicecheck"
@@ -125,7 +126,7 @@ func (ms *MetricSender) reportColumnMetrics(metricConfig profiledefinition.Metri
 	for _, symbol := range metricConfig.Symbols {
 		var metricValues map[string]valuestore.ResultValue
 
-		if symbol.ConstantValueOne {
+		if symbol.IsConstantValueOne() {
 			metricValues = getConstantMetricValues(metricConfig.MetricTags, values)
 		} else {
 			var err error
@@ -188,7 +189,12 @@ func (ms *MetricSender) sendMetric(metricSample MetricSample) {
 			return
 		}
 		options := metricSample.options
-		floatValue, err := getFlagStreamValue(options.Placement, strValue)
+		var floatValue float64
+		if options.Placement == 0 && metricSample.symbol.Format != "" {
+			floatValue, err = getFlagStreamRangeValue(metricSample.symbol.Format, strValue)
+		} else {
+			floatValue, err = getFlagStreamValue(options.Placement, strValue)
+		}
 		if err != nil {
 			log.Debugf("metric `%s`: failed to get flag stream value: %s", metricFullName, err)
 			return
@@ -257,6 +263,23 @@ func (ms *MetricSender) GetSubmittedMetrics() int {
 	return ms.submittedMetrics
 }
 
+// getFlagStreamRangeValue returns the flags selected by a `flags:<n>:<m>`
+// format, read as a binary number
+func getFlagStreamRangeValue(format string, strValue string) (float64, error) {
+	start, end, err := profiledefinition.ParseFlagStreamFormat(format)
+	if err != nil {
+		return 0, err
+	}
+	if end > len(strValue) {
+		return 0, fmt.Errorf("flag stream range `%d:%d` not found in `%s`", start, end, strValue)
+	}
+	flags, err := strconv.ParseUint(strValue[start:end], 2, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid flags `%s` in flag stream `%s`: %w", strValue[start:end], strValue, err)
+	}
+	return float64(flags), nil
+}
+
 func getFlagStreamValue(placement uint, strValue string) (float64, error) {
 	index := placement - 1
 	if int(index) >= len(strValue) {
@@ -210,6 +210,39 @@ func TestSendMetric(t *testing.T) {
 				{"[DEBUG] sendMetric: metric `snmp.metric`: failed to get flag stream value: flag stream index `9` not found in `1010`", 1},
 			},
 		},
+		{
+			caseName: "Forced flag_stream format",
+			symbol:   profiledefinition.SymbolConfig{Name: "metric", Format: "flags:1:4"},
+			value:    valuestore.ResultValue{Value: "1011"},
+			tags:     []string{},
+			metricConfig: profiledefinition.MetricsConfig{
+				MetricType: "flag_stream",
+				Options:    profiledefinition.MetricsConfigOption{MetricSuffix: "baz"},
+			},
+			expectedMethod:     "Gauge",
+			expectedMetricName: "snmp.metric.baz",
+			expectedValue:      3.0,
+			expectedTags:       []string{},
+			expectedSubMetrics: 1,
+		},
+		{
+			caseName: "Forced flag_stream format invalid range",
+			symbol:   profiledefinition.SymbolConfig{Name: "metric", Format: "flags:2:8"},
+			value:    valuestore.ResultValue{Value: "1010"},
+			tags:     []string{},
+			metricConfig: profiledefinition.MetricsConfig{
+				MetricType: "flag_stream",
+				Options:    profiledefinition.MetricsConfigOption{MetricSuffix: "none"},
+			},
+			expectedMethod:     "",
+			expectedMetricName: "",
+			expectedValue:      0.0,
+			expectedTags:       []string{},
+			expectedSubMetrics: 0,
+			expectedLogs: []logCount{
+				{"[DEBUG] sendMetric: metric `snmp.metric`: failed to get flag stream value: flag stream range `2:8` not found in `1010`", 1},
+			},
+		},
 		{
 			caseName:           "Forced monotonic_count via symbol config",
 			symbol:             profiledefinition.SymbolConfig{Name: "my.metric", MetricType: profiledefinition.ProfileMetricTypeMonotonicCount},
@@ -349,6 +382,102 @@ func TestSendMetric(t *testing.T) {
 	}
 }
 
+func TestSendMetricNormalizedDeprecatedTypes(t *testing.T) {
+	tests := []struct {
+		name           string
+		metricConfig   profiledefinition.MetricsConfig
+		expectedMethod string
+		expectedValue  float64
+	}{
+		{
+			name: "percent metric type",
+			metricConfig: profiledefinition.MetricsConfig{
+				Symbol:     profiledefinition.SymbolConfig{OID: "1.2.3", Name: "my.metric"},
+				MetricType: profiledefinition.ProfileMetricTypePercent,
+			},
+			expectedMethod: "Rate",
+			expectedValue:  50,
+		},
+		{
+			name: "percent forced type",
+			metricConfig: profiledefinition.MetricsConfig{
+				OID:        "1.2.3",
+				Name:       "my.metric",
+				ForcedType: profiledefinition.ProfileMetricTypePercent,
+			},
+			expectedMethod: "Rate",
+			expectedValue:  50,
+		},
+		{
+			name: "percent symbol metric type with scale factor",
+			metricConfig: profiledefinition.MetricsConfig{
+				Symbol: profiledefinition.SymbolConfig{OID: "1.2.3", Name: "my.metric", ScaleFactor: 0.1, MetricType: profiledefinition.ProfileMetricTypePercent},
+			},
+			expectedMethod: "Rate",
+			expectedValue:  5,
+		},
+		{
+			name: "percent table metric type",
+			metricConfig: profiledefinition.MetricsConfig{
+				Table:      profiledefinition.SymbolConfig{OID: "1.2.3", Name: "my.table"},
+				Symbols:    []profiledefinition.SymbolConfig{{OID: "1.2.3.1.1", Name: "my.metric"}},
+				MetricType: profiledefinition.ProfileMetricTypePercent,
+			},
+			expectedMethod: "Rate",
+			expectedValue:  50,
+		},
+		{
+			name: "counter metric type",
+			metricConfig: profiledefinition.MetricsConfig{
+				Symbol:     profiledefinition.SymbolConfig{OID: "1.2.3", Name: "my.metric"},
+				MetricType: profiledefinition.ProfileMetricTypeCounter,
+			},
+			expectedMethod: "Rate",
+			expectedValue:  0.5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			send := func(metricConfig profiledefinition.MetricsConfig) *mocksender.MockSender {
+				mockSender := mocksender.NewMockSender("foo")
+				mockSender.SetupAcceptAll()
+				metricSender := MetricSender{sender: mockSender}
+
+				symbol := metricConfig.Symbol
+				if metricConfig.IsColumn() {
+					symbol = metricConfig.Symbols[0]
+				}
+				forcedType := metricConfig.MetricType
+				if forcedType == "" {
+					// forced_type is moved to metric_type when validating the metrics
+					forcedType = metricConfig.ForcedType
+				}
+				if symbol.Name == "" {
+					symbol.Name = metricConfig.Name
+				}
+				metricSender.sendMetric(MetricSample{
+					value:      valuestore.ResultValue{Value: 0.5},
+					tags:       []string{},
+					symbol:     symbol,
+					forcedType: forcedType,
+				})
+				return mockSender
+			}
+
+			legacySender := send(tt.metricConfig)
+			legacySender.AssertCalled(t, tt.expectedMethod, "snmp.my.metric", tt.expectedValue, "", []string{})
+
+			metrics := []profiledefinition.MetricsConfig{tt.metricConfig}
+			_, err := profiledefinition.NormalizeMetrics(metrics)
+			assert.NoError(t, err)
+
+			normalizedSender := send(metrics[0])
+			normalizedSender.AssertCalled(t, tt.expectedMethod, "snmp.my.metric", tt.expectedValue, "", []string{})
+			normalizedSender.AssertNumberOfCalls(t, tt.expectedMethod, 1)
+		})
+	}
+}
+
 func Test_metricSender_reportMetrics(t *testing.T) {
 	type logCount struct {
 		log   string
@@ -75,7 +75,8 @@ func processValueUsingSymbolConfig(value valuestore.ResultValue, symbol profiled
 			return valuestore.ResultValue{}, fmt.Errorf("match pattern `%v` does not match string `%s`", symbol.MatchPattern, strValue)
 		}
 	}
-	if symbol.Format != "" {
+	// flag stream formats are applied when the metric is sent
+	if symbol.Format != "" && !strings.HasPrefix(symbol.Format, "flags:") {
 		var err error
 		value, err = formatValue(value, symbol.Format)
 		if err != nil {
@@ -278,6 +278,9 @@ func InitConfig(config Config) {
 	config.BindEnvAndSetDefault("allow_arbitrary_tags", false)
 	config.BindEnvAndSetDefault("use_proxy_for_cloud_metadata", false)
 	config.BindEnvAndSetDefault("remote_tagger_timeout_seconds", 30)
+	// Maximum number of entities stored by the tagger, the least recently
+	// used 10% of it are evicted at once above it. 0 disables the limit.
+	config.BindEnvAndSetDefault("tagger.max_entities", 100000)
 
 	// Fips
 	config.BindEnvAndSetDefault("fips.enabled", false)
@@ -626,6 +629,13 @@ func InitConfig(config Config) {
 	config.BindEnvAndSetDefault("kubernetes_node_annotations_as_host_aliases", []string{"cluster.k8s.io/machine"})
 	config.BindEnvAndSetDefault("kubernetes_node_label_as_cluster_name", "")
 	config.BindEnvAndSetDefault("kubernetes_namespace_labels_as_tags", map[string]string{})
+	config.BindEnvAndSetDefault("kubernetes_namespace_standard_tags_from_annotations", false)
+	config.BindEnvAndSetDefault("kubernetes_namespace_include_patterns", []string{})
+	config.BindEnvAndSetDefault("kubernetes_namespace_exclude_patterns", []string{})
+	config.BindEnvAndSetDefault("kubernetes_secret_names_as_tags", false)
+	config.BindEnvAndSetDefault("kubernetes_pod_conditions_as_tags", []string{})
+	config.BindEnvAndSetDefault("kubernetes_node_conditions_as_tags", false)
+	config.BindEnvAndSetDefault("virtual_node_label", "")
 	config.BindEnvAndSetDefault("container_cgroup_prefix", "")
 
 	// CRI
@@ -690,13 +700,26 @@ func InitConfig(config Config) {
 	config.SetKnown("snmp_listener.min_collection_interval")
 	config.SetKnown("snmp_listener.namespace")
 	config.SetKnown("snmp_listener.use_device_id_as_hostname")
+	config.BindEnvAndSetDefault("snmp_profiles_bundle_url", "")
+	config.BindEnvAndSetDefault("snmp_profiles_bundle_sha256_url", "")
 
 	bindEnvAndSetLogsConfigKeys(config, "network_devices.snmp_traps.forwarder.")
 	config.BindEnvAndSetDefault("network_devices.snmp_traps.enabled", false)
 	config.BindEnvAndSetDefault("network_devices.snmp_traps.port", 9162)
 	config.BindEnvAndSetDefault("network_devices.snmp_traps.community_strings", []string{})
 	config.BindEnvAndSetDefault("network_devices.snmp_traps.bind_host", "0.0.0.0")
-	config.BindEnvAndSetDefault("network_devices.snmp_traps.stop_timeout", 5) // in seconds
+	config.BindEnvAndSetDefault("network_devices.snmp_traps.stop_timeout", 5)  // in seconds
+	config.BindEnvAndSetDefault("network_devices.snmp_traps.drain_timeout", 5) // in seconds
+	config.BindEnvAndSetDefault("network_devices.snmp_traps.oid_cache_size", 1024)
+	config.BindEnvAndSetDefault("network_devices.snmp_traps.context_engine_id", "")
+	config.BindEnvAndSetDefault("network_devices.snmp_traps.context_name", "")
+	config.BindEnvAndSetDefault("network_devices.snmp_traps.rate_limit_per_source", 0) // in traps per second, 0 disables rate limiting
+	config.BindEnvAndSetDefault("network_devices.snmp_traps.rate_limit_burst", 0)
+	config.BindEnvAndSetDefault("network_devices.snmp_traps.tls_cert_file", "")
+	config.BindEnvAndSetDefault("network_devices.snmp_traps.tls_key_file", "")
+	config.BindEnvAndSetDefault("network_devices.snmp_traps.tls_ca_file", "")
+	config.BindEnvAndSetDefault("network_devices.snmp_traps.enrich_with_sysname", false)
+	config.BindEnvAndSetDefault("network_devices.snmp_traps.sysname_cache_ttl", 3600) // in seconds
 	config.SetKnown("network_devices.snmp_traps.users")
 
 	// NetFlow
@@ -740,6 +763,8 @@ func InitConfig(config Config) {
 	config.BindEnvAndSetDefault("cluster_agent.max_leader_connections", 100)
 	config.BindEnvAndSetDefault("cluster_agent.client_reconnect_period_seconds", 1200)
 	config.BindEnvAndSetDefault("cluster_agent.collect_kubernetes_tags", false)
+	config.BindEnvAndSetDefault("cluster_agent.collect_kubernetes_job_owners", false)
+	config.BindEnvAndSetDefault("cluster_agent.kubernetes_configmap_labels_as_tags", map[string]string{})
 	config.BindEnvAndSetDefault("cluster_agent.kubernetes_resources_collection.pod_annotations_exclude", []string{
 		`^kubectl\.kubernetes\.io\/last-applied-configuration$`,
 		`^ad\.datadoghq\.com\/([[:alnum:]]+\.)?(checks|check_names|init_configs|instances)$`,
@@ -1125,6 +1150,9 @@ func InitConfig(config Config) {
 	// Remote process collector
 	config.BindEnvAndSetDefault("workloadmeta.local_process_collector.collection_interval", DefaultLocalProcessCollectorInterval)
 
+	// Workloadmeta store
+	config.BindEnvAndSetDefault("workloadmeta.event_coalescing_window", time.Duration(0))
+
 	// SBOM configuration
 	config.BindEnvAndSetDefault("sbom.enabled", false)
 	bindEnvAndSetLogsConfigKeys(config, "sbom.")
@@ -0,0 +1,70 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package jsonextract implements a parser that extracts fields of single-line JSON logs as message attributes.
+package jsonextract
+
+import (
+	"encoding/json"
+
+	"github.com/DataDog/datadog-agent/pkg/logs/internal/parsers"
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+)
+
+// NewJSONExtractor creates a parser that copies the top-level fields of JSON
+// lines listed in keyMap to the message attributes named after their keyMap
+// value. Objects, arrays and null values are skipped, and lines that are not
+// JSON objects are returned unchanged.
+func NewJSONExtractor(keyMap map[string]string) parsers.Parser {
+	return &jsonExtractor{keyMap: keyMap}
+}
+
+type jsonExtractor struct {
+	keyMap map[string]string
+}
+
+// Parse implements Parser#Parse
+func (p *jsonExtractor) Parse(msg *message.Message) (*message.Message, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(msg.Content, &fields); err != nil {
+		return msg, nil
+	}
+	for key, attribute := range p.keyMap {
+		raw, ok := fields[key]
+		if !ok {
+			continue
+		}
+		value, ok := scalarValue(raw)
+		if !ok {
+			continue
+		}
+		if msg.Attributes == nil {
+			msg.Attributes = make(map[string]string, len(p.keyMap))
+		}
+		msg.Attributes[attribute] = value
+	}
+	return msg, nil
+}
+
+// SupportsPartialLine implements Parser#SupportsPartialLine
+func (p *jsonExtractor) SupportsPartialLine() bool {
+	return false
+}
+
+// scalarValue returns strings unquoted and numbers and booleans as written in the JSON
+func scalarValue(raw json.RawMessage) (string, bool) {
+	switch raw[0] {
+	case '{', '[', 'n':
+		return "", false
+	case '"':
+		var value string
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return "", false
+		}
+		return value, true
+	default:
+		return string(raw), true
+	}
+}
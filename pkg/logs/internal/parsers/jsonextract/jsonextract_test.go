@@ -0,0 +1,68 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package jsonextract
+
+import (
+	"testing"
+
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var keyMap = map[string]string{
+	"level":   "status",
+	"trace":   "trace_id",
+	"retries": "retries",
+	"ok":      "success",
+	"user":    "user",
+	"tags":    "tags",
+	"parent":  "parent",
+}
+
+func parse(content string) *message.Message {
+	msg, _ := NewJSONExtractor(keyMap).Parse(&message.Message{Content: []byte(content)})
+	return msg
+}
+
+func TestJSONExtractorExtractsKeys(t *testing.T) {
+	content := `{"level":"error","trace":"abc\"123","retries":3,"ok":false,"msg":"boom"}`
+	msg := parse(content)
+	assert.Equal(t, content, string(msg.Content))
+	assert.Equal(t, map[string]string{
+		"status":   "error",
+		"trace_id": `abc"123`,
+		"retries":  "3",
+		"success":  "false",
+	}, msg.Attributes)
+}
+
+func TestJSONExtractorMissingKeys(t *testing.T) {
+	msg := parse(`{"msg":"boom"}`)
+	assert.Nil(t, msg.Attributes)
+
+	msg = parse(`{"level":"info"}`)
+	assert.Equal(t, map[string]string{"status": "info"}, msg.Attributes)
+}
+
+func TestJSONExtractorSkipsNestedValues(t *testing.T) {
+	msg := parse(`{"level":"warn","user":{"id":1},"tags":["a","b"],"parent":null}`)
+	assert.Equal(t, map[string]string{"status": "warn"}, msg.Attributes)
+}
+
+func TestJSONExtractorNonJSONInput(t *testing.T) {
+	for _, content := range []string{
+		"level=error msg=boom",
+		`{"level":"error"`,
+		`["level","error"]`,
+		`"level"`,
+		"",
+	} {
+		msg := parse(content)
+		assert.Equal(t, content, string(msg.Content))
+		assert.Nil(t, msg.Attributes)
+	}
+}
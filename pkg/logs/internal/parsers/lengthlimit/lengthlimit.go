@@ -0,0 +1,49 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package lengthlimit implements a parser that truncates messages exceeding a byte limit.
+package lengthlimit
+
+import (
+	"unicode/utf8"
+
+	"github.com/DataDog/datadog-agent/pkg/logs/internal/parsers"
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+)
+
+// truncatedSuffix is appended to the content of truncated messages
+var truncatedSuffix = []byte("...")
+
+// NewLengthLimiter creates a parser that truncates the content of messages
+// longer than maxBytes, without splitting multibyte UTF-8 sequences, and
+// appends "..." to the truncated content
+func NewLengthLimiter(maxBytes int) parsers.Parser {
+	return &lengthLimiter{maxBytes: maxBytes}
+}
+
+type lengthLimiter struct {
+	maxBytes int
+}
+
+// Parse implements Parser#Parse
+func (p *lengthLimiter) Parse(msg *message.Message) (*message.Message, error) {
+	if len(msg.Content) <= p.maxBytes {
+		return msg, nil
+	}
+	end := p.maxBytes
+	// move the cut back to the start of the rune it would split
+	for end > 0 && !utf8.RuneStart(msg.Content[end]) {
+		end--
+	}
+	content := make([]byte, 0, end+len(truncatedSuffix))
+	content = append(content, msg.Content[:end]...)
+	msg.Content = append(content, truncatedSuffix...)
+	return msg, nil
+}
+
+// SupportsPartialLine implements Parser#SupportsPartialLine
+func (p *lengthLimiter) SupportsPartialLine() bool {
+	return false
+}
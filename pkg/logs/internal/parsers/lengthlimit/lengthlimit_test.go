@@ -0,0 +1,43 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package lengthlimit
+
+import (
+	"testing"
+
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func parse(t *testing.T, maxBytes int, content string) string {
+	msg, err := NewLengthLimiter(maxBytes).Parse(&message.Message{Content: []byte(content)})
+	assert.Nil(t, err)
+	return string(msg.Content)
+}
+
+func TestLengthLimiterUnderLimit(t *testing.T) {
+	assert.Equal(t, "Foo", parse(t, 10, "Foo"))
+}
+
+func TestLengthLimiterExactBoundary(t *testing.T) {
+	assert.Equal(t, "FooBar", parse(t, 6, "FooBar"))
+}
+
+func TestLengthLimiterOverLimit(t *testing.T) {
+	assert.Equal(t, "Foo...", parse(t, 3, "FooBar"))
+	assert.Equal(t, "...", parse(t, 0, "FooBar"))
+}
+
+func TestLengthLimiterMultibyte(t *testing.T) {
+	// "é" is 2 bytes and "世" is 3 bytes long
+	assert.Equal(t, "caf...", parse(t, 4, "café!"))
+	assert.Equal(t, "café...", parse(t, 5, "café!"))
+	assert.Equal(t, "a...", parse(t, 2, "a世界"))
+	assert.Equal(t, "a...", parse(t, 3, "a世界"))
+	assert.Equal(t, "a世...", parse(t, 4, "a世界"))
+	assert.Equal(t, "a世界", parse(t, 7, "a世界"))
+}
@@ -0,0 +1,102 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+// Package multiline implements a parser that joins consecutive lines into blocks, such as stack traces.
+package multiline
+
+import (
+	"bytes"
+	"regexp"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+)
+
+// MultilineParser joins lines into blocks starting with a line matching a pattern.
+// Parse returns nil while the current block may still grow, and the previous block
+// once a new one starts. The last block is returned by Flush, which must be called
+// when FlushChan delivers a message or when the input ends.
+type MultilineParser struct {
+	startPattern *regexp.Regexp
+	maxLines     int
+	flushTimeout time.Duration
+	flushTimer   *time.Timer
+	// first is the first message of the current block, carrying its metadata
+	first *message.Message
+	lines [][]byte
+}
+
+// NewMultilineParser creates a parser joining lines until a line matches startPattern.
+// A block is also returned once it has maxLines lines, when maxLines is positive, or
+// when no line was received for flushTimeout.
+func NewMultilineParser(startPattern *regexp.Regexp, maxLines int, flushTimeout time.Duration) *MultilineParser {
+	return &MultilineParser{
+		startPattern: startPattern,
+		maxLines:     maxLines,
+		flushTimeout: flushTimeout,
+	}
+}
+
+// Parse implements Parser#Parse
+func (p *MultilineParser) Parse(msg *message.Message) (*message.Message, error) {
+	p.stopFlushTimer()
+
+	var block *message.Message
+	if len(p.lines) > 0 && p.startPattern.Match(msg.Content) {
+		block = p.Flush()
+	}
+	if len(p.lines) == 0 {
+		p.first = msg
+	}
+	p.lines = append(p.lines, msg.Content)
+
+	if p.maxLines > 0 && len(p.lines) >= p.maxLines {
+		// the buffer was empty before this line if a block was just completed
+		return p.Flush(), nil
+	}
+
+	if p.flushTimer == nil {
+		p.flushTimer = time.NewTimer(p.flushTimeout)
+	} else {
+		p.flushTimer.Reset(p.flushTimeout)
+	}
+	return block, nil
+}
+
+// SupportsPartialLine implements Parser#SupportsPartialLine
+func (p *MultilineParser) SupportsPartialLine() bool {
+	return false
+}
+
+// FlushChan returns a channel which delivers a message when Flush should be called.
+func (p *MultilineParser) FlushChan() <-chan time.Time {
+	if p.flushTimer != nil && len(p.lines) > 0 {
+		return p.flushTimer.C
+	}
+	return nil
+}
+
+// Flush returns the current block, or nil if there is none.
+func (p *MultilineParser) Flush() *message.Message {
+	if len(p.lines) == 0 {
+		return nil
+	}
+	p.stopFlushTimer()
+	block := p.first
+	block.Content = bytes.Join(p.lines, []byte("\n"))
+	p.first = nil
+	p.lines = nil
+	return block
+}
+
+func (p *MultilineParser) stopFlushTimer() {
+	if p.flushTimer != nil && !p.flushTimer.Stop() {
+		// drain the channel if the timer fired without being read
+		select {
+		case <-p.flushTimer.C:
+		default:
+		}
+	}
+}
@@ -0,0 +1,120 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package multiline
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DataDog/datadog-agent/pkg/logs/internal/parsers"
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var _ parsers.Parser = &MultilineParser{}
+
+// parseLines parses all lines and flushes the last block, returning the content of all blocks
+func parseLines(t *testing.T, parser *MultilineParser, lines []string) []string {
+	var blocks []string
+	for _, line := range lines {
+		msg, err := parser.Parse(&message.Message{Content: []byte(line), Status: message.StatusInfo})
+		require.NoError(t, err)
+		if msg != nil {
+			blocks = append(blocks, string(msg.Content))
+		}
+	}
+	if msg := parser.Flush(); msg != nil {
+		blocks = append(blocks, string(msg.Content))
+	}
+	return blocks
+}
+
+func TestMultilineParserJavaStackTrace(t *testing.T) {
+	parser := NewMultilineParser(regexp.MustCompile(`^\d{4}-\d{2}-\d{2}`), 100, time.Minute)
+	stackTrace := []string{
+		`2023-10-01 12:00:00 ERROR Exception in thread "main" java.lang.NullPointerException`,
+		"\tat com.example.Foo.bar(Foo.java:10)",
+		"\tat com.example.Main.main(Main.java:5)",
+		"Caused by: java.lang.IllegalStateException: not ready",
+		"\t... 2 more",
+	}
+	lines := append([]string{"2023-10-01 11:59:59 INFO starting"}, stackTrace...)
+	lines = append(lines, "2023-10-01 12:00:01 INFO stopping")
+
+	assert.Equal(t, []string{
+		"2023-10-01 11:59:59 INFO starting",
+		strings.Join(stackTrace, "\n"),
+		"2023-10-01 12:00:01 INFO stopping",
+	}, parseLines(t, parser, lines))
+}
+
+func TestMultilineParserPythonTraceback(t *testing.T) {
+	parser := NewMultilineParser(regexp.MustCompile(`^\[\d{4}-\d{2}-\d{2} `), 100, time.Minute)
+	traceback := []string{
+		"[2023-10-01 12:00:00,123] ERROR Unhandled exception",
+		"Traceback (most recent call last):",
+		`  File "app.py", line 12, in <module>`,
+		"    main()",
+		`  File "app.py", line 8, in main`,
+		`    raise ValueError("boom")`,
+		"ValueError: boom",
+	}
+	lines := append(traceback, "[2023-10-01 12:00:01,456] INFO retrying")
+
+	assert.Equal(t, []string{
+		strings.Join(traceback, "\n"),
+		"[2023-10-01 12:00:01,456] INFO retrying",
+	}, parseLines(t, parser, lines))
+}
+
+func TestMultilineParserKeepsFirstLineMetadata(t *testing.T) {
+	parser := NewMultilineParser(regexp.MustCompile(`^\S`), 100, time.Minute)
+	msg, err := parser.Parse(&message.Message{Content: []byte("first"), Status: message.StatusError})
+	assert.NoError(t, err)
+	assert.Nil(t, msg)
+	msg, err = parser.Parse(&message.Message{Content: []byte("  second"), Status: message.StatusInfo})
+	assert.NoError(t, err)
+	assert.Nil(t, msg)
+
+	msg = parser.Flush()
+	assert.Equal(t, "first\n  second", string(msg.Content))
+	assert.Equal(t, message.StatusError, msg.Status)
+	assert.Nil(t, parser.Flush())
+}
+
+func TestMultilineParserMaxLines(t *testing.T) {
+	parser := NewMultilineParser(regexp.MustCompile(`^\S`), 2, time.Minute)
+	assert.Equal(t, []string{
+		"start\n  1",
+		"  2\n  3",
+		"  4",
+		"next",
+	}, parseLines(t, parser, []string{"start", "  1", "  2", "  3", "  4", "next"}))
+}
+
+func TestMultilineParserFlushTimeout(t *testing.T) {
+	parser := NewMultilineParser(regexp.MustCompile(`^\S`), 100, 10*time.Millisecond)
+	assert.Nil(t, parser.FlushChan())
+
+	for _, line := range []string{"Traceback (most recent call last):", "  ValueError: boom"} {
+		msg, err := parser.Parse(&message.Message{Content: []byte(line)})
+		assert.NoError(t, err)
+		assert.Nil(t, msg)
+	}
+
+	select {
+	case <-parser.FlushChan():
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "flush timeout did not trigger")
+	}
+	msg := parser.Flush()
+	assert.Equal(t, "Traceback (most recent call last):\n  ValueError: boom", string(msg.Content))
+	assert.Nil(t, parser.FlushChan())
+}
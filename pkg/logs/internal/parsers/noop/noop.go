@@ -7,6 +7,8 @@
 package noop
 
 import (
+	"regexp"
+
 	"github.com/DataDog/datadog-agent/pkg/logs/internal/parsers"
 	"github.com/DataDog/datadog-agent/pkg/logs/message"
 )
@@ -27,3 +29,24 @@ func (p *noop) Parse(msg *message.Message) (*message.Message, error) {
 func (p *noop) SupportsPartialLine() bool {
 	return false
 }
+
+var ansiEscapeSequence = regexp.MustCompile(`\x1b\[[0-9;]*[mGKHF]`)
+
+// NewAnsiStripping creates a parser that returns lines as messages after
+// removing their ANSI escape sequences, such as color codes
+func NewAnsiStripping() parsers.Parser {
+	return &noopAnsiStrip{}
+}
+
+type noopAnsiStrip struct{}
+
+// Parse implements Parser#Parse
+func (p *noopAnsiStrip) Parse(msg *message.Message) (*message.Message, error) {
+	msg.Content = ansiEscapeSequence.ReplaceAll(msg.Content, nil)
+	return msg, nil
+}
+
+// SupportsPartialLine implements Parser#SupportsPartialLine
+func (p *noopAnsiStrip) SupportsPartialLine() bool {
+	return false
+}
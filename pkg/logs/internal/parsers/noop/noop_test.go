@@ -23,3 +23,21 @@ func TestNoopParserHandleMessages(t *testing.T) {
 	assert.False(t, logMessage.ParsingExtra.IsPartial)
 	assert.Equal(t, logMessage.Content, msg.Content)
 }
+
+func TestAnsiStrippingParserHandleMessages(t *testing.T) {
+	parser := NewAnsiStripping()
+	for input, expected := range map[string]string{
+		"Foo":                                   "Foo",
+		"\x1b[31mERROR\x1b[0m something failed": "ERROR something failed",
+		"\x1b[1;32mINFO\x1b[m \x1b[2Kready\x1b[H":     "INFO ready",
+		"\x1b[38;5;208mwarn\x1b[0m: \x1b[4Fdisk full": "warn: disk full",
+	} {
+		logMessage := message.Message{
+			Content: []byte(input),
+		}
+		msg, err := parser.Parse(&logMessage)
+		assert.Nil(t, err)
+		assert.False(t, msg.ParsingExtra.IsPartial)
+		assert.Equal(t, expected, string(msg.Content))
+	}
+}
@@ -8,16 +8,21 @@
 package journald
 
 import (
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/coreos/go-systemd/sdjournal"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/DataDog/datadog-agent/comp/logs/agent/config"
 	"github.com/DataDog/datadog-agent/pkg/logs/auditor"
 	"github.com/DataDog/datadog-agent/pkg/logs/launchers"
+	"github.com/DataDog/datadog-agent/pkg/logs/message"
 	"github.com/DataDog/datadog-agent/pkg/logs/pipeline"
+	"github.com/DataDog/datadog-agent/pkg/logs/pipeline/mock"
 	"github.com/DataDog/datadog-agent/pkg/logs/sources"
 	"github.com/DataDog/datadog-agent/pkg/logs/tailers"
 	tailer "github.com/DataDog/datadog-agent/pkg/logs/tailers/journald"
@@ -130,3 +135,150 @@ func TestStopLauncher(t *testing.T) {
 
 	assert.Equal(t, 0, len(launcher.tailers))
 }
+
+// MockCursorJournal is a mock journal holding a fixed list of entries, that
+// can be read from a cursor like a real journal.
+type MockCursorJournal struct {
+	MockJournal
+	m       sync.Mutex
+	entries []*sdjournal.JournalEntry
+	// position is the index of the current entry, it is -1 before the first entry
+	position int
+}
+
+func newMockCursorJournal(size int) *MockCursorJournal {
+	entries := make([]*sdjournal.JournalEntry, 0, size)
+	for i := 0; i < size; i++ {
+		entries = append(entries, &sdjournal.JournalEntry{
+			Fields: map[string]string{sdjournal.SD_JOURNAL_FIELD_MESSAGE: fmt.Sprintf("entry %d", i)},
+			Cursor: fmt.Sprintf("cursor-%d", i),
+		})
+	}
+	return &MockCursorJournal{entries: entries, position: -1}
+}
+
+func (m *MockCursorJournal) SeekHead() error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	m.position = -1
+	return nil
+}
+
+func (m *MockCursorJournal) SeekTail() error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	m.position = len(m.entries) - 1
+	return nil
+}
+
+func (m *MockCursorJournal) SeekCursor(cursor string) error {
+	m.m.Lock()
+	defer m.m.Unlock()
+	for i, entry := range m.entries {
+		if entry.Cursor == cursor {
+			// like sd_journal_seek_cursor, the next entry is the one of the cursor
+			m.position = i - 1
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown cursor %s", cursor)
+}
+
+func (m *MockCursorJournal) NextSkip(skip uint64) (uint64, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	skipped := uint64(0)
+	for ; skipped < skip && m.position+1 < len(m.entries); skipped++ {
+		m.position++
+	}
+	return skipped, nil
+}
+
+func (m *MockCursorJournal) Next() (uint64, error) {
+	return m.NextSkip(1)
+}
+
+func (m *MockCursorJournal) Wait(timeout time.Duration) int {
+	time.Sleep(10 * time.Millisecond)
+	return sdjournal.SD_JOURNAL_NOP
+}
+
+func (m *MockCursorJournal) GetEntry() (*sdjournal.JournalEntry, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.entries[m.position], nil
+}
+
+func (m *MockCursorJournal) GetCursor() (string, error) {
+	m.m.Lock()
+	defer m.m.Unlock()
+	return m.entries[m.position].Cursor, nil
+}
+
+// MockCursorJournalFactory a journal factory that produces mock cursor journals with the same entries
+type MockCursorJournalFactory struct {
+	size int
+}
+
+func (s *MockCursorJournalFactory) NewJournal() (tailer.Journal, error) {
+	return newMockCursorJournal(s.size), nil
+}
+
+func (s *MockCursorJournalFactory) NewJournalFromPath(path string) (tailer.Journal, error) {
+	return newMockCursorJournal(s.size), nil
+}
+
+// runLauncherUntil tails the journal with a new launcher and a new auditor
+// using the registry of runPath, commits the first count messages to the
+// auditor, and stops both, which persists the registry.
+func runLauncherUntil(t *testing.T, runPath string, count int) []*message.Message {
+	registry := auditor.New(runPath, "registry.json", time.Hour, health.RegisterLiveness("fake"))
+	registry.Start()
+
+	pipelineProvider := mock.NewMockProvider()
+	launcher := NewLauncherWithFactory(&MockCursorJournalFactory{size: 5})
+	launcher.Start(launchers.NewMockSourceProvider(), pipelineProvider, registry, tailers.NewTailerTracker())
+	launcher.sources <- sources.NewLogSource("testSource", &config.LogsConfig{TailingMode: "beginning"})
+
+	var messages []*message.Message
+	for len(messages) < count {
+		select {
+		case msg := <-pipelineProvider.NextPipelineChan():
+			messages = append(messages, msg)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timeout waiting for journal entries")
+		}
+	}
+	registry.Channel() <- &message.Payload{Messages: messages}
+
+	// discard the following messages until the launcher is stopped
+	stopped := make(chan struct{})
+	go func() {
+		for {
+			select {
+			case <-pipelineProvider.NextPipelineChan():
+			case <-stopped:
+				return
+			}
+		}
+	}()
+	launcher.Stop()
+	close(stopped)
+	registry.Stop()
+
+	return messages
+}
+
+func TestLauncherResumesFromPersistedCursor(t *testing.T) {
+	runPath := t.TempDir()
+
+	messages := runLauncherUntil(t, runPath, 3)
+	assert.Contains(t, string(messages[0].Content), "entry 0")
+	assert.Equal(t, "cursor-2", messages[2].Origin.Offset)
+
+	// after a restart, the entries already committed are not tailed again
+	messages = runLauncherUntil(t, runPath, 2)
+	assert.Contains(t, string(messages[0].Content), "entry 3")
+	assert.Contains(t, string(messages[1].Content), "entry 4")
+	assert.Equal(t, "cursor-4", messages[1].Origin.Offset)
+}
@@ -45,6 +45,8 @@ type ParsingExtra struct {
 	// Used by docker parsers to transmit an offset.
 	Timestamp string
 	IsPartial bool
+	// Used by the JSON extractor parser to transmit fields extracted from the content.
+	Attributes map[string]string
 }
 
 // ServerlessExtra ships extra information from logs processing in serverless envs.
@@ -0,0 +1,99 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package profiledefinition
+
+// Clone returns a deep copy of the profile definition, nil slices and maps are
+// kept nil. Compiled regexps are shared between copies since they are safe for
+// concurrent use and never mutated.
+func (p ProfileDefinition) Clone() ProfileDefinition {
+	clone := p
+	clone.SysObjectIds = cloneSlice(p.SysObjectIds)
+	clone.Extends = cloneSlice(p.Extends)
+	clone.Metadata = p.Metadata.Clone()
+	clone.MetricTags = cloneSliceFunc(p.MetricTags, MetricTagConfig.Clone)
+	clone.StaticTags = cloneSlice(p.StaticTags)
+	clone.Metrics = cloneSliceFunc(p.Metrics, MetricsConfig.Clone)
+	return clone
+}
+
+// Clone returns a deep copy of the metric
+func (m MetricsConfig) Clone() MetricsConfig {
+	clone := m
+	clone.Symbols = cloneSlice(m.Symbols)
+	clone.StaticTags = cloneSlice(m.StaticTags)
+	clone.MetricTags = cloneSliceFunc(m.MetricTags, MetricTagConfig.Clone)
+	return clone
+}
+
+// Clone returns a deep copy of the metric tag
+func (mtc MetricTagConfig) Clone() MetricTagConfig {
+	clone := mtc
+	clone.IndexTransform = cloneSlice(mtc.IndexTransform)
+	clone.Mapping = cloneMap(mtc.Mapping)
+	clone.Tags = cloneMap(mtc.Tags)
+	return clone
+}
+
+// Clone returns a deep copy of the metadata config
+func (mc MetadataConfig) Clone() MetadataConfig {
+	if mc == nil {
+		return nil
+	}
+	clone := make(MetadataConfig, len(mc))
+	for resourceName, resource := range mc {
+		clone[resourceName] = resource.Clone()
+	}
+	return clone
+}
+
+// Clone returns a deep copy of the metadata resource
+func (rc MetadataResourceConfig) Clone() MetadataResourceConfig {
+	clone := rc
+	if rc.Fields != nil {
+		clone.Fields = make(map[string]MetadataField, len(rc.Fields))
+		for fieldName, field := range rc.Fields {
+			clone.Fields[fieldName] = field.Clone()
+		}
+	}
+	clone.IDTags = cloneSliceFunc(rc.IDTags, MetricTagConfig.Clone)
+	return clone
+}
+
+// Clone returns a deep copy of the metadata field
+func (mf MetadataField) Clone() MetadataField {
+	clone := mf
+	clone.Symbols = cloneSlice(mf.Symbols)
+	return clone
+}
+
+func cloneSlice[S ~[]T, T any](s S) S {
+	if s == nil {
+		return nil
+	}
+	return append(make(S, 0, len(s)), s...)
+}
+
+func cloneSliceFunc[S ~[]T, T any](s S, clone func(T) T) S {
+	if s == nil {
+		return nil
+	}
+	result := make(S, 0, len(s))
+	for _, item := range s {
+		result = append(result, clone(item))
+	}
+	return result
+}
+
+func cloneMap[M ~map[string]T, T any](m M) M {
+	if m == nil {
+		return nil
+	}
+	result := make(M, len(m))
+	for key, value := range m {
+		result[key] = value
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package profiledefinition
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newCloneTestProfile() ProfileDefinition {
+	return ProfileDefinition{
+		Name:         "my-profile",
+		Description:  "a profile",
+		SysObjectIds: StringArray{"1.3.6.1.4.1.9.*"},
+		Extends:      []string{"_base.yaml"},
+		Device:       DeviceMeta{Vendor: "cisco"},
+		Metadata: MetadataConfig{
+			"device": {
+				Fields: map[string]MetadataField{
+					"vendor": {Value: "cisco"},
+					"serial_number": {
+						Symbols: []SymbolConfig{{OID: "1.3.6.1.2.1.47.1.1.1.1.11", Name: "entPhysicalSerialNum"}},
+					},
+				},
+				IDTags: MetricTagConfigList{{Tag: "interface", Column: SymbolConfig{OID: "1.3.6.1.2.1.31.1.1.1.1", Name: "ifName"}}},
+			},
+		},
+		MetricTags: []MetricTagConfig{
+			{
+				Tag:            "snmp_host",
+				OID:            "1.3.6.1.2.1.1.5.0",
+				Name:           "sysName",
+				IndexTransform: []MetricIndexTransform{{Start: 1, End: 2}},
+				Mapping:        ListMap[string]{"1": "up"},
+				Match:          "(\\w)(\\w+)",
+				Tags:           map[string]string{"prefix": "\\1"},
+				Pattern:        regexp.MustCompile("(\\w)(\\w+)"),
+			},
+		},
+		StaticTags: []string{"static:tag"},
+		Metrics: []MetricsConfig{
+			{
+				MIB:        "IF-MIB",
+				Table:      SymbolConfig{OID: "1.3.6.1.2.1.2.2", Name: "ifTable"},
+				Symbols:    []SymbolConfig{{OID: "1.3.6.1.2.1.2.2.1.14", Name: "ifInErrors"}},
+				StaticTags: []string{"table:if"},
+				MetricTags: MetricTagConfigList{
+					{Tag: "interface", Column: SymbolConfig{OID: "1.3.6.1.2.1.31.1.1.1.1", Name: "ifName"}, Mapping: ListMap[string]{"1": "eth0"}},
+				},
+			},
+		},
+	}
+}
+
+func mutateCloneTestProfile(p *ProfileDefinition) {
+	p.Name = "mutated"
+	p.SysObjectIds[0] = "mutated"
+	p.Extends[0] = "mutated"
+	p.Device.Vendor = "mutated"
+	p.Metadata["device"].Fields["serial_number"].Symbols[0].OID = "mutated"
+	p.Metadata["device"].Fields["vendor"] = MetadataField{Value: "mutated"}
+	p.Metadata["device"].IDTags[0].Tag = "mutated"
+	p.Metadata["interface"] = MetadataResourceConfig{}
+	p.MetricTags[0].IndexTransform[0].Start = 42
+	p.MetricTags[0].Mapping["1"] = "mutated"
+	p.MetricTags[0].Tags["prefix"] = "mutated"
+	p.MetricTags = append(p.MetricTags, MetricTagConfig{Tag: "mutated"})
+	p.StaticTags[0] = "mutated"
+	p.Metrics[0].Symbols[0].Name = "mutated"
+	p.Metrics[0].StaticTags[0] = "mutated"
+	p.Metrics[0].MetricTags[0].Mapping["1"] = "mutated"
+	p.Metrics[0].MetricTags[0].Tag = "mutated"
+}
+
+func TestProfileDefinition_Clone(t *testing.T) {
+	original := newCloneTestProfile()
+	clone := original.Clone()
+	assert.Equal(t, original, clone)
+
+	// mutating the clone doesn't change the original
+	mutateCloneTestProfile(&clone)
+	assert.Equal(t, newCloneTestProfile(), original)
+
+	// mutating the original doesn't change the clone
+	original = newCloneTestProfile()
+	clone = original.Clone()
+	mutateCloneTestProfile(&original)
+	assert.Equal(t, newCloneTestProfile(), clone)
+}
+
+func TestProfileDefinition_Clone_empty(t *testing.T) {
+	assert.Equal(t, ProfileDefinition{}, ProfileDefinition{}.Clone())
+	assert.Equal(t, *NewProfileDefinition(), NewProfileDefinition().Clone())
+}
@@ -0,0 +1,43 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package profiledefinition
+
+import (
+	"encoding/json"
+
+	"github.com/invopop/jsonschema"
+)
+
+// JSONSchemaDraft7 is the JSON Schema version used by GenerateJSONSchema
+const JSONSchemaDraft7 = "http://json-schema.org/draft-07/schema#"
+
+// GenerateJSONSchema generates a JSON Schema (draft-07) document for ProfileDefinition.
+// The schema is reflected from the `json` and `jsonschema` struct tags, fields tagged
+// with `jsonschema:"-"` (deprecated fields) are excluded.
+func GenerateJSONSchema() ([]byte, error) {
+	reflector := &jsonschema.Reflector{
+		AllowAdditionalProperties: false,
+		// `$defs` is not part of draft-07, inline all definitions instead
+		DoNotReference: true,
+	}
+	return ReflectJSONSchema(reflector, &ProfileDefinition{}, JSONSchemaDraft7)
+}
+
+// ReflectJSONSchema reflects the JSON Schema of v with reflector and returns it indented
+// and newline terminated. version overrides the `$schema` of the document when not empty.
+func ReflectJSONSchema(reflector *jsonschema.Reflector, v interface{}, version string) ([]byte, error) {
+	schema := reflector.Reflect(v)
+	if version != "" {
+		schema.Version = version
+	}
+
+	schemaJSON, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	schemaJSON = append(schemaJSON, byte('\n'))
+	return schemaJSON, nil
+}
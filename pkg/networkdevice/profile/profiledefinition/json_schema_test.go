@@ -0,0 +1,100 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package profiledefinition
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaNode struct {
+	Schema     string                `json:"$schema"`
+	Properties map[string]schemaNode `json:"properties"`
+	Items      *schemaNode           `json:"items"`
+	Required   []string              `json:"required"`
+}
+
+func TestGenerateJSONSchema_requiredProperties(t *testing.T) {
+	schema := generateSchemaNode(t)
+
+	assert.Equal(t, JSONSchemaDraft7, schema.Schema)
+	assert.Equal(t, []string{"name"}, schema.Required)
+
+	metricTag := schema.Properties["metric_tags"].Items
+	require.NotNil(t, metricTag)
+	assert.Equal(t, []string{"tag"}, metricTag.Required)
+}
+
+func TestGenerateJSONSchema_deprecatedFieldsExcluded(t *testing.T) {
+	schema := generateSchemaNode(t)
+
+	assert.Contains(t, schema.Properties, "metrics")
+	assert.NotContains(t, schema.Properties, "metadata")
+
+	metric := schema.Properties["metrics"].Items
+	require.NotNil(t, metric)
+	assert.Contains(t, metric.Properties, "symbol")
+	assert.Contains(t, metric.Properties, "metric_type")
+	// legacy symbol syntax and forced_type are tagged with `jsonschema:"-"`
+	assert.NotContains(t, metric.Properties, "OID")
+	assert.NotContains(t, metric.Properties, "name")
+	assert.NotContains(t, metric.Properties, "forced_type")
+	// not exposed as json
+	assert.NotContains(t, metric.Properties, "static_tags")
+	assert.NotContains(t, metric.Properties, "options")
+}
+
+func TestGenerateJSONSchema_validSchema(t *testing.T) {
+	schemaJSON, err := GenerateJSONSchema()
+	require.NoError(t, err)
+
+	compiler := jsonschema.NewCompiler()
+	compiler.Draft = jsonschema.Draft7
+	require.NoError(t, compiler.AddResource("profile_schema.json", strings.NewReader(string(schemaJSON))))
+	schema, err := compiler.Compile("profile_schema.json")
+	require.NoError(t, err)
+
+	// language=json
+	validProfile := `{
+	"name": "my-profile",
+	"sysobjectid": ["1.3.6.1.4.1.9.*"],
+	"metric_tags": [{"tag": "snmp_host", "symbol": "sysName", "OID": "1.3.6.1.2.1.1.5.0"}],
+	"metrics": [
+		{"symbol": {"OID": "1.3.6.1.2.1.1.3.0", "name": "sysUpTimeInstance"}, "metric_type": "gauge"}
+	]
+}`
+	var instance interface{}
+	require.NoError(t, json.Unmarshal([]byte(validProfile), &instance))
+	assert.NoError(t, schema.Validate(instance))
+
+	// language=json
+	invalidProfile := `{
+	"metrics": [{"OID": "1.3.6.1.2.1.1.3.0", "name": "sysUpTimeInstance"}]
+}`
+	var invalidInstance interface{}
+	require.NoError(t, json.Unmarshal([]byte(invalidProfile), &invalidInstance))
+	err = schema.Validate(invalidInstance)
+	require.Error(t, err)
+	validationErr := fmt.Sprintf("%#v", err) // using %#v prints errors hierarchy
+	assert.Contains(t, validationErr, "missing properties: 'name'")
+	// the order of the properties isn't stable
+	assert.Regexp(t, `additionalProperties ('OID', 'name'|'name', 'OID') not allowed`, validationErr)
+}
+
+func generateSchemaNode(t *testing.T) schemaNode {
+	schemaJSON, err := GenerateJSONSchema()
+	require.NoError(t, err)
+
+	var schema schemaNode
+	require.NoError(t, json.Unmarshal(schemaJSON, &schema))
+	return schema
+}
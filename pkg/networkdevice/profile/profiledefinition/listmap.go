@@ -7,6 +7,8 @@ package profiledefinition
 
 import (
 	"encoding/json"
+	"sort"
+
 	"github.com/invopop/jsonschema"
 )
 
@@ -21,11 +23,7 @@ type MapItem[T any] struct {
 
 // MarshalJSON marshalls map to list
 func (lm ListMap[T]) MarshalJSON() ([]byte, error) {
-	var items []MapItem[T]
-	for key, value := range lm {
-		items = append(items, MapItem[T]{Key: key, Value: value})
-	}
-	return json.Marshal(items)
+	return json.Marshal(MapToKeyValueList(lm))
 }
 
 // UnmarshalJSON unmarshalls list to map
@@ -34,12 +32,34 @@ func (lm *ListMap[T]) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &items); err != nil {
 		return err
 	}
-	result := make(ListMap[T])
+	*lm = KeyValueListToMap(items)
+	return nil
+}
+
+// KeyValueListToMap converts a list of key/value items into a map. When a key
+// is repeated, the last item wins.
+func KeyValueListToMap[T any](items []MapItem[T]) map[string]T {
+	result := make(map[string]T, len(items))
 	for _, item := range items {
 		result[item.Key] = item.Value
 	}
-	*lm = result
-	return nil
+	return result
+}
+
+// MapToKeyValueList converts a map into a list of key/value items, sorted by
+// key. An empty map is converted into a nil list.
+func MapToKeyValueList[T any](m map[string]T) []MapItem[T] {
+	if len(m) == 0 {
+		return nil
+	}
+	items := make([]MapItem[T], 0, len(m))
+	for key, value := range m {
+		items = append(items, MapItem[T]{Key: key, Value: value})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].Key < items[j].Key
+	})
+	return items
 }
 
 // JSONSchema is needed to customize jsonschema to match []MapItem[T] used in json format
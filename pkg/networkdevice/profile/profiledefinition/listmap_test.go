@@ -50,6 +50,38 @@ func TestListMap_UnmarshalJSON(t *testing.T) {
 	assert.Equal(t, example.Mapping, expectedExample.Mapping)
 }
 
+func TestListMap_MarshalJSON_sorted(t *testing.T) {
+	bytes, err := json.Marshal(example)
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"mapping":[{"key":"1","value":"aaa"},{"key":"2","value":"bbb"}]}`, string(bytes))
+}
+
+func TestKeyValueListConversions(t *testing.T) {
+	items := []MapItem[string]{
+		{Key: "1", Value: "aaa"},
+		{Key: "2", Value: "bbb"},
+	}
+	m := map[string]string{
+		"1": "aaa",
+		"2": "bbb",
+	}
+
+	assert.Equal(t, m, KeyValueListToMap(items))
+	assert.Equal(t, items, MapToKeyValueList(m))
+
+	// round trips
+	assert.Equal(t, m, KeyValueListToMap(MapToKeyValueList(m)))
+	assert.Equal(t, items, MapToKeyValueList(KeyValueListToMap(items)))
+	assert.Equal(t, map[string]string{}, KeyValueListToMap(MapToKeyValueList(map[string]string{})))
+
+	// the last item wins for repeated keys
+	assert.Equal(t, map[string]string{"1": "ccc"}, KeyValueListToMap([]MapItem[string]{{Key: "1", Value: "aaa"}, {Key: "1", Value: "ccc"}}))
+
+	// non-string values
+	assert.Equal(t, []MapItem[int]{{Key: "a", Value: 1}, {Key: "b", Value: 2}}, MapToKeyValueList(map[string]int{"b": 2, "a": 1}))
+}
+
 func TestListMap_JSONSchema(t *testing.T) {
 	reflector := jsonschema.Reflector{
 		AllowAdditionalProperties: false,
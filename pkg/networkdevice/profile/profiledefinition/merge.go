@@ -0,0 +1,186 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package profiledefinition
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// MergeProfileDefinitions merges a base profile into the profile extending it.
+//
+// The identity fields of the override profile (name, description, sysobjectids,
+// extends) are kept as is. For other scalar fields, the override value wins when
+// set. Metrics, metric tags and static tags are the union of both profiles, with
+// the override entries first. When a metric OID is defined by both profiles, the
+// override definition wins and the symbol is removed from the base metric.
+//
+// An error is returned if an OID is used as a scalar symbol in one profile and as
+// a column symbol in the other, since both definitions cannot be valid.
+func MergeProfileDefinitions(base, override ProfileDefinition) (ProfileDefinition, error) {
+	merged := override
+
+	if merged.Device.Vendor == "" {
+		merged.Device.Vendor = base.Device.Vendor
+	}
+
+	metrics, err := mergeMetrics(base.Metrics, override.Metrics)
+	if err != nil {
+		return ProfileDefinition{}, err
+	}
+	merged.Metrics = metrics
+	merged.MetricTags = mergeMetricTags(base.MetricTags, override.MetricTags)
+	merged.StaticTags = mergeStaticTags(base.StaticTags, override.StaticTags)
+	merged.Metadata = mergeMetadata(base.Metadata, override.Metadata)
+
+	return merged, nil
+}
+
+// mergeMetrics returns the override metrics followed by the base metrics, minus
+// the base symbols whose OID is already defined by the override metrics.
+func mergeMetrics(base, override []MetricsConfig) ([]MetricsConfig, error) {
+	// OID -> whether it is used as a scalar symbol
+	overrideOIDs := make(map[string]bool)
+	for _, metric := range override {
+		for oid, scalar := range metricOIDs(metric) {
+			overrideOIDs[oid] = scalar
+		}
+	}
+
+	var errs []error
+	merged := append([]MetricsConfig(nil), override...)
+	for _, metric := range base {
+		for oid, scalar := range metricOIDs(metric) {
+			if overrideScalar, ok := overrideOIDs[oid]; ok && overrideScalar != scalar {
+				errs = append(errs, fmt.Errorf("OID `%s` is defined both as a scalar and as a column symbol", oid))
+			}
+		}
+
+		if metric.IsColumn() {
+			var symbols []SymbolConfig
+			for _, symbol := range metric.Symbols {
+				if _, ok := overrideOIDs[symbol.OID]; !ok {
+					symbols = append(symbols, symbol)
+				}
+			}
+			if len(symbols) == 0 {
+				continue
+			}
+			metric.Symbols = symbols
+		} else if oid := scalarOID(metric); oid != "" {
+			if _, ok := overrideOIDs[oid]; ok {
+				continue
+			}
+		}
+		merged = append(merged, metric)
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return merged, nil
+}
+
+// metricOIDs returns the symbol OIDs defined by a metric, and whether each of
+// them is used as a scalar.
+func metricOIDs(metric MetricsConfig) map[string]bool {
+	oids := make(map[string]bool)
+	if oid := scalarOID(metric); oid != "" {
+		oids[oid] = true
+	}
+	for _, symbol := range metric.Symbols {
+		if symbol.OID != "" {
+			oids[symbol.OID] = false
+		}
+	}
+	return oids
+}
+
+// scalarOID returns the OID of a scalar metric, supporting the legacy syntax.
+func scalarOID(metric MetricsConfig) string {
+	if metric.Symbol.OID != "" {
+		return metric.Symbol.OID
+	}
+	return metric.OID
+}
+
+func mergeMetricTags(base, override []MetricTagConfig) []MetricTagConfig {
+	merged := append([]MetricTagConfig(nil), override...)
+	for _, baseTag := range base {
+		duplicate := false
+		for _, tag := range override {
+			if reflect.DeepEqual(baseTag, tag) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			merged = append(merged, baseTag)
+		}
+	}
+	return merged
+}
+
+func mergeStaticTags(base, override []string) []string {
+	seen := make(map[string]struct{}, len(override))
+	merged := append([]string(nil), override...)
+	for _, tag := range override {
+		seen[tag] = struct{}{}
+	}
+	for _, tag := range base {
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		merged = append(merged, tag)
+	}
+	return merged
+}
+
+// mergeMetadata merges the metadata resources: override fields win, and id tags
+// are concatenated.
+func mergeMetadata(base, override MetadataConfig) MetadataConfig {
+	if len(base) == 0 && override == nil {
+		return nil
+	}
+	merged := make(MetadataConfig, len(override))
+	for resName, resource := range override {
+		merged[resName] = MetadataResourceConfig{
+			Fields: copyMetadataFields(resource.Fields),
+			IDTags: append(MetricTagConfigList(nil), resource.IDTags...),
+		}
+	}
+	for resName, baseResource := range base {
+		resource, ok := merged[resName]
+		if !ok {
+			resource = NewMetadataResourceConfig()
+		}
+		resource.IDTags = append(resource.IDTags, baseResource.IDTags...)
+
+		if resource.Fields == nil {
+			resource.Fields = make(map[string]MetadataField, len(baseResource.Fields))
+		}
+		for field, symbol := range baseResource.Fields {
+			if _, ok := resource.Fields[field]; !ok {
+				resource.Fields[field] = symbol
+			}
+		}
+		merged[resName] = resource
+	}
+	return merged
+}
+
+func copyMetadataFields(fields map[string]MetadataField) map[string]MetadataField {
+	if fields == nil {
+		return nil
+	}
+	copied := make(map[string]MetadataField, len(fields))
+	for name, field := range fields {
+		copied[name] = field
+	}
+	return copied
+}
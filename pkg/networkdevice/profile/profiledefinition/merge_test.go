@@ -0,0 +1,483 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package profiledefinition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeProfileDefinitions(t *testing.T) {
+	okBaseDefinition := ProfileDefinition{
+		Metrics: []MetricsConfig{
+			{Symbol: SymbolConfig{OID: "1.1", Name: "metric1"}, MetricType: ProfileMetricTypeGauge},
+		},
+		MetricTags: []MetricTagConfig{
+			{
+				Tag:  "tag1",
+				OID:  "2.1",
+				Name: "tagName1",
+			},
+		},
+		Metadata: MetadataConfig{
+			"device": {
+				Fields: map[string]MetadataField{
+					"vendor": {
+						Value: "f5",
+					},
+					"description": {
+						Symbol: SymbolConfig{
+							OID:  "1.3.6.1.2.1.1.1.0",
+							Name: "sysDescr",
+						},
+					},
+				},
+			},
+			"interface": {
+				Fields: map[string]MetadataField{
+					"admin_status": {
+						Symbol: SymbolConfig{
+
+							OID:  "1.3.6.1.2.1.2.2.1.7",
+							Name: "ifAdminStatus",
+						},
+					},
+				},
+				IDTags: MetricTagConfigList{
+					{
+						Tag: "alias",
+						Column: SymbolConfig{
+							OID:  "1.3.6.1.2.1.31.1.1.1.1",
+							Name: "ifAlias",
+						},
+					},
+				},
+			},
+		},
+	}
+	emptyBaseDefinition := ProfileDefinition{}
+	okTargetDefinition := ProfileDefinition{
+		Metrics: []MetricsConfig{
+			{Symbol: SymbolConfig{OID: "1.2", Name: "metric2"}, MetricType: ProfileMetricTypeGauge},
+		},
+		MetricTags: []MetricTagConfig{
+			{
+				Tag:  "tag2",
+				OID:  "2.2",
+				Name: "tagName2",
+			},
+		},
+		Metadata: MetadataConfig{
+			"device": {
+				Fields: map[string]MetadataField{
+					"name": {
+						Symbol: SymbolConfig{
+							OID:  "1.3.6.1.2.1.1.5.0",
+							Name: "sysName",
+						},
+					},
+				},
+			},
+			"interface": {
+				Fields: map[string]MetadataField{
+					"oper_status": {
+						Symbol: SymbolConfig{
+							OID:  "1.3.6.1.2.1.2.2.1.8",
+							Name: "ifOperStatus",
+						},
+					},
+				},
+				IDTags: MetricTagConfigList{
+					{
+						Tag: "interface",
+						Column: SymbolConfig{
+							OID:  "1.3.6.1.2.1.31.1.1.1.1",
+							Name: "ifName",
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name               string
+		targetDefinition   ProfileDefinition
+		baseDefinition     ProfileDefinition
+		expectedDefinition ProfileDefinition
+	}{
+		{
+			name:             "merge case",
+			baseDefinition:   okBaseDefinition,
+			targetDefinition: okTargetDefinition,
+			expectedDefinition: ProfileDefinition{
+				Metrics: []MetricsConfig{
+					{Symbol: SymbolConfig{OID: "1.2", Name: "metric2"}, MetricType: ProfileMetricTypeGauge},
+					{Symbol: SymbolConfig{OID: "1.1", Name: "metric1"}, MetricType: ProfileMetricTypeGauge},
+				},
+				MetricTags: []MetricTagConfig{
+					{
+						Tag:  "tag2",
+						OID:  "2.2",
+						Name: "tagName2",
+					},
+					{
+						Tag:  "tag1",
+						OID:  "2.1",
+						Name: "tagName1",
+					},
+				},
+				Metadata: MetadataConfig{
+					"device": {
+						Fields: map[string]MetadataField{
+							"vendor": {
+								Value: "f5",
+							},
+							"name": {
+								Symbol: SymbolConfig{
+									OID:  "1.3.6.1.2.1.1.5.0",
+									Name: "sysName",
+								},
+							},
+							"description": {
+								Symbol: SymbolConfig{
+									OID:  "1.3.6.1.2.1.1.1.0",
+									Name: "sysDescr",
+								},
+							},
+						},
+					},
+					"interface": {
+						Fields: map[string]MetadataField{
+							"oper_status": {
+								Symbol: SymbolConfig{
+									OID:  "1.3.6.1.2.1.2.2.1.8",
+									Name: "ifOperStatus",
+								},
+							},
+							"admin_status": {
+								Symbol: SymbolConfig{
+
+									OID:  "1.3.6.1.2.1.2.2.1.7",
+									Name: "ifAdminStatus",
+								},
+							},
+						},
+						IDTags: MetricTagConfigList{
+							{
+								Tag: "interface",
+								Column: SymbolConfig{
+									OID:  "1.3.6.1.2.1.31.1.1.1.1",
+									Name: "ifName",
+								},
+							},
+							{
+								Tag: "alias",
+								Column: SymbolConfig{
+									OID:  "1.3.6.1.2.1.31.1.1.1.1",
+									Name: "ifAlias",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:             "empty base definition",
+			baseDefinition:   emptyBaseDefinition,
+			targetDefinition: okTargetDefinition,
+			expectedDefinition: ProfileDefinition{
+				Metrics: []MetricsConfig{
+					{Symbol: SymbolConfig{OID: "1.2", Name: "metric2"}, MetricType: ProfileMetricTypeGauge},
+				},
+				MetricTags: []MetricTagConfig{
+					{
+						Tag:  "tag2",
+						OID:  "2.2",
+						Name: "tagName2",
+					},
+				},
+				Metadata: MetadataConfig{
+					"device": {
+						Fields: map[string]MetadataField{
+							"name": {
+								Symbol: SymbolConfig{
+									OID:  "1.3.6.1.2.1.1.5.0",
+									Name: "sysName",
+								},
+							},
+						},
+					},
+					"interface": {
+						Fields: map[string]MetadataField{
+							"oper_status": {
+								Symbol: SymbolConfig{
+									OID:  "1.3.6.1.2.1.2.2.1.8",
+									Name: "ifOperStatus",
+								},
+							},
+						},
+						IDTags: MetricTagConfigList{
+							{
+								Tag: "interface",
+								Column: SymbolConfig{
+									OID:  "1.3.6.1.2.1.31.1.1.1.1",
+									Name: "ifName",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:             "empty taget definition",
+			baseDefinition:   okBaseDefinition,
+			targetDefinition: emptyBaseDefinition,
+			expectedDefinition: ProfileDefinition{
+				Metrics: []MetricsConfig{
+					{Symbol: SymbolConfig{OID: "1.1", Name: "metric1"}, MetricType: ProfileMetricTypeGauge},
+				},
+				MetricTags: []MetricTagConfig{
+					{
+						Tag:  "tag1",
+						OID:  "2.1",
+						Name: "tagName1",
+					},
+				},
+				Metadata: MetadataConfig{
+					"device": {
+						Fields: map[string]MetadataField{
+							"vendor": {
+								Value: "f5",
+							},
+							"description": {
+								Symbol: SymbolConfig{
+									OID:  "1.3.6.1.2.1.1.1.0",
+									Name: "sysDescr",
+								},
+							},
+						},
+					},
+					"interface": {
+						Fields: map[string]MetadataField{
+							"admin_status": {
+								Symbol: SymbolConfig{
+
+									OID:  "1.3.6.1.2.1.2.2.1.7",
+									Name: "ifAdminStatus",
+								},
+							},
+						},
+						IDTags: MetricTagConfigList{
+							{
+								Tag: "alias",
+								Column: SymbolConfig{
+									OID:  "1.3.6.1.2.1.31.1.1.1.1",
+									Name: "ifAlias",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := MergeProfileDefinitions(tt.baseDefinition, tt.targetDefinition)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedDefinition.Metrics, merged.Metrics)
+			assert.Equal(t, tt.expectedDefinition.MetricTags, merged.MetricTags)
+			assert.Equal(t, tt.expectedDefinition.Metadata, merged.Metadata)
+		})
+	}
+}
+
+func TestMergeProfileDefinitions_chain(t *testing.T) {
+	base := ProfileDefinition{
+		Name:       "_base",
+		StaticTags: []string{"source:snmp"},
+		Metrics: []MetricsConfig{
+			{Symbol: SymbolConfig{OID: "1.3.6.1.2.1.1.3.0", Name: "sysUpTimeInstance"}},
+		},
+		Device: DeviceMeta{Vendor: "generic"},
+	}
+	vendor := ProfileDefinition{
+		Name:       "_vendor",
+		Extends:    []string{"_base.yaml"},
+		StaticTags: []string{"source:snmp", "vendor:acme"},
+		Metrics: []MetricsConfig{
+			{Symbol: SymbolConfig{OID: "1.3.6.1.4.1.1.1.0", Name: "acmeTemperature"}},
+		},
+		Device: DeviceMeta{Vendor: "acme"},
+	}
+	device := ProfileDefinition{
+		Name:         "acme-router",
+		SysObjectIds: StringArray{"1.3.6.1.4.1.1.*"},
+		Extends:      []string{"_vendor.yaml"},
+		Metrics: []MetricsConfig{
+			{Symbol: SymbolConfig{OID: "1.3.6.1.4.1.1.2.0", Name: "acmeRouterLoad"}},
+		},
+	}
+
+	vendorMerged, err := MergeProfileDefinitions(base, vendor)
+	assert.NoError(t, err)
+	merged, err := MergeProfileDefinitions(vendorMerged, device)
+	assert.NoError(t, err)
+
+	assert.Equal(t, ProfileDefinition{
+		Name:         "acme-router",
+		SysObjectIds: StringArray{"1.3.6.1.4.1.1.*"},
+		Extends:      []string{"_vendor.yaml"},
+		StaticTags:   []string{"source:snmp", "vendor:acme"},
+		Metrics: []MetricsConfig{
+			{Symbol: SymbolConfig{OID: "1.3.6.1.4.1.1.2.0", Name: "acmeRouterLoad"}},
+			{Symbol: SymbolConfig{OID: "1.3.6.1.4.1.1.1.0", Name: "acmeTemperature"}},
+			{Symbol: SymbolConfig{OID: "1.3.6.1.2.1.1.3.0", Name: "sysUpTimeInstance"}},
+		},
+		Device: DeviceMeta{Vendor: "acme"},
+	}, merged)
+
+	// inputs are not modified
+	assert.Len(t, vendor.Metrics, 1)
+	assert.Len(t, device.Metrics, 1)
+}
+
+func TestMergeProfileDefinitions_duplicateOIDs(t *testing.T) {
+	tests := []struct {
+		name            string
+		base            []MetricsConfig
+		override        []MetricsConfig
+		expectedMetrics []MetricsConfig
+		expectedError   string
+	}{
+		{
+			name: "override scalar wins",
+			base: []MetricsConfig{
+				{Symbol: SymbolConfig{OID: "1.2.3.0", Name: "baseMetric"}, MetricType: ProfileMetricTypeGauge},
+				{Symbol: SymbolConfig{OID: "1.2.4.0", Name: "otherMetric"}},
+			},
+			override: []MetricsConfig{
+				{Symbol: SymbolConfig{OID: "1.2.3.0", Name: "overrideMetric"}, MetricType: ProfileMetricTypeRate},
+			},
+			expectedMetrics: []MetricsConfig{
+				{Symbol: SymbolConfig{OID: "1.2.3.0", Name: "overrideMetric"}, MetricType: ProfileMetricTypeRate},
+				{Symbol: SymbolConfig{OID: "1.2.4.0", Name: "otherMetric"}},
+			},
+		},
+		{
+			name: "legacy scalar syntax",
+			base: []MetricsConfig{
+				{OID: "1.2.3.0", Name: "baseMetric"},
+			},
+			override: []MetricsConfig{
+				{Symbol: SymbolConfig{OID: "1.2.3.0", Name: "overrideMetric"}},
+			},
+			expectedMetrics: []MetricsConfig{
+				{Symbol: SymbolConfig{OID: "1.2.3.0", Name: "overrideMetric"}},
+			},
+		},
+		{
+			name: "override column removes base symbol",
+			base: []MetricsConfig{
+				{
+					Table: SymbolConfig{OID: "1.2.5", Name: "aTable"},
+					Symbols: []SymbolConfig{
+						{OID: "1.2.5.1.1", Name: "baseColumn"},
+						{OID: "1.2.5.1.2", Name: "otherColumn"},
+					},
+				},
+			},
+			override: []MetricsConfig{
+				{
+					Table:   SymbolConfig{OID: "1.2.5", Name: "aTable"},
+					Symbols: []SymbolConfig{{OID: "1.2.5.1.1", Name: "overrideColumn"}},
+				},
+			},
+			expectedMetrics: []MetricsConfig{
+				{
+					Table:   SymbolConfig{OID: "1.2.5", Name: "aTable"},
+					Symbols: []SymbolConfig{{OID: "1.2.5.1.1", Name: "overrideColumn"}},
+				},
+				{
+					Table:   SymbolConfig{OID: "1.2.5", Name: "aTable"},
+					Symbols: []SymbolConfig{{OID: "1.2.5.1.2", Name: "otherColumn"}},
+				},
+			},
+		},
+		{
+			name: "base table fully overridden",
+			base: []MetricsConfig{
+				{
+					Table:   SymbolConfig{OID: "1.2.5", Name: "aTable"},
+					Symbols: []SymbolConfig{{OID: "1.2.5.1.1", Name: "baseColumn"}},
+				},
+			},
+			override: []MetricsConfig{
+				{
+					Table:   SymbolConfig{OID: "1.2.5", Name: "aTable"},
+					Symbols: []SymbolConfig{{OID: "1.2.5.1.1", Name: "overrideColumn"}},
+				},
+			},
+			expectedMetrics: []MetricsConfig{
+				{
+					Table:   SymbolConfig{OID: "1.2.5", Name: "aTable"},
+					Symbols: []SymbolConfig{{OID: "1.2.5.1.1", Name: "overrideColumn"}},
+				},
+			},
+		},
+		{
+			name: "scalar and column conflict",
+			base: []MetricsConfig{
+				{Symbol: SymbolConfig{OID: "1.2.5.1.1", Name: "aScalar"}},
+			},
+			override: []MetricsConfig{
+				{
+					Table:   SymbolConfig{OID: "1.2.5", Name: "aTable"},
+					Symbols: []SymbolConfig{{OID: "1.2.5.1.1", Name: "aColumn"}},
+				},
+			},
+			expectedError: "OID `1.2.5.1.1` is defined both as a scalar and as a column symbol",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, err := MergeProfileDefinitions(ProfileDefinition{Metrics: tt.base}, ProfileDefinition{Metrics: tt.override})
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedMetrics, merged.Metrics)
+		})
+	}
+}
+
+func TestMergeProfileDefinitions_tags(t *testing.T) {
+	base := ProfileDefinition{
+		MetricTags: []MetricTagConfig{
+			{Tag: "snmp_host", OID: "1.3.6.1.2.1.1.5.0", Name: "sysName"},
+			{Tag: "location", OID: "1.3.6.1.2.1.1.6.0", Name: "sysLocation"},
+		},
+		StaticTags: []string{"a:1", "b:2"},
+	}
+	override := ProfileDefinition{
+		MetricTags: []MetricTagConfig{
+			{Tag: "snmp_host", OID: "1.3.6.1.2.1.1.5.0", Name: "sysName"},
+		},
+		StaticTags: []string{"b:2", "c:3"},
+	}
+
+	merged, err := MergeProfileDefinitions(base, override)
+	assert.NoError(t, err)
+	assert.Equal(t, []MetricTagConfig{
+		{Tag: "snmp_host", OID: "1.3.6.1.2.1.1.5.0", Name: "sysName"},
+		{Tag: "location", OID: "1.3.6.1.2.1.1.6.0", Name: "sysLocation"},
+	}, merged.MetricTags)
+	assert.Equal(t, []string{"b:2", "c:3", "a:1"}, merged.StaticTags)
+}
@@ -6,7 +6,10 @@
 package profiledefinition
 
 import (
+	"errors"
+	"fmt"
 	"regexp"
+	"strconv"
 )
 
 // ProfileMetricType metric type used to override default type of the metric
@@ -39,6 +42,23 @@ const (
 	ProfileMetricTypePercent ProfileMetricType = "percent"
 )
 
+// oidPattern matches dotted-numeric OIDs, optionally starting with a dot
+var oidPattern = regexp.MustCompile(`^\.?[0-9]+(\.[0-9]+)*$`)
+
+// flagStreamFormatPattern matches the `flags:<n>:<m>` format of flag stream symbols
+var flagStreamFormatPattern = regexp.MustCompile(`^flags:([0-9]+):([0-9]+)$`)
+
+// deprecatedMetricTypes maps deprecated metric types to their replacement.
+// `percent` metrics are submitted as rates multiplied by 100, so the scale
+// factor of the symbols using it is also multiplied by percentScaleFactor.
+var deprecatedMetricTypes = map[ProfileMetricType]ProfileMetricType{
+	ProfileMetricTypeCounter: ProfileMetricTypeRate,
+	ProfileMetricTypePercent: ProfileMetricTypeRate,
+}
+
+// percentScaleFactor is the factor applied to the value of `percent` metrics
+const percentScaleFactor = 100
+
 // SymbolConfig holds info for a single symbol/oid
 type SymbolConfig struct {
 	OID  string `yaml:"OID,omitempty" json:"OID,omitempty"`
@@ -63,6 +83,84 @@ type SymbolConfig struct {
 	MetricType ProfileMetricType `yaml:"metric_type,omitempty" json:"metric_type,omitempty"`
 }
 
+// NewConstantValueOneSymbol returns a symbol that reports a constant value of
+// 1 for each row of its table, which is used to count rows.
+func NewConstantValueOneSymbol(oid, name string) SymbolConfig {
+	return SymbolConfig{
+		OID:              oid,
+		Name:             name,
+		ConstantValueOne: true,
+	}
+}
+
+// IsConstantValueOne returns true if the symbol reports a constant value of 1
+// instead of the value of its OID.
+func (s SymbolConfig) IsConstantValueOne() bool {
+	return s.ConstantValueOne
+}
+
+// Validate checks that the symbol OID is a valid dotted-numeric OID, that
+// `extract_value` and `match_pattern` are valid regexes and that
+// `scale_factor` is not negative.
+func (s *SymbolConfig) Validate() error {
+	var errs []error
+	if s.OID == "" {
+		if !s.IsConstantValueOne() {
+			errs = append(errs, fmt.Errorf("symbol `%s`: OID is missing", s.Name))
+		}
+	} else if !oidPattern.MatchString(s.OID) {
+		errs = append(errs, fmt.Errorf("symbol `%s`: invalid OID `%s`", s.Name, s.OID))
+	}
+	if s.ExtractValue != "" {
+		if _, err := regexp.Compile(s.ExtractValue); err != nil {
+			errs = append(errs, fmt.Errorf("symbol `%s`: cannot compile `extract_value` (`%s`): %w", s.Name, s.ExtractValue, err))
+		}
+	}
+	if s.MatchPattern != "" {
+		if _, err := regexp.Compile(s.MatchPattern); err != nil {
+			errs = append(errs, fmt.Errorf("symbol `%s`: cannot compile `match_pattern` (`%s`): %w", s.Name, s.MatchPattern, err))
+		}
+	}
+	if s.ScaleFactor < 0 {
+		errs = append(errs, fmt.Errorf("symbol `%s`: `scale_factor` must not be negative: %v", s.Name, s.ScaleFactor))
+	}
+	return errors.Join(errs...)
+}
+
+// ParseFlagStreamFormat parses the `flags:<n>:<m>` format of a flag stream
+// symbol, which selects the flags from position n (included) to position m
+// (excluded) of the flag stream, positions starting at 0.
+func ParseFlagStreamFormat(format string) (start int, end int, err error) {
+	matches := flagStreamFormatPattern.FindStringSubmatch(format)
+	if matches == nil {
+		return 0, 0, fmt.Errorf("invalid flag stream format `%s`, expected `flags:<n>:<m>`", format)
+	}
+	start, err = strconv.Atoi(matches[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid flag stream format `%s`: %w", format, err)
+	}
+	end, err = strconv.Atoi(matches[2])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid flag stream format `%s`: %w", format, err)
+	}
+	if start >= end {
+		return 0, 0, fmt.Errorf("invalid flag stream format `%s`: start position %d must be lower than end position %d", format, start, end)
+	}
+	return start, end, nil
+}
+
+// ValidateFlagStreamConfig checks that the format of a flag stream symbol
+// selects a valid range of flags with the `flags:<n>:<m>` syntax.
+func ValidateFlagStreamConfig(symbol SymbolConfig) error {
+	if symbol.Format == "" {
+		return fmt.Errorf("symbol `%s`: flag stream format is missing", symbol.Name)
+	}
+	if _, _, err := ParseFlagStreamFormat(symbol.Format); err != nil {
+		return fmt.Errorf("symbol `%s`: %w", symbol.Name, err)
+	}
+	return nil
+}
+
 // MetricTagConfig holds metric tag info
 type MetricTagConfig struct {
 	Tag string `yaml:"tag" json:"tag"`
@@ -90,6 +188,32 @@ type MetricTagConfig struct {
 	SymbolTag string `yaml:"-" json:"-"`
 }
 
+// Validate detects metric tag configs that are ambiguous:
+// - the scalar symbol (`OID` and `symbol`) is only partially set
+// - both a column and a scalar symbol are set
+// - the tag name is empty while the tag is neither built from a `match` regex nor a symbol tag
+//
+// A `mapping` without tag name is not an error, for backward compatibility: the SNMP check
+// only warns about it.
+func (m *MetricTagConfig) Validate() error {
+	var errs []error
+	hasColumn := m.Column.OID != "" || m.Column.Name != ""
+	hasSymbol := m.OID != "" || m.Name != ""
+	if m.OID != "" && m.Name == "" {
+		errs = append(errs, fmt.Errorf("`OID` (`%s`) is set without `symbol`", m.OID))
+	}
+	if m.Name != "" && m.OID == "" {
+		errs = append(errs, fmt.Errorf("`symbol` (`%s`) is set without `OID`", m.Name))
+	}
+	if hasColumn && hasSymbol {
+		errs = append(errs, fmt.Errorf("both `column` (`%s`) and `symbol` (`%s`) are set", m.Column.Name, m.Name))
+	}
+	if m.Tag == "" && m.Match == "" && m.SymbolTag == "" && len(m.Mapping) == 0 {
+		errs = append(errs, errors.New("`tag` is empty"))
+	}
+	return errors.Join(errs...)
+}
+
 // MetricTagConfigList holds configs for a list of metric tags
 type MetricTagConfigList []MetricTagConfig
 
@@ -148,15 +272,81 @@ func (m *MetricsConfig) IsColumn() bool {
 	return len(m.Symbols) > 0
 }
 
+// IsFlagStream returns true if the symbol of the metrics config is reported
+// as a flag stream, the symbol metric type taking precedence over the metric one
+func (m *MetricsConfig) IsFlagStream(symbol SymbolConfig) bool {
+	if symbol.MetricType != "" {
+		return symbol.MetricType == ProfileMetricTypeFlagStream
+	}
+	if m.MetricType != "" {
+		return m.MetricType == ProfileMetricTypeFlagStream
+	}
+	return m.ForcedType == ProfileMetricTypeFlagStream
+}
+
 // IsScalar returns true if the metrics config define scalar metrics
 func (m *MetricsConfig) IsScalar() bool {
 	return m.Symbol.OID != "" && m.Symbol.Name != ""
 }
 
+// ValidateMetricType returns an error if the metric type is not a known metric type.
+// Deprecated metric types are considered valid.
+func ValidateMetricType(mt ProfileMetricType) error {
+	switch mt {
+	case ProfileMetricTypeGauge,
+		ProfileMetricTypeMonotonicCount,
+		ProfileMetricTypeMonotonicCountAndRate,
+		ProfileMetricTypeRate,
+		ProfileMetricTypeFlagStream,
+		ProfileMetricTypeCounter,
+		ProfileMetricTypePercent:
+		return nil
+	}
+	return fmt.Errorf("invalid metric type `%s`", mt)
+}
+
+// ValidateMetricsConfig validates the symbols of a metrics config: the scalar
+// symbol, the table symbols and the columns used by the metric tags.
+func ValidateMetricsConfig(metric MetricsConfig) error {
+	var errs []error
+	if metric.IsScalar() {
+		errs = append(errs, metric.Symbol.Validate())
+		errs = append(errs, validateFlagStreamMetric(metric, metric.Symbol))
+	}
+	for i := range metric.Symbols {
+		errs = append(errs, metric.Symbols[i].Validate())
+		errs = append(errs, validateFlagStreamMetric(metric, metric.Symbols[i]))
+	}
+	for i := range metric.MetricTags {
+		column := &metric.MetricTags[i].Column
+		// tags based on the row index don't use a column
+		if column.OID == "" && column.Name == "" {
+			continue
+		}
+		errs = append(errs, column.Validate())
+	}
+	return errors.Join(errs...)
+}
+
+// validateFlagStreamMetric checks the format of the flag stream symbols that
+// don't use the legacy `options.placement` syntax.
+func validateFlagStreamMetric(metric MetricsConfig, symbol SymbolConfig) error {
+	if metric.Options.Placement != 0 || !metric.IsFlagStream(symbol) {
+		return nil
+	}
+	return ValidateFlagStreamConfig(symbol)
+}
+
 // NormalizeMetrics converts legacy syntax to new syntax
 // 1/ converts old symbol syntax to new symbol syntax
 // metric.Name and metric.OID info are moved to metric.Symbol.Name and metric.Symbol.OID
-func NormalizeMetrics(metrics []MetricsConfig) {
+// 2/ replaces deprecated metric types with their modern equivalent, without
+// changing the submitted values
+// A warning is returned for each deprecated metric type, and an error for each
+// unrecognized metric type.
+func NormalizeMetrics(metrics []MetricsConfig) ([]string, error) {
+	var warnings []string
+	var errs []error
 	for i := range metrics {
 		metric := &metrics[i]
 
@@ -167,5 +357,72 @@ func NormalizeMetrics(metrics []MetricsConfig) {
 			metric.Name = ""
 			metric.OID = ""
 		}
+
+		// symbols without a metric type use the one of the metric, where
+		// `metric_type` takes precedence over `forced_type`
+		metricType := metric.MetricType
+		if metricType == "" {
+			metricType = metric.ForcedType
+		}
+		if metric.Symbol.OID != "" || metric.Symbol.Name != "" {
+			normalizePercentSymbol(&metric.Symbol, metricType)
+		}
+		for j := range metric.Symbols {
+			normalizePercentSymbol(&metric.Symbols[j], metricType)
+		}
+
+		metricName := metric.Symbol.Name
+		if metric.IsColumn() {
+			metricName = metric.Table.Name
+		}
+		symbolNames := []string{metricName, metricName, metric.Symbol.Name}
+		metricTypes := []*ProfileMetricType{&metric.MetricType, &metric.ForcedType, &metric.Symbol.MetricType}
+		for j := range metric.Symbols {
+			symbolNames = append(symbolNames, metric.Symbols[j].Name)
+			metricTypes = append(metricTypes, &metric.Symbols[j].MetricType)
+		}
+		for j, metricType := range metricTypes {
+			warning, err := normalizeMetricType(metricType, symbolNames[j])
+			if warning != "" {
+				warnings = append(warnings, warning)
+			}
+			errs = append(errs, err)
+		}
+	}
+	return warnings, errors.Join(errs...)
+}
+
+// normalizePercentSymbol multiplies the scale factor of the symbol by
+// percentScaleFactor if it uses the deprecated `percent` metric type, either
+// directly or through the metric type of its metric.
+func normalizePercentSymbol(symbol *SymbolConfig, metricType ProfileMetricType) {
+	if symbol.MetricType != "" {
+		metricType = symbol.MetricType
+	}
+	if metricType != ProfileMetricTypePercent {
+		return
+	}
+	if symbol.ScaleFactor == 0 {
+		symbol.ScaleFactor = 1
+	}
+	symbol.ScaleFactor *= percentScaleFactor
+}
+
+func normalizeMetricType(metricType *ProfileMetricType, metricName string) (string, error) {
+	if *metricType == "" {
+		return "", nil
+	}
+	if err := ValidateMetricType(*metricType); err != nil {
+		return "", fmt.Errorf("metric `%s`: %w", metricName, err)
+	}
+	newType, ok := deprecatedMetricTypes[*metricType]
+	if !ok {
+		return "", nil
+	}
+	warning := fmt.Sprintf("metric `%s`: metric type `%s` is deprecated, using `%s` instead", metricName, *metricType, newType)
+	if *metricType == ProfileMetricTypePercent {
+		warning += fmt.Sprintf(" with a `scale_factor` multiplied by %d", percentScaleFactor)
 	}
+	*metricType = newType
+	return warning, nil
 }
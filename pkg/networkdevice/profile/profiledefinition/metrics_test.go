@@ -0,0 +1,497 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package profiledefinition
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMetricType(t *testing.T) {
+	tests := []struct {
+		metricType    ProfileMetricType
+		expectedError string
+	}{
+		{metricType: ProfileMetricTypeGauge},
+		{metricType: ProfileMetricTypeMonotonicCount},
+		{metricType: ProfileMetricTypeMonotonicCountAndRate},
+		{metricType: ProfileMetricTypeRate},
+		{metricType: ProfileMetricTypeFlagStream},
+		{metricType: ProfileMetricTypeCounter},
+		{metricType: ProfileMetricTypePercent},
+		{metricType: "histogram", expectedError: "invalid metric type `histogram`"},
+		{metricType: "Gauge", expectedError: "invalid metric type `Gauge`"},
+		{metricType: "", expectedError: "invalid metric type ``"},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.metricType), func(t *testing.T) {
+			err := ValidateMetricType(tt.metricType)
+			if tt.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestNormalizeMetrics(t *testing.T) {
+	tests := []struct {
+		name             string
+		metrics          []MetricsConfig
+		expectedMetrics  []MetricsConfig
+		expectedWarnings []string
+		expectedErrors   []string
+	}{
+		{
+			name: "legacy symbol syntax",
+			metrics: []MetricsConfig{
+				{OID: "1.2.3", Name: "aMetric"},
+			},
+			expectedMetrics: []MetricsConfig{
+				{Symbol: SymbolConfig{OID: "1.2.3", Name: "aMetric"}},
+			},
+		},
+		{
+			name: "valid metric types are unchanged",
+			metrics: []MetricsConfig{
+				{Symbol: SymbolConfig{OID: "1.2.3", Name: "aMetric"}, MetricType: ProfileMetricTypeMonotonicCount},
+				{
+					Table:      SymbolConfig{OID: "1.2.4", Name: "aTable"},
+					Symbols:    []SymbolConfig{{OID: "1.2.4.1.1", Name: "aColumn", MetricType: ProfileMetricTypeFlagStream}},
+					MetricType: ProfileMetricTypeGauge,
+				},
+			},
+			expectedMetrics: []MetricsConfig{
+				{Symbol: SymbolConfig{OID: "1.2.3", Name: "aMetric"}, MetricType: ProfileMetricTypeMonotonicCount},
+				{
+					Table:      SymbolConfig{OID: "1.2.4", Name: "aTable"},
+					Symbols:    []SymbolConfig{{OID: "1.2.4.1.1", Name: "aColumn", MetricType: ProfileMetricTypeFlagStream}},
+					MetricType: ProfileMetricTypeGauge,
+				},
+			},
+		},
+		{
+			name: "deprecated metric types are replaced",
+			metrics: []MetricsConfig{
+				{Symbol: SymbolConfig{OID: "1.2.3", Name: "aMetric"}, MetricType: ProfileMetricTypeCounter},
+				{Symbol: SymbolConfig{OID: "1.2.4", Name: "anotherMetric", MetricType: ProfileMetricTypePercent}},
+				{OID: "1.2.5", Name: "aLegacyMetric", ForcedType: ProfileMetricTypePercent},
+				{
+					Table:   SymbolConfig{OID: "1.2.6", Name: "aTable"},
+					Symbols: []SymbolConfig{{OID: "1.2.6.1.1", Name: "aColumn", MetricType: ProfileMetricTypeCounter}},
+				},
+			},
+			expectedMetrics: []MetricsConfig{
+				{Symbol: SymbolConfig{OID: "1.2.3", Name: "aMetric"}, MetricType: ProfileMetricTypeRate},
+				{Symbol: SymbolConfig{OID: "1.2.4", Name: "anotherMetric", MetricType: ProfileMetricTypeRate, ScaleFactor: 100}},
+				{Symbol: SymbolConfig{OID: "1.2.5", Name: "aLegacyMetric", ScaleFactor: 100}, ForcedType: ProfileMetricTypeRate},
+				{
+					Table:   SymbolConfig{OID: "1.2.6", Name: "aTable"},
+					Symbols: []SymbolConfig{{OID: "1.2.6.1.1", Name: "aColumn", MetricType: ProfileMetricTypeRate}},
+				},
+			},
+			expectedWarnings: []string{
+				"metric `aMetric`: metric type `counter` is deprecated, using `rate` instead",
+				"metric `anotherMetric`: metric type `percent` is deprecated, using `rate` instead with a `scale_factor` multiplied by 100",
+				"metric `aLegacyMetric`: metric type `percent` is deprecated, using `rate` instead with a `scale_factor` multiplied by 100",
+				"metric `aColumn`: metric type `counter` is deprecated, using `rate` instead",
+			},
+		},
+		{
+			name: "percent metric type of a table applies to the symbols without a metric type",
+			metrics: []MetricsConfig{
+				{
+					Table: SymbolConfig{OID: "1.2.6", Name: "aTable"},
+					Symbols: []SymbolConfig{
+						{OID: "1.2.6.1.1", Name: "aColumn"},
+						{OID: "1.2.6.1.2", Name: "aScaledColumn", ScaleFactor: 0.5},
+						{OID: "1.2.6.1.3", Name: "aGaugeColumn", MetricType: ProfileMetricTypeGauge},
+					},
+					MetricType: ProfileMetricTypePercent,
+				},
+			},
+			expectedMetrics: []MetricsConfig{
+				{
+					Table: SymbolConfig{OID: "1.2.6", Name: "aTable"},
+					Symbols: []SymbolConfig{
+						{OID: "1.2.6.1.1", Name: "aColumn", ScaleFactor: 100},
+						{OID: "1.2.6.1.2", Name: "aScaledColumn", ScaleFactor: 50},
+						{OID: "1.2.6.1.3", Name: "aGaugeColumn", MetricType: ProfileMetricTypeGauge},
+					},
+					MetricType: ProfileMetricTypeRate,
+				},
+			},
+			expectedWarnings: []string{
+				"metric `aTable`: metric type `percent` is deprecated, using `rate` instead with a `scale_factor` multiplied by 100",
+			},
+		},
+		{
+			name: "invalid metric types",
+			metrics: []MetricsConfig{
+				{Symbol: SymbolConfig{OID: "1.2.3", Name: "aMetric"}, MetricType: "histogram"},
+				{
+					Table:   SymbolConfig{OID: "1.2.4", Name: "aTable"},
+					Symbols: []SymbolConfig{{OID: "1.2.4.1.1", Name: "aColumn", MetricType: "distribution"}},
+				},
+				{Symbol: SymbolConfig{OID: "1.2.5", Name: "aValidMetric"}, MetricType: ProfileMetricTypeCounter},
+			},
+			expectedMetrics: []MetricsConfig{
+				{Symbol: SymbolConfig{OID: "1.2.3", Name: "aMetric"}, MetricType: "histogram"},
+				{
+					Table:   SymbolConfig{OID: "1.2.4", Name: "aTable"},
+					Symbols: []SymbolConfig{{OID: "1.2.4.1.1", Name: "aColumn", MetricType: "distribution"}},
+				},
+				{Symbol: SymbolConfig{OID: "1.2.5", Name: "aValidMetric"}, MetricType: ProfileMetricTypeRate},
+			},
+			expectedWarnings: []string{
+				"metric `aValidMetric`: metric type `counter` is deprecated, using `rate` instead",
+			},
+			expectedErrors: []string{
+				"metric `aMetric`: invalid metric type `histogram`",
+				"metric `aColumn`: invalid metric type `distribution`",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := NormalizeMetrics(tt.metrics)
+			assert.Equal(t, tt.expectedWarnings, warnings)
+			if len(tt.expectedErrors) == 0 {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				for _, expectedError := range tt.expectedErrors {
+					assert.Contains(t, err.Error(), expectedError)
+				}
+			}
+			assert.Equal(t, tt.expectedMetrics, tt.metrics)
+		})
+	}
+}
+
+func TestNewConstantValueOneSymbol(t *testing.T) {
+	symbol := NewConstantValueOneSymbol("1.3.6.1.2.1.2.2.1.1", "ifIndex")
+
+	assert.True(t, symbol.IsConstantValueOne())
+	assert.Equal(t, SymbolConfig{
+		OID:              "1.3.6.1.2.1.2.2.1.1",
+		Name:             "ifIndex",
+		ConstantValueOne: true,
+	}, symbol)
+	assert.NoError(t, symbol.Validate())
+
+	assert.False(t, SymbolConfig{OID: "1.3.6.1.2.1.2.2.1.1", Name: "ifIndex"}.IsConstantValueOne())
+}
+
+func TestSymbolConfigValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		symbol         SymbolConfig
+		expectedErrors []string
+	}{
+		{
+			name:   "valid symbol",
+			symbol: SymbolConfig{OID: "1.3.6.1.2.1.1.3.0", Name: "sysUpTimeInstance"},
+		},
+		{
+			name:   "valid OID with leading dot",
+			symbol: SymbolConfig{OID: ".1.3.6.1.2.1.1.3.0", Name: "sysUpTimeInstance"},
+		},
+		{
+			name:   "valid regexes and scale factor",
+			symbol: SymbolConfig{OID: "1.2.3", Name: "aMetric", ExtractValue: `(\d+)C`, MatchPattern: `^(\w+)$`, ScaleFactor: 0.1},
+		},
+		{
+			name:   "constant value one without OID",
+			symbol: SymbolConfig{Name: "aMetric", ConstantValueOne: true},
+		},
+		{
+			name:           "missing OID",
+			symbol:         SymbolConfig{Name: "aMetric"},
+			expectedErrors: []string{"symbol `aMetric`: OID is missing"},
+		},
+		{
+			name:           "OID with letters",
+			symbol:         SymbolConfig{OID: "1.3.6.a.1", Name: "aMetric"},
+			expectedErrors: []string{"symbol `aMetric`: invalid OID `1.3.6.a.1`"},
+		},
+		{
+			name:           "OID with trailing dot",
+			symbol:         SymbolConfig{OID: "1.3.6.1.", Name: "aMetric"},
+			expectedErrors: []string{"symbol `aMetric`: invalid OID `1.3.6.1.`"},
+		},
+		{
+			name:           "OID with empty component",
+			symbol:         SymbolConfig{OID: "1.3..6", Name: "aMetric"},
+			expectedErrors: []string{"symbol `aMetric`: invalid OID `1.3..6`"},
+		},
+		{
+			name:           "invalid extract_value",
+			symbol:         SymbolConfig{OID: "1.2.3", Name: "aMetric", ExtractValue: "(\\w"},
+			expectedErrors: []string{"symbol `aMetric`: cannot compile `extract_value` (`(\\w`)"},
+		},
+		{
+			name:           "invalid match_pattern",
+			symbol:         SymbolConfig{OID: "1.2.3", Name: "aMetric", MatchPattern: "[a-"},
+			expectedErrors: []string{"symbol `aMetric`: cannot compile `match_pattern` (`[a-`)"},
+		},
+		{
+			name:           "negative scale factor",
+			symbol:         SymbolConfig{OID: "1.2.3", Name: "aMetric", ScaleFactor: -1},
+			expectedErrors: []string{"symbol `aMetric`: `scale_factor` must not be negative: -1"},
+		},
+		{
+			name:   "multiple errors",
+			symbol: SymbolConfig{OID: "abc", Name: "aMetric", ExtractValue: "(", ScaleFactor: -0.5},
+			expectedErrors: []string{
+				"symbol `aMetric`: invalid OID `abc`",
+				"symbol `aMetric`: cannot compile `extract_value` (`(`)",
+				"symbol `aMetric`: `scale_factor` must not be negative: -0.5",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.symbol.Validate()
+			if len(tt.expectedErrors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			for _, expectedError := range tt.expectedErrors {
+				assert.Contains(t, err.Error(), expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateMetricsConfig(t *testing.T) {
+	tests := []struct {
+		name           string
+		metric         MetricsConfig
+		expectedErrors []string
+	}{
+		{
+			name:   "valid scalar metric",
+			metric: MetricsConfig{Symbol: SymbolConfig{OID: "1.2.3", Name: "aMetric"}},
+		},
+		{
+			name: "invalid scalar metric",
+			metric: MetricsConfig{
+				Symbol: SymbolConfig{OID: "1.2.3", Name: "aMetric", ScaleFactor: -1},
+			},
+			expectedErrors: []string{"symbol `aMetric`: `scale_factor` must not be negative: -1"},
+		},
+		{
+			name: "valid table metric",
+			metric: MetricsConfig{
+				Table: SymbolConfig{OID: "1.2.4", Name: "aTable"},
+				Symbols: []SymbolConfig{
+					{OID: "1.2.4.1.1", Name: "aColumn"},
+					{Name: "aConstant", ConstantValueOne: true},
+				},
+				MetricTags: MetricTagConfigList{
+					{Tag: "index", Index: 1},
+					{Tag: "name", Column: SymbolConfig{OID: "1.2.4.1.2", Name: "aNameColumn"}},
+				},
+			},
+		},
+		{
+			name: "invalid table symbols and metric tags",
+			metric: MetricsConfig{
+				Table: SymbolConfig{OID: "1.2.4", Name: "aTable"},
+				Symbols: []SymbolConfig{
+					{OID: "1.2.4.1.1", Name: "aColumn"},
+					{OID: "1.2.4.1.x", Name: "anInvalidColumn"},
+				},
+				MetricTags: MetricTagConfigList{
+					{Tag: "index", Index: 1},
+					{Tag: "name", Column: SymbolConfig{OID: "1.2.4.1.2", Name: "aNameColumn", MatchPattern: "("}},
+				},
+			},
+			expectedErrors: []string{
+				"symbol `anInvalidColumn`: invalid OID `1.2.4.1.x`",
+				"symbol `aNameColumn`: cannot compile `match_pattern` (`(`)",
+			},
+		},
+		{
+			name: "flag stream metrics",
+			metric: MetricsConfig{
+				Table: SymbolConfig{OID: "1.2.4", Name: "aTable"},
+				Symbols: []SymbolConfig{
+					{OID: "1.2.4.1.1", Name: "aFlagStream", Format: "flags:0:2"},
+					{OID: "1.2.4.1.2", Name: "aFlagStreamWithoutFormat"},
+					{OID: "1.2.4.1.3", Name: "aGauge", MetricType: ProfileMetricTypeGauge},
+				},
+				MetricType: ProfileMetricTypeFlagStream,
+			},
+			expectedErrors: []string{"symbol `aFlagStreamWithoutFormat`: flag stream format is missing"},
+		},
+		{
+			name: "flag stream metric with placement",
+			metric: MetricsConfig{
+				Symbol:     SymbolConfig{OID: "1.2.3", Name: "aFlag"},
+				MetricType: ProfileMetricTypeFlagStream,
+				Options:    MetricsConfigOption{Placement: 2, MetricSuffix: "aSuffix"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMetricsConfig(tt.metric)
+			if len(tt.expectedErrors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			for _, expectedError := range tt.expectedErrors {
+				assert.Contains(t, err.Error(), expectedError)
+			}
+		})
+	}
+}
+
+func TestValidateFlagStreamConfig(t *testing.T) {
+	tests := []struct {
+		name          string
+		format        string
+		expectedStart int
+		expectedEnd   int
+		expectedError string
+	}{
+		{
+			name:          "valid format",
+			format:        "flags:0:4",
+			expectedStart: 0,
+			expectedEnd:   4,
+		},
+		{
+			name:          "single flag",
+			format:        "flags:3:4",
+			expectedStart: 3,
+			expectedEnd:   4,
+		},
+		{
+			name:          "out of order positions",
+			format:        "flags:4:2",
+			expectedError: "symbol `aFlag`: invalid flag stream format `flags:4:2`: start position 4 must be lower than end position 2",
+		},
+		{
+			name:          "empty range",
+			format:        "flags:2:2",
+			expectedError: "symbol `aFlag`: invalid flag stream format `flags:2:2`: start position 2 must be lower than end position 2",
+		},
+		{
+			name:          "invalid syntax",
+			format:        "flags:1",
+			expectedError: "symbol `aFlag`: invalid flag stream format `flags:1`, expected `flags:<n>:<m>`",
+		},
+		{
+			name:          "missing format",
+			expectedError: "symbol `aFlag`: flag stream format is missing",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateFlagStreamConfig(SymbolConfig{OID: "1.2.3", Name: "aFlag", Format: tt.format})
+			if tt.expectedError != "" {
+				assert.EqualError(t, err, tt.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+
+			start, end, err := ParseFlagStreamFormat(tt.format)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStart, start)
+			assert.Equal(t, tt.expectedEnd, end)
+		})
+	}
+}
+
+func TestMetricTagConfigValidate(t *testing.T) {
+	tests := []struct {
+		name           string
+		metricTag      MetricTagConfig
+		expectedErrors []string
+	}{
+		{
+			name:      "scalar symbol tag",
+			metricTag: MetricTagConfig{Tag: "snmp_host", OID: "1.3.6.1.2.1.1.5.0", Name: "sysName"},
+		},
+		{
+			name:      "column tag",
+			metricTag: MetricTagConfig{Tag: "interface", Column: SymbolConfig{OID: "1.3.6.1.2.1.31.1.1.1.1", Name: "ifName"}},
+		},
+		{
+			name:      "index tag",
+			metricTag: MetricTagConfig{Tag: "index", Index: 1},
+		},
+		{
+			name:      "match tag without tag name",
+			metricTag: MetricTagConfig{OID: "1.3.6.1.2.1.1.5.0", Name: "sysName", Match: "(\\w+)", Tags: map[string]string{"host": "\\1"}},
+		},
+		{
+			name:      "symbol tag",
+			metricTag: MetricTagConfig{SymbolTag: "snmp_host:foo"},
+		},
+		{
+			name: "mapping without tag name",
+			metricTag: MetricTagConfig{
+				Column:  SymbolConfig{OID: "1.3.6.1.2.1.2.2.1.3", Name: "ifType"},
+				Mapping: ListMap[string]{"1": "other", "6": "ethernet"},
+			},
+		},
+		{
+			name:           "OID without symbol",
+			metricTag:      MetricTagConfig{Tag: "snmp_host", OID: "1.3.6.1.2.1.1.5.0"},
+			expectedErrors: []string{"`OID` (`1.3.6.1.2.1.1.5.0`) is set without `symbol`"},
+		},
+		{
+			name:           "symbol without OID",
+			metricTag:      MetricTagConfig{Tag: "snmp_host", Name: "sysName"},
+			expectedErrors: []string{"`symbol` (`sysName`) is set without `OID`"},
+		},
+		{
+			name: "column and symbol both set",
+			metricTag: MetricTagConfig{
+				Tag:    "interface",
+				OID:    "1.3.6.1.2.1.1.5.0",
+				Name:   "sysName",
+				Column: SymbolConfig{OID: "1.3.6.1.2.1.31.1.1.1.1", Name: "ifName"},
+			},
+			expectedErrors: []string{"both `column` (`ifName`) and `symbol` (`sysName`) are set"},
+		},
+		{
+			name:           "empty tag",
+			metricTag:      MetricTagConfig{Column: SymbolConfig{OID: "1.3.6.1.2.1.31.1.1.1.1", Name: "ifName"}},
+			expectedErrors: []string{"`tag` is empty"},
+		},
+		{
+			name:      "multiple errors",
+			metricTag: MetricTagConfig{OID: "1.3.6.1.2.1.1.5.0", Column: SymbolConfig{Name: "ifName"}},
+			expectedErrors: []string{
+				"`OID` (`1.3.6.1.2.1.1.5.0`) is set without `symbol`",
+				"both `column` (`ifName`) and `symbol` (``) are set",
+				"`tag` is empty",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.metricTag.Validate()
+			if len(tt.expectedErrors) == 0 {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			for _, expectedError := range tt.expectedErrors {
+				assert.Contains(t, err.Error(), expectedError)
+			}
+		})
+	}
+}
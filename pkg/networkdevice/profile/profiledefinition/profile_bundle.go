@@ -0,0 +1,27 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package profiledefinition
+
+// ProfileBundleResponse represents a bundle of profiles, as served (gzipped JSON)
+// by a profiles bundle endpoint.
+// ProfileBundleVersion is serialized first, so that the version of a bundle can be
+// read without decompressing its profiles.
+type ProfileBundleResponse struct {
+	ProfileBundleVersion string                     `json:"profile_bundle_version,omitempty"`
+	CreatedAt            int64                      `json:"created_at"`
+	Profiles             []ProfileBundleProfileItem `json:"profiles"`
+}
+
+// ProfileBundleProfileItem represents a profile of a bundle
+type ProfileBundleProfileItem struct {
+	Metadata ProfileBundleProfileMetadata `json:"metadata"`
+	Profile  ProfileDefinition            `json:"profile_definition"`
+}
+
+// ProfileBundleProfileMetadata holds the metadata of a profile of a bundle
+type ProfileBundleProfileMetadata struct {
+	IsUserProfile bool `json:"is_user_profile"`
+}
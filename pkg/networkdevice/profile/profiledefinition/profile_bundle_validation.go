@@ -0,0 +1,53 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package profiledefinition
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ValidateProfileBundle checks that the metric OIDs of a bundle are not defined
+// by several profiles, since their metrics would be collected twice. One error
+// is returned per OID and pair of profiles defining it.
+func ValidateProfileBundle(bundle *ProfileBundleResponse) []error {
+	if bundle == nil {
+		return nil
+	}
+
+	// OID -> names of the profiles defining it, in bundle order
+	oidProfiles := make(map[string][]string)
+	for _, item := range bundle.Profiles {
+		profileOIDs := make(map[string]struct{})
+		for _, metric := range item.Profile.Metrics {
+			for oid := range metricOIDs(metric) {
+				profileOIDs[oid] = struct{}{}
+			}
+		}
+		for oid := range profileOIDs {
+			oidProfiles[oid] = append(oidProfiles[oid], item.Profile.Name)
+		}
+	}
+
+	oids := make([]string, 0, len(oidProfiles))
+	for oid, profiles := range oidProfiles {
+		if len(profiles) > 1 {
+			oids = append(oids, oid)
+		}
+	}
+	sort.Strings(oids)
+
+	var errs []error
+	for _, oid := range oids {
+		profiles := oidProfiles[oid]
+		for i := 0; i < len(profiles); i++ {
+			for j := i + 1; j < len(profiles); j++ {
+				errs = append(errs, fmt.Errorf("OID `%s` is defined by both profiles `%s` and `%s`", oid, profiles[i], profiles[j]))
+			}
+		}
+	}
+	return errs
+}
@@ -0,0 +1,100 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package profiledefinition
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newBundleProfile(name string, metrics ...MetricsConfig) ProfileBundleProfileItem {
+	return ProfileBundleProfileItem{
+		Profile: ProfileDefinition{
+			Name:    name,
+			Metrics: metrics,
+		},
+	}
+}
+
+func scalarMetric(oid string, name string) MetricsConfig {
+	return MetricsConfig{Symbol: SymbolConfig{OID: oid, Name: name}}
+}
+
+func tableMetric(oids ...string) MetricsConfig {
+	metric := MetricsConfig{MIB: "IF-MIB", Table: SymbolConfig{OID: "1.3.6.1.2.1.2.2", Name: "ifTable"}}
+	for _, oid := range oids {
+		metric.Symbols = append(metric.Symbols, SymbolConfig{OID: oid, Name: "symbol" + oid})
+	}
+	return metric
+}
+
+func TestValidateProfileBundle(t *testing.T) {
+	tests := []struct {
+		name           string
+		bundle         *ProfileBundleResponse
+		expectedErrors []error
+	}{
+		{
+			name: "clean bundle",
+			bundle: &ProfileBundleResponse{
+				Profiles: []ProfileBundleProfileItem{
+					newBundleProfile("profile-a", scalarMetric("1.3.6.1.2.1.1.3.0", "sysUpTimeInstance"), tableMetric("1.3.6.1.2.1.2.2.1.14")),
+					newBundleProfile("profile-b", scalarMetric("1.3.6.1.2.1.1.7.0", "sysServices"), tableMetric("1.3.6.1.2.1.2.2.1.20")),
+				},
+			},
+		},
+		{
+			name: "same OID defined twice by a profile",
+			bundle: &ProfileBundleResponse{
+				Profiles: []ProfileBundleProfileItem{
+					newBundleProfile("profile-a", scalarMetric("1.3.6.1.2.1.1.3.0", "sysUpTimeInstance"), scalarMetric("1.3.6.1.2.1.1.3.0", "sysUpTimeInstance")),
+				},
+			},
+		},
+		{
+			name: "partially overlapping bundle",
+			bundle: &ProfileBundleResponse{
+				Profiles: []ProfileBundleProfileItem{
+					newBundleProfile("profile-a", scalarMetric("1.3.6.1.2.1.1.3.0", "sysUpTimeInstance"), tableMetric("1.3.6.1.2.1.2.2.1.14")),
+					newBundleProfile("profile-b", scalarMetric("1.3.6.1.2.1.1.7.0", "sysServices"), tableMetric("1.3.6.1.2.1.2.2.1.14", "1.3.6.1.2.1.2.2.1.20")),
+					newBundleProfile("profile-c", scalarMetric("1.3.6.1.2.1.1.5.0", "sysName")),
+				},
+			},
+			expectedErrors: []error{
+				errors.New("OID `1.3.6.1.2.1.2.2.1.14` is defined by both profiles `profile-a` and `profile-b`"),
+			},
+		},
+		{
+			name: "fully conflicting bundle",
+			bundle: &ProfileBundleResponse{
+				Profiles: []ProfileBundleProfileItem{
+					newBundleProfile("profile-a", scalarMetric("1.3.6.1.2.1.1.3.0", "sysUpTimeInstance"), tableMetric("1.3.6.1.2.1.2.2.1.14")),
+					newBundleProfile("profile-b", scalarMetric("1.3.6.1.2.1.1.3.0", "sysUpTimeInstance"), tableMetric("1.3.6.1.2.1.2.2.1.14")),
+					// legacy scalar syntax
+					newBundleProfile("profile-c", MetricsConfig{OID: "1.3.6.1.2.1.1.3.0", Name: "sysUpTimeInstance"}, tableMetric("1.3.6.1.2.1.2.2.1.14")),
+				},
+			},
+			expectedErrors: []error{
+				errors.New("OID `1.3.6.1.2.1.1.3.0` is defined by both profiles `profile-a` and `profile-b`"),
+				errors.New("OID `1.3.6.1.2.1.1.3.0` is defined by both profiles `profile-a` and `profile-c`"),
+				errors.New("OID `1.3.6.1.2.1.1.3.0` is defined by both profiles `profile-b` and `profile-c`"),
+				errors.New("OID `1.3.6.1.2.1.2.2.1.14` is defined by both profiles `profile-a` and `profile-b`"),
+				errors.New("OID `1.3.6.1.2.1.2.2.1.14` is defined by both profiles `profile-a` and `profile-c`"),
+				errors.New("OID `1.3.6.1.2.1.2.2.1.14` is defined by both profiles `profile-b` and `profile-c`"),
+			},
+		},
+		{
+			name: "nil bundle",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedErrors, ValidateProfileBundle(tt.bundle))
+		})
+	}
+}
@@ -45,6 +45,8 @@ type ContainerCheck struct {
 	containerFailedLogLimit *util.LogLimit
 
 	maxBatchSize int
+
+	intervalOverride time.Duration
 }
 
 // Init initializes a ContainerCheck instance.
@@ -69,6 +71,17 @@ func (c *ContainerCheck) IsEnabled() bool {
 	return canEnableContainerChecks(c.config, true)
 }
 
+// SetIntervalOverride runs the check every interval instead of the configured interval.
+// It must be called before the check is scheduled.
+func (c *ContainerCheck) SetIntervalOverride(interval time.Duration) {
+	c.intervalOverride = interval
+}
+
+// IntervalOverride implements IntervalOverrider#IntervalOverride
+func (c *ContainerCheck) IntervalOverride() (time.Duration, bool) {
+	return c.intervalOverride, c.intervalOverride > 0
+}
+
 // SupportsRunOptions returns true if the check supports RunOptions
 func (c *ContainerCheck) SupportsRunOptions() bool {
 	return false
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	model "github.com/DataDog/agent-payload/v5/process"
+	"go.uber.org/atomic"
 
 	ddconfig "github.com/DataDog/datadog-agent/pkg/config"
 	proccontainers "github.com/DataDog/datadog-agent/pkg/process/util/containers"
@@ -23,7 +24,10 @@ const (
 // NewRTContainerCheck returns an instance of the RTContainerCheck.
 func NewRTContainerCheck(config ddconfig.ConfigReader) *RTContainerCheck {
 	return &RTContainerCheck{
-		config: config,
+		config:       config,
+		lastRunStart: atomic.NewTime(time.Time{}),
+		lastRunEnd:   atomic.NewTime(time.Time{}),
+		paused:       atomic.NewBool(false),
 	}
 }
 
@@ -34,6 +38,9 @@ type RTContainerCheck struct {
 	containerProvider proccontainers.ContainerProvider
 	lastRates         map[string]*proccontainers.ContainerRateMetrics
 	config            ddconfig.ConfigReader
+	lastRunStart      *atomic.Time
+	lastRunEnd        *atomic.Time
+	paused            *atomic.Bool
 }
 
 // Init initializes a RTContainerCheck instance.
@@ -65,7 +72,15 @@ func (r *RTContainerCheck) Realtime() bool { return true }
 func (r *RTContainerCheck) ShouldSaveLastRun() bool { return true }
 
 // Run runs the real-time container check getting container-level stats from the Cgroups and Docker APIs.
+// Runs of a paused check collect nothing.
 func (r *RTContainerCheck) Run(nextGroupID func() int32, _ *RunOptions) (RunResult, error) {
+	if r.paused.Load() {
+		return nil, nil
+	}
+
+	r.lastRunStart.Store(time.Now())
+	defer func() { r.lastRunEnd.Store(time.Now()) }()
+
 	var err error
 	var containers []*model.Container
 	var lastRates map[string]*proccontainers.ContainerRateMetrics
@@ -103,6 +118,27 @@ func (r *RTContainerCheck) Run(nextGroupID func() int32, _ *RunOptions) (RunResu
 	return StandardRunResult(messages), nil
 }
 
+// LastRun returns when the latest run of the check started and when the latest run completed.
+// A start after the end means a run is in progress. Both are zero if the check never ran.
+func (r *RTContainerCheck) LastRun() (start, end time.Time) {
+	return r.lastRunStart.Load(), r.lastRunEnd.Load()
+}
+
+// Pause stops the collection of realtime container stats until Resume is called
+func (r *RTContainerCheck) Pause() {
+	r.paused.Store(true)
+}
+
+// Resume restarts the collection of realtime container stats after Pause
+func (r *RTContainerCheck) Resume() {
+	r.paused.Store(false)
+}
+
+// IsPaused returns true if the check is paused
+func (r *RTContainerCheck) IsPaused() bool {
+	return r.paused.Load()
+}
+
 // Cleanup frees any resource held by the RTContainerCheck before the agent exits
 func (r *RTContainerCheck) Cleanup() {}
 
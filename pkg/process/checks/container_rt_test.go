@@ -0,0 +1,34 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package checks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/config"
+)
+
+func TestRTContainerCheckPause(t *testing.T) {
+	check := NewRTContainerCheck(config.Mock(t))
+	assert.False(t, check.IsPaused())
+
+	check.Pause()
+	assert.True(t, check.IsPaused())
+	// no container provider is set up: a paused check must not collect anything
+	result, err := check.Run(func() int32 { return 0 }, nil)
+	require.NoError(t, err)
+	assert.Nil(t, result)
+	// paused runs are not tracked
+	start, end := check.LastRun()
+	assert.True(t, start.IsZero())
+	assert.True(t, end.IsZero())
+
+	check.Resume()
+	assert.False(t, check.IsPaused())
+}
@@ -56,6 +56,22 @@ var (
 	}
 )
 
+// IntervalOverrider is implemented by checks whose interval can be set independently of the configuration
+type IntervalOverrider interface {
+	// IntervalOverride returns the interval of the check and whether it is overridden
+	IntervalOverride() (time.Duration, bool)
+}
+
+// GetCheckInterval returns the interval of the check, taking its IntervalOverrider override into account
+func GetCheckInterval(cfg config.ConfigReader, c Check) time.Duration {
+	if overrider, ok := c.(IntervalOverrider); ok {
+		if interval, overridden := overrider.IntervalOverride(); overridden {
+			return interval
+		}
+	}
+	return GetInterval(cfg, c.Name())
+}
+
 // GetDefaultInterval returns the default check interval value
 func GetDefaultInterval(checkName string) time.Duration {
 	return defaultIntervals[checkName]
@@ -317,7 +317,7 @@ func (l *CheckRunner) runnerForCheck(c checks.Check) (func(), error) {
 	}
 
 	rtName := checks.RTName(c.Name())
-	interval := checks.GetInterval(l.config, c.Name())
+	interval := checks.GetCheckInterval(l.config, c)
 	rtInterval := checks.GetInterval(l.config, rtName)
 
 	if interval < rtInterval || interval%rtInterval != 0 {
@@ -360,7 +360,7 @@ func (l *CheckRunner) basicRunner(c checks.Check) func() {
 			l.runCheck(c)
 		}
 
-		ticker := time.NewTicker(checks.GetInterval(l.config, c.Name()))
+		ticker := time.NewTicker(checks.GetCheckInterval(l.config, c))
 		for {
 			select {
 			case <-ticker.C:
@@ -6,9 +6,11 @@
 package traps
 
 import (
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"hash/fnv"
+	"strings"
 
 	"github.com/gosnmp/gosnmp"
 
@@ -41,8 +43,20 @@ type Config struct {
 	CommunityStrings      []string `mapstructure:"community_strings" yaml:"community_strings"`
 	BindHost              string   `mapstructure:"bind_host" yaml:"bind_host"`
 	StopTimeout           int      `mapstructure:"stop_timeout" yaml:"stop_timeout"`
+	DrainTimeout          int      `mapstructure:"drain_timeout" yaml:"drain_timeout"`
 	Namespace             string   `mapstructure:"namespace" yaml:"namespace"`
+	OIDCacheSize          int      `mapstructure:"oid_cache_size" yaml:"oid_cache_size"`
+	ContextEngineID       string   `mapstructure:"context_engine_id" yaml:"context_engine_id"`
+	ContextName           string   `mapstructure:"context_name" yaml:"context_name"`
+	RateLimitPerSource    float64  `mapstructure:"rate_limit_per_source" yaml:"rate_limit_per_source"`
+	RateLimitBurst        int      `mapstructure:"rate_limit_burst" yaml:"rate_limit_burst"`
+	TLSCertFile           string   `mapstructure:"tls_cert_file" yaml:"tls_cert_file"`
+	TLSKeyFile            string   `mapstructure:"tls_key_file" yaml:"tls_key_file"`
+	TLSCAFile             string   `mapstructure:"tls_ca_file" yaml:"tls_ca_file"`
+	EnrichWithSysname     bool     `mapstructure:"enrich_with_sysname" yaml:"enrich_with_sysname"`
+	SysnameCacheTTL       int      `mapstructure:"sysname_cache_ttl" yaml:"sysname_cache_ttl"`
 	authoritativeEngineID string   `mapstructure:"-" yaml:"-"`
+	contextEngineID       string   `mapstructure:"-" yaml:"-"`
 }
 
 // ReadConfig builds and returns configuration from Agent configuration.
@@ -73,19 +87,29 @@ func ReadConfig(agentHostname string) (*Config, error) {
 	if c.StopTimeout == 0 {
 		c.StopTimeout = defaultStopTimeout
 	}
+	if c.DrainTimeout == 0 {
+		c.DrainTimeout = defaultDrainTimeout
+	}
+	if c.OIDCacheSize <= 0 {
+		c.OIDCacheSize = defaultOIDCacheSize
+	}
+	if c.SysnameCacheTTL <= 0 {
+		c.SysnameCacheTTL = defaultSysnameCacheTTL
+	}
 
-	if agentHostname == "" {
-		// Make sure to have at least some unique bytes for the authoritative engineID.
-		// Unlikely to happen since the agent cannot start without a hostname
-		agentHostname = "unknown-datadog-agent"
+	if (c.TLSCertFile == "") != (c.TLSKeyFile == "") || (c.TLSCertFile == "") != (c.TLSCAFile == "") {
+		return nil, errors.New("tls_cert_file, tls_key_file and tls_ca_file must be either all set or all empty")
 	}
-	h := fnv.New128()
-	h.Write([]byte(agentHostname))
-	// First byte is always 0x80
-	// Next four bytes are the Private Enterprise Number (set to an invalid value here)
-	// The next 16 bytes are the hash of the agent hostname
-	engineID := h.Sum([]byte{0x80, 0xff, 0xff, 0xff, 0xff})
-	c.authoritativeEngineID = string(engineID)
+
+	if c.ContextEngineID != "" {
+		contextEngineID, err := hex.DecodeString(strings.TrimPrefix(c.ContextEngineID, "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("context_engine_id must be a valid hex string: %w", err)
+		}
+		c.contextEngineID = string(contextEngineID)
+	}
+
+	c.authoritativeEngineID = AuthoritativeEngineID(agentHostname)
 
 	if c.Namespace == "" {
 		c.Namespace = config.Datadog.GetString("network_devices.namespace")
@@ -98,6 +122,27 @@ func ReadConfig(agentHostname string) (*Config, error) {
 	return &c, nil
 }
 
+// AuthoritativeEngineID returns the SNMPv3 authoritative engine ID of the listener
+// of the Agent running on agentHostname.
+func AuthoritativeEngineID(agentHostname string) string {
+	if agentHostname == "" {
+		// Make sure to have at least some unique bytes for the authoritative engineID.
+		// Unlikely to happen since the agent cannot start without a hostname
+		agentHostname = "unknown-datadog-agent"
+	}
+	h := fnv.New128()
+	h.Write([]byte(agentHostname))
+	// First byte is always 0x80
+	// Next four bytes are the Private Enterprise Number (set to an invalid value here)
+	// The next 16 bytes are the hash of the agent hostname
+	return string(h.Sum([]byte{0x80, 0xff, 0xff, 0xff, 0xff}))
+}
+
+// TLSEnabled returns whether traps are received over DTLS instead of plain UDP.
+func (c *Config) TLSEnabled() bool {
+	return c.TLSCertFile != ""
+}
+
 // Addr returns the host:port address to listen on.
 func (c *Config) Addr() string {
 	return fmt.Sprintf("%s:%d", c.BindHost, c.Port)
@@ -137,6 +182,9 @@ func (c *Config) BuildSNMPParams() (*gosnmp.GoSNMP, error) {
 		Version:       gosnmp.Version3, // Always using version3 for traps, only option that works with all SNMP versions simultaneously
 		SecurityModel: gosnmp.UserSecurityModel,
 		MsgFlags:      msgFlags,
+		// The context is only used by SNMPv3 inform requests
+		ContextEngineID: c.contextEngineID,
+		ContextName:     c.ContextName,
 		SecurityParameters: &gosnmp.UsmSecurityParameters{
 			UserName:                 user.Username,
 			AuthoritativeEngineID:    c.authoritativeEngineID,
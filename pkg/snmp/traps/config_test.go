@@ -34,10 +34,12 @@ func TestFullConfig(t *testing.T) {
 				PrivProtocol: "AES",
 			},
 		},
-		BindHost:         "127.0.0.1",
-		CommunityStrings: []string{"public"},
-		StopTimeout:      12,
-		Namespace:        "foo",
+		BindHost:           "127.0.0.1",
+		CommunityStrings:   []string{"public"},
+		StopTimeout:        12,
+		Namespace:          "foo",
+		RateLimitPerSource: 10,
+		RateLimitBurst:     20,
 	})
 	config, err := ReadConfig(mockedHostname)
 	assert.NoError(t, err)
@@ -46,6 +48,8 @@ func TestFullConfig(t *testing.T) {
 	assert.Equal(t, []string{"public"}, config.CommunityStrings)
 	assert.Equal(t, "127.0.0.1", config.BindHost)
 	assert.Equal(t, "foo", config.Namespace)
+	assert.Equal(t, 10.0, config.RateLimitPerSource)
+	assert.Equal(t, 20, config.RateLimitBurst)
 	assert.Equal(t, []UserV3{
 		{
 			Username:     "user",
@@ -79,6 +83,11 @@ func TestMinimalConfig(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, uint16(9162), config.Port)
 	assert.Equal(t, 5, config.StopTimeout)
+	assert.Equal(t, 1024, config.OIDCacheSize)
+	assert.Equal(t, 5, config.DrainTimeout)
+	assert.Equal(t, 0.0, config.RateLimitPerSource)
+	assert.False(t, config.EnrichWithSysname)
+	assert.Equal(t, 3600, config.SysnameCacheTTL)
 	assert.Equal(t, []string{}, config.CommunityStrings)
 	assert.Equal(t, "0.0.0.0", config.BindHost)
 	assert.Equal(t, []UserV3{}, config.Users)
@@ -150,3 +159,52 @@ func TestNamespaceSetBothGloballyAndLocally(t *testing.T) {
 
 	assert.Equal(t, "bar", config.Namespace)
 }
+
+func TestContextConfig(t *testing.T) {
+	Configure(t, Config{
+		Users:           []UserV3{{Username: "user", AuthKey: "password", AuthProtocol: "SHA"}},
+		ContextEngineID: "80001f8880e9630000d61ff449",
+		ContextName:     "public",
+	})
+
+	config, err := ReadConfig(mockedHostname)
+	assert.NoError(t, err)
+	assert.Equal(t, "80001f8880e9630000d61ff449", config.ContextEngineID)
+	assert.Equal(t, "public", config.ContextName)
+
+	params, err := config.BuildSNMPParams()
+	assert.NoError(t, err)
+	assert.Equal(t, "\x80\x00\x1f\x88\x80\xe9\x63\x00\x00\xd6\x1f\xf4\x49", params.ContextEngineID)
+	assert.Equal(t, "public", params.ContextName)
+}
+
+func TestContextEngineIDWithPrefix(t *testing.T) {
+	Configure(t, Config{ContextEngineID: "0x8000"})
+
+	config, err := ReadConfig("")
+	assert.NoError(t, err)
+	assert.Equal(t, "\x80\x00", config.contextEngineID)
+}
+
+func TestInvalidContextEngineID(t *testing.T) {
+	for _, contextEngineID := range []string{"not-hex", "800"} {
+		Configure(t, Config{ContextEngineID: contextEngineID})
+
+		_, err := ReadConfig("")
+		assert.ErrorContains(t, err, "context_engine_id must be a valid hex string", contextEngineID)
+	}
+}
+
+func TestPartialTLSConfig(t *testing.T) {
+	for _, c := range []Config{
+		{TLSCertFile: "cert.pem"},
+		{TLSCertFile: "cert.pem", TLSKeyFile: "key.pem"},
+		{TLSKeyFile: "key.pem", TLSCAFile: "ca.pem"},
+		{TLSCAFile: "ca.pem"},
+	} {
+		Configure(t, c)
+
+		_, err := ReadConfig("")
+		assert.ErrorContains(t, err, "tls_cert_file, tls_key_file and tls_ca_file must be either all set or all empty")
+	}
+}
@@ -6,8 +6,11 @@
 package traps
 
 const (
-	defaultPort        = uint16(9162) // Standard UDP port for traps.
-	defaultStopTimeout = 5
-	packetsChanSize    = 100
-	genericTrapOid     = "1.3.6.1.6.3.1.1.5"
+	defaultPort            = uint16(9162) // Standard UDP port for traps.
+	defaultStopTimeout     = 5
+	defaultDrainTimeout    = 5
+	defaultOIDCacheSize    = 1024
+	defaultSysnameCacheTTL = 3600 // in seconds
+	packetsChanSize        = 100
+	genericTrapOid         = "1.3.6.1.6.3.1.1.5"
 )
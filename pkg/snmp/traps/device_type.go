@@ -0,0 +1,95 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package traps
+
+import (
+	"github.com/gosnmp/gosnmp"
+)
+
+const sysObjectIDInstanceOID = "1.3.6.1.2.1.1.2.0"
+
+// DeviceType is the vendor and the type of a device, eg. "fortinet" and "firewall".
+// Type is empty when only the vendor is known.
+type DeviceType struct {
+	Vendor string
+	Type   string
+}
+
+// DeviceTypeEnricher infers the type of a device from an OID identifying it, usually its sysObjectID
+type DeviceTypeEnricher interface {
+	GetDeviceType(oid string) (DeviceType, bool)
+}
+
+// defaultDeviceTypes maps enterprise OID prefixes to the vendor and the type of the devices under them
+var defaultDeviceTypes = map[string]DeviceType{
+	"1.3.6.1.4.1.9":            {Vendor: "cisco"},
+	"1.3.6.1.4.1.11":           {Vendor: "hp"},
+	"1.3.6.1.4.1.11.2.3.9.1":   {Vendor: "hp", Type: "printer"},
+	"1.3.6.1.4.1.311":          {Vendor: "microsoft"},
+	"1.3.6.1.4.1.311.1.1.3.1":  {Vendor: "microsoft", Type: "server"},
+	"1.3.6.1.4.1.318":          {Vendor: "apc"},
+	"1.3.6.1.4.1.318.1.3.4":    {Vendor: "apc", Type: "pdu"},
+	"1.3.6.1.4.1.318.1.3.27":   {Vendor: "apc", Type: "ups"},
+	"1.3.6.1.4.1.674":          {Vendor: "dell"},
+	"1.3.6.1.4.1.674.10892.5":  {Vendor: "dell", Type: "server"},
+	"1.3.6.1.4.1.789":          {Vendor: "netapp", Type: "storage"},
+	"1.3.6.1.4.1.2011":         {Vendor: "huawei"},
+	"1.3.6.1.4.1.2620":         {Vendor: "checkpoint", Type: "firewall"},
+	"1.3.6.1.4.1.2636":         {Vendor: "juniper"},
+	"1.3.6.1.4.1.3375":         {Vendor: "f5"},
+	"1.3.6.1.4.1.3375.2.1.3.4": {Vendor: "f5", Type: "load_balancer"},
+	"1.3.6.1.4.1.6876":         {Vendor: "vmware"},
+	"1.3.6.1.4.1.6876.4.1":     {Vendor: "vmware", Type: "server"},
+	"1.3.6.1.4.1.8072.3.2.10":  {Vendor: "net-snmp", Type: "server"},
+	"1.3.6.1.4.1.12356":        {Vendor: "fortinet"},
+	"1.3.6.1.4.1.12356.101.1":  {Vendor: "fortinet", Type: "firewall"},
+	"1.3.6.1.4.1.14823":        {Vendor: "aruba"},
+	"1.3.6.1.4.1.14988":        {Vendor: "mikrotik"},
+	"1.3.6.1.4.1.14988.1":      {Vendor: "mikrotik", Type: "router"},
+	"1.3.6.1.4.1.25461":        {Vendor: "paloalto"},
+	"1.3.6.1.4.1.25461.2.3":    {Vendor: "paloalto", Type: "firewall"},
+	"1.3.6.1.4.1.30065":        {Vendor: "arista"},
+	"1.3.6.1.4.1.30065.1":      {Vendor: "arista", Type: "switch"},
+	"1.3.6.1.4.1.41112":        {Vendor: "ubiquiti"},
+}
+
+// PrefixDeviceTypeEnricher is a DeviceTypeEnricher that looks up the longest
+// OID prefix of a table matching an OID.
+type PrefixDeviceTypeEnricher struct {
+	deviceTypes map[string]DeviceType
+}
+
+// NewPrefixDeviceTypeEnricher creates a PrefixDeviceTypeEnricher using the
+// built-in table of OID prefixes.
+func NewPrefixDeviceTypeEnricher() *PrefixDeviceTypeEnricher {
+	return &PrefixDeviceTypeEnricher{deviceTypes: defaultDeviceTypes}
+}
+
+// GetDeviceType returns the device type of the longest prefix matching oid, if any
+func (e *PrefixDeviceTypeEnricher) GetDeviceType(oid string) (DeviceType, bool) {
+	return lookupLongestOIDPrefix(e.deviceTypes, NormalizeOID(oid))
+}
+
+// getDeviceOID returns the OID the most likely to identify the device that
+// sent a trap: its sysObjectID if it is part of the trap variables, or else
+// the enterprise OID of a v1 trap, or else the trap OID.
+func getDeviceOID(content *gosnmp.SnmpPacket, trapOID string) string {
+	for _, variable := range content.Variables {
+		if NormalizeOID(variable.Name) != sysObjectIDInstanceOID {
+			continue
+		}
+		switch value := variable.Value.(type) {
+		case string:
+			return NormalizeOID(value)
+		case []byte:
+			return NormalizeOID(string(value))
+		}
+	}
+	if content.Version == gosnmp.Version1 {
+		return NormalizeOID(content.Enterprise)
+	}
+	return trapOID
+}
@@ -0,0 +1,118 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package traps
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+)
+
+func TestPrefixDeviceTypeEnricher(t *testing.T) {
+	enricher := NewPrefixDeviceTypeEnricher()
+
+	tests := []struct {
+		name               string
+		oid                string
+		expectedDeviceType DeviceType
+		expectedFound      bool
+	}{
+		{
+			name:               "vendor and type",
+			oid:                "1.3.6.1.4.1.12356.101.1.1000",
+			expectedDeviceType: DeviceType{Vendor: "fortinet", Type: "firewall"},
+			expectedFound:      true,
+		},
+		{
+			name:               "absolute OID",
+			oid:                ".1.3.6.1.4.1.25461.2.3.18",
+			expectedDeviceType: DeviceType{Vendor: "paloalto", Type: "firewall"},
+			expectedFound:      true,
+		},
+		{
+			name:               "vendor only",
+			oid:                "1.3.6.1.4.1.12356.100.1",
+			expectedDeviceType: DeviceType{Vendor: "fortinet"},
+			expectedFound:      true,
+		},
+		{
+			name:               "exact prefix",
+			oid:                "1.3.6.1.4.1.9",
+			expectedDeviceType: DeviceType{Vendor: "cisco"},
+			expectedFound:      true,
+		},
+		{
+			name:          "prefix must end on an OID component",
+			oid:           "1.3.6.1.4.1.99999.1",
+			expectedFound: false,
+		},
+		{
+			name:          "unknown enterprise",
+			oid:           "1.3.6.1.4.1.1234.1.2",
+			expectedFound: false,
+		},
+		{
+			name:          "not an enterprise OID",
+			oid:           "1.3.6.1.6.3.1.1.5.4",
+			expectedFound: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deviceType, found := enricher.GetDeviceType(tt.oid)
+			assert.Equal(t, tt.expectedFound, found)
+			assert.Equal(t, tt.expectedDeviceType, deviceType)
+		})
+	}
+}
+
+func TestGetDeviceOID(t *testing.T) {
+	v2Packet := &gosnmp.SnmpPacket{Version: gosnmp.Version2c}
+	assert.Equal(t, "1.3.6.1.4.1.9.9.41.2.0.1", getDeviceOID(v2Packet, "1.3.6.1.4.1.9.9.41.2.0.1"))
+
+	v2PacketWithSysObjectID := &gosnmp.SnmpPacket{
+		Version: gosnmp.Version2c,
+		Variables: []gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.2.1.1.2.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.4.1.30065.1.3011"},
+		},
+	}
+	assert.Equal(t, "1.3.6.1.4.1.30065.1.3011", getDeviceOID(v2PacketWithSysObjectID, "1.3.6.1.6.3.1.1.5.4"))
+
+	v1Packet := &gosnmp.SnmpPacket{Version: gosnmp.Version1, SnmpTrap: gosnmp.SnmpTrap{Enterprise: ".1.3.6.1.4.1.318.1.3.27"}}
+	assert.Equal(t, "1.3.6.1.4.1.318.1.3.27", getDeviceOID(v1Packet, "1.3.6.1.6.3.1.1.5.3"))
+}
+
+func TestFormatPacketWithDeviceType(t *testing.T) {
+	mockSender := mocksender.NewMockSender("snmp-traps-telemetry")
+	mockSender.SetupAcceptAll()
+	formatter, err := NewJSONFormatter(NoOpOIDResolver{}, mockSender)
+	require.NoError(t, err)
+
+	formatTrap := func(packet *SnmpPacket) map[string]interface{} {
+		formattedPacket, err := formatter.FormatPacket(packet)
+		require.NoError(t, err)
+		data := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal(formattedPacket, &data))
+		return data["trap"].(map[string]interface{})
+	}
+
+	// known device
+	trap := LinkDownv1GenericTrap
+	trap.Enterprise = ".1.3.6.1.4.1.318.1.3.27"
+	trapContent := formatTrap(createTestV1Packet(trap))
+	assert.Equal(t, "apc", trapContent["device_vendor"])
+	assert.Equal(t, "ups", trapContent["device_type"])
+
+	// unknown device
+	trapContent = formatTrap(createTestV1GenericPacket())
+	assert.NotContains(t, trapContent, "device_vendor")
+	assert.NotContains(t, trapContent, "device_type")
+}
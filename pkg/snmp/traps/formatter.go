@@ -26,8 +26,11 @@ type Formatter interface {
 
 // JSONFormatter is a Formatter implementation that transforms Traps into JSON
 type JSONFormatter struct {
-	oidResolver OIDResolver
-	aggregator  sender.Sender
+	oidResolver        OIDResolver
+	aggregator         sender.Sender
+	deviceTypeEnricher DeviceTypeEnricher
+	sysNameResolver    SysNameResolver
+	varbindFormatter   VarbindFormatter
 }
 
 type trapVariable struct {
@@ -50,7 +53,12 @@ func NewJSONFormatter(oidResolver OIDResolver, aggregator sender.Sender) (JSONFo
 	if oidResolver == nil {
 		return JSONFormatter{}, fmt.Errorf("NewJSONFormatter called with a nil OIDResolver")
 	}
-	return JSONFormatter{oidResolver, aggregator}, nil
+	return JSONFormatter{
+		oidResolver:        oidResolver,
+		aggregator:         aggregator,
+		deviceTypeEnricher: NewPrefixDeviceTypeEnricher(),
+		varbindFormatter:   NoopVarbindFormatter{},
+	}, nil
 }
 
 // FormatPacket converts a raw SNMP trap packet to a FormattedSnmpPacket containing the JSON data and the tags to attach
@@ -58,11 +66,13 @@ func NewJSONFormatter(oidResolver OIDResolver, aggregator sender.Sender) (JSONFo
 //	{
 //		"trap": {
 //	   "ddsource": "snmp-traps",
-//	   "ddtags": "namespace:default,snmp_device:10.0.0.2,...",
+//	   "ddtags": "namespace:default,snmp_device:10.0.0.2,...", # snmp_device_name when enrich_with_sysname is enabled
 //	   "timestamp": 123456789,
 //	   "snmpTrapName": "...",
 //	   "snmpTrapOID": "1.3.6.1.5.3.....",
 //	   "snmpTrapMIB": "...",
+//	   "device_vendor": "...", # when the device is known
+//	   "device_type": "...", # when the device is known
 //	   "uptime": "12345",
 //	   "genericTrap": "5", # v1 only
 //	   "specificTrap": "0",  # v1 only
@@ -88,8 +98,15 @@ func (f JSONFormatter) FormatPacket(packet *SnmpPacket) ([]byte, error) {
 			return nil, err
 		}
 	}
+	f.enrichDeviceType(packet.Content, formattedTrap)
 	formattedTrap["ddsource"] = ddsource
-	formattedTrap["ddtags"] = strings.Join(packet.getTags(), ",")
+	tags := packet.getTags()
+	if f.sysNameResolver != nil {
+		if sysName, ok := f.sysNameResolver.GetSysName(packet); ok {
+			tags = append(tags, "snmp_device_name:"+sysName)
+		}
+	}
+	formattedTrap["ddtags"] = strings.Join(tags, ",")
 	formattedTrap["timestamp"] = packet.Timestamp
 	payload["trap"] = formattedTrap
 	return json.Marshal(payload)
@@ -187,6 +204,22 @@ func (f JSONFormatter) formatTrap(packet *SnmpPacket) (map[string]interface{}, e
 	return data, nil
 }
 
+// enrichDeviceType adds the vendor and the type of the device that sent the trap, when they can be inferred
+func (f JSONFormatter) enrichDeviceType(content *gosnmp.SnmpPacket, data map[string]interface{}) {
+	if f.deviceTypeEnricher == nil {
+		return
+	}
+	trapOID, _ := data["snmpTrapOID"].(string)
+	deviceType, ok := f.deviceTypeEnricher.GetDeviceType(getDeviceOID(content, trapOID))
+	if !ok {
+		return
+	}
+	data["device_vendor"] = deviceType.Vendor
+	if deviceType.Type != "" {
+		data["device_type"] = deviceType.Type
+	}
+}
+
 // NormalizeOID convert an OID from the absolute form ".1.2.3..." to a relative form "1.2.3..."
 func NormalizeOID(value string) string {
 	// OIDs can be formatted as ".1.2.3..." ("absolute form") or "1.2.3..." ("relative form").
@@ -194,6 +227,23 @@ func NormalizeOID(value string) string {
 	return strings.TrimLeft(value, ".")
 }
 
+// lookupLongestOIDPrefix returns the value of the longest key of values that is oid
+// or one of its parents
+func lookupLongestOIDPrefix[T any](values map[string]T, oid string) (T, bool) {
+	for prefix := oid; prefix != ""; {
+		if value, ok := values[prefix]; ok {
+			return value, true
+		}
+		lastDot := strings.LastIndex(prefix, ".")
+		if lastDot < 0 {
+			break
+		}
+		prefix = prefix[:lastDot]
+	}
+	var zero T
+	return zero, false
+}
+
 // IsValidOID returns true if a looks like a valid OID.
 // An OID is made of digits and dots, but OIDs do not end with a dot and there are always
 // digits between dots.
@@ -312,7 +362,7 @@ func (f JSONFormatter) parseVariables(trapOID string, variables []gosnmp.SnmpPDU
 		varMetadata, err := f.oidResolver.GetVariableMetadata(trapOID, varOID)
 		if err != nil {
 			log.Debugf("unable to enrich variable: %s", err)
-			tv.Value = formatValue(variable)
+			tv.Value = f.formatVarbind(varOID, formatValue(variable))
 			parsedVariables = append(parsedVariables, tv)
 			continue
 		}
@@ -329,7 +379,7 @@ func (f JSONFormatter) parseVariables(trapOID string, variables []gosnmp.SnmpPDU
 			}
 		} else {
 			// only format the value if it's not an enum type
-			tv.Value = formatValue(variable)
+			tv.Value = f.formatVarbind(varOID, formatValue(variable))
 			enrichedValues[varMetadata.Name] = tv.Value
 		}
 
@@ -339,6 +389,15 @@ func (f JSONFormatter) parseVariables(trapOID string, variables []gosnmp.SnmpPDU
 	return parsedVariables, enrichedValues
 }
 
+// formatVarbind converts the value of variables without enum or bits metadata with the varbind formatter
+func (f JSONFormatter) formatVarbind(oid string, value interface{}) interface{} {
+	if f.varbindFormatter == nil {
+		return value
+	}
+	formattedValue, _ := f.varbindFormatter.FormatVarbind(oid, value)
+	return formattedValue
+}
+
 func formatType(variable gosnmp.SnmpPDU) string {
 	switch variable.Type {
 	case gosnmp.UnknownType:
@@ -18,19 +18,23 @@ import (
 // to the minimum. The forwarder process payloads received by the listener via the trapsIn channel, formats them and finally
 // give them to the epforwarder for sending it to Datadog.
 type TrapForwarder struct {
-	trapsIn   PacketsChannel
-	formatter Formatter
-	sender    sender.Sender
-	stopChan  chan struct{}
+	trapsIn      PacketsChannel
+	formatter    Formatter
+	sender       sender.Sender
+	stopChan     chan struct{}
+	stoppedChan  chan struct{}
+	drainTimeout time.Duration
 }
 
 // NewTrapForwarder creates a simple TrapForwarder instance
 func NewTrapForwarder(formatter Formatter, sender sender.Sender, packets PacketsChannel) (*TrapForwarder, error) {
 	return &TrapForwarder{
-		trapsIn:   packets,
-		formatter: formatter,
-		sender:    sender,
-		stopChan:  make(chan struct{}),
+		trapsIn:      packets,
+		formatter:    formatter,
+		sender:       sender,
+		stopChan:     make(chan struct{}),
+		stoppedChan:  make(chan struct{}),
+		drainTimeout: defaultDrainTimeout * time.Second,
 	}, nil
 }
 
@@ -40,9 +44,11 @@ func (tf *TrapForwarder) Start() {
 	go tf.run()
 }
 
-// Stop the TrapForwarder instance.
+// Stop the TrapForwarder instance. The traps already received are forwarded
+// before returning, for at most the drain timeout of the forwarder.
 func (tf *TrapForwarder) Stop() {
 	tf.stopChan <- struct{}{}
+	<-tf.stoppedChan
 }
 
 func (tf *TrapForwarder) run() {
@@ -50,7 +56,10 @@ func (tf *TrapForwarder) run() {
 	for {
 		select {
 		case <-tf.stopChan:
+			tf.drain()
+			tf.sender.Commit()
 			log.Info("Stopped TrapForwarder")
+			close(tf.stoppedChan)
 			return
 		case packet := <-tf.trapsIn:
 			tf.sendTrap(packet)
@@ -60,6 +69,26 @@ func (tf *TrapForwarder) run() {
 	}
 }
 
+// drain forwards the traps waiting in the trapsIn channel until it is empty
+// or the drain timeout expires
+func (tf *TrapForwarder) drain() {
+	deadline := time.Now().Add(tf.drainTimeout)
+	for {
+		if !time.Now().Before(deadline) {
+			if pending := len(tf.trapsIn); pending > 0 {
+				log.Warnf("Stopping TrapForwarder before forwarding %d traps: drain timeout of %s exceeded", pending, tf.drainTimeout)
+			}
+			return
+		}
+		select {
+		case packet := <-tf.trapsIn:
+			tf.sendTrap(packet)
+		default:
+			return
+		}
+	}
+}
+
 func (tf *TrapForwarder) sendTrap(packet *SnmpPacket) {
 	data, err := tf.formatter.FormatPacket(packet)
 	if err != nil {
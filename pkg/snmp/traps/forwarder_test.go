@@ -110,3 +110,71 @@ func TestForwarderTelemetry(t *testing.T) {
 	forwarder.Stop()
 	sender.AssertMetric(t, "Count", "datadog.snmp_traps.forwarded", 1, "", []string{"snmp_device:1.1.1.1", "device_namespace:totoro", "snmp_version:2"})
 }
+
+func TestForwarderStopDrainsTraps(t *testing.T) {
+	packetsIn := make(PacketsChannel, packetsChanSize)
+	mockSender := mocksender.NewMockSender("snmp-traps-listener")
+	mockSender.SetupAcceptAll()
+
+	forwarder, err := NewTrapForwarder(&DummyFormatter{}, mockSender, packetsIn)
+	require.NoError(t, err)
+
+	// the traps are queued before the forwarder starts consuming them
+	for i := 0; i < 10; i++ {
+		packetsIn <- makeSnmpPacket(NetSNMPExampleHeartbeatNotification)
+	}
+	forwarder.Start()
+	forwarder.Stop()
+
+	mockSender.AssertNumberOfCalls(t, "EventPlatformEvent", 10)
+	require.Empty(t, packetsIn)
+}
+
+// slowFormatter is a DummyFormatter taking delay to format each packet
+type slowFormatter struct {
+	DummyFormatter
+	delay time.Duration
+}
+
+func (f slowFormatter) FormatPacket(packet *SnmpPacket) ([]byte, error) {
+	time.Sleep(f.delay)
+	return f.DummyFormatter.FormatPacket(packet)
+}
+
+func TestForwarderDrainTimeout(t *testing.T) {
+	packetsIn := make(PacketsChannel, packetsChanSize)
+	mockSender := mocksender.NewMockSender("snmp-traps-listener")
+	mockSender.SetupAcceptAll()
+
+	// forwarding a single trap exceeds the drain timeout
+	forwarder, err := NewTrapForwarder(slowFormatter{delay: 20 * time.Millisecond}, mockSender, packetsIn)
+	require.NoError(t, err)
+	forwarder.drainTimeout = 10 * time.Millisecond
+
+	for i := 0; i < 10; i++ {
+		packetsIn <- makeSnmpPacket(NetSNMPExampleHeartbeatNotification)
+	}
+	forwarder.drain()
+
+	mockSender.AssertNumberOfCalls(t, "EventPlatformEvent", 1)
+	require.Len(t, packetsIn, 9)
+}
+
+func TestForwarderDrainTimeoutExpired(t *testing.T) {
+	packetsIn := make(PacketsChannel, packetsChanSize)
+	mockSender := mocksender.NewMockSender("snmp-traps-listener")
+	mockSender.SetupAcceptAll()
+
+	forwarder, err := NewTrapForwarder(&DummyFormatter{}, mockSender, packetsIn)
+	require.NoError(t, err)
+	forwarder.drainTimeout = 0
+
+	for i := 0; i < 10; i++ {
+		packetsIn <- makeSnmpPacket(NetSNMPExampleHeartbeatNotification)
+	}
+	forwarder.drain()
+
+	// no trap is received from the channel once the timeout has expired
+	mockSender.AssertNumberOfCalls(t, "EventPlatformEvent", 0)
+	require.Len(t, packetsIn, 10)
+}
@@ -9,20 +9,32 @@ import (
 	"fmt"
 	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
 	"net"
+	"sync"
 	"time"
 
 	"github.com/gosnmp/gosnmp"
+	"github.com/pion/dtls/v2"
 
 	"github.com/DataDog/datadog-agent/pkg/util/log"
 )
 
-// TrapListener opens an UDP socket and put all received traps in a channel
+// TrapListener opens an UDP socket and put all received traps in a channel.
+// When TLS is enabled, traps are received in DTLS sessions instead.
 type TrapListener struct {
 	config        Config
 	aggregator    sender.Sender
 	packets       PacketsChannel
 	listener      *gosnmp.TrapListener
+	rateLimiter   *sourceRateLimiter
 	errorsChannel chan error
+
+	dtlsConfig   *dtls.Config
+	dtlsListener net.Listener
+	dtlsMu       sync.Mutex
+	dtlsConns    map[net.Conn]struct{}
+	dtlsStopped  bool
+	// paramsMu protects the gosnmp params, which are shared by the DTLS sessions
+	paramsMu sync.Mutex
 }
 
 // NewTrapListener creates a simple TrapListener instance but does not start it
@@ -33,13 +45,25 @@ func NewTrapListener(config Config, aggregator sender.Sender, packets PacketsCha
 	if err != nil {
 		return nil, err
 	}
+	rateLimiter, err := newSourceRateLimiter(config.RateLimitPerSource, config.RateLimitBurst)
+	if err != nil {
+		return nil, err
+	}
 	errorsChan := make(chan error, 1)
 	trapListener := &TrapListener{
 		config:        config,
 		aggregator:    aggregator,
 		packets:       packets,
 		listener:      gosnmpListener,
+		rateLimiter:   rateLimiter,
 		errorsChannel: errorsChan,
+		dtlsConns:     make(map[net.Conn]struct{}),
+	}
+	if config.TLSEnabled() {
+		trapListener.dtlsConfig, err = buildDTLSConfig(config)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	gosnmpListener.OnNewTrap = trapListener.receiveTrap
@@ -49,6 +73,9 @@ func NewTrapListener(config Config, aggregator sender.Sender, packets PacketsCha
 // Start the TrapListener instance. Need to be manually Stopped
 func (t *TrapListener) Start() error {
 	log.Infof("Start listening for traps on %s", t.config.Addr())
+	if t.config.TLSEnabled() {
+		return t.startDTLS()
+	}
 	go t.run()
 	return t.blockUntilReady()
 }
@@ -76,6 +103,10 @@ func (t *TrapListener) blockUntilReady() error {
 
 // Stop the current TrapListener instance
 func (t *TrapListener) Stop() {
+	if t.config.TLSEnabled() {
+		t.stopDTLS()
+		return
+	}
 	t.listener.Close()
 }
 
@@ -91,6 +122,12 @@ func (t *TrapListener) receiveTrap(p *gosnmp.SnmpPacket, u *net.UDPAddr) {
 		t.aggregator.Count("datadog.snmp_traps.invalid_packet", 1, "", append(tags, "reason:unknown_community_string"))
 		return
 	}
+	if !t.rateLimiter.allow(u, p.Community, time.Now()) {
+		log.Debugf("Rate limit exceeded by %s on listener %s, dropping traps", u.String(), t.config.Addr())
+		trapsPacketsRateLimited.Add(1)
+		t.aggregator.Count("datadog.snmp_traps.traps_dropped", 1, "", append(tags, "reason:rate_limit"))
+		return
+	}
 	log.Debugf("Packet received from %s on listener %s", u.String(), t.config.Addr())
 	trapsPackets.Add(1)
 	t.packets <- packet
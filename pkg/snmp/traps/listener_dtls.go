@@ -0,0 +1,149 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package traps
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"os"
+	"time"
+
+	"github.com/pion/dtls/v2"
+
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	dtlsHandshakeTimeout = 5 * time.Second
+	// maxTrapSize is the size of the largest UDP datagram, and so of the largest trap.
+	maxTrapSize = 65535
+)
+
+// buildDTLSConfig loads the certificates of the DTLS listener. Devices must
+// present a certificate signed by the configured CA to send traps.
+func buildDTLSConfig(c Config) (*dtls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(c.TLSCertFile, c.TLSKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the TLS certificate: %w", err)
+	}
+	caCert, err := os.ReadFile(c.TLSCAFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the TLS CA file: %w", err)
+	}
+	clientCAs := x509.NewCertPool()
+	if !clientCAs.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("no valid certificate found in the TLS CA file %s", c.TLSCAFile)
+	}
+
+	return &dtls.Config{
+		Certificates:         []tls.Certificate{cert},
+		ClientAuth:           dtls.RequireAndVerifyClientCert,
+		ClientCAs:            clientCAs,
+		ExtendedMasterSecret: dtls.RequireExtendedMasterSecret,
+		ConnectContextMaker: func() (context.Context, func()) {
+			return context.WithTimeout(context.Background(), dtlsHandshakeTimeout)
+		},
+	}, nil
+}
+
+// startDTLS listens for traps sent in DTLS sessions, instead of plain UDP datagrams.
+func (t *TrapListener) startDTLS() error {
+	addr, err := net.ResolveUDPAddr("udp", t.config.Addr())
+	if err != nil {
+		return fmt.Errorf("error happened when listening for SNMP Traps over DTLS: %s", err)
+	}
+	listener, err := dtls.Listen("udp", addr, t.dtlsConfig)
+	if err != nil {
+		return fmt.Errorf("error happened when listening for SNMP Traps over DTLS: %s", err)
+	}
+	t.dtlsListener = listener
+	go t.acceptDTLS()
+	return nil
+}
+
+func (t *TrapListener) acceptDTLS() {
+	for {
+		conn, err := t.dtlsListener.Accept()
+		if err != nil {
+			t.dtlsMu.Lock()
+			stopped := t.dtlsStopped
+			t.dtlsMu.Unlock()
+			if stopped {
+				return
+			}
+			log.Debugf("DTLS handshake failed on listener %s: %s", t.config.Addr(), err)
+			continue
+		}
+
+		t.dtlsMu.Lock()
+		if t.dtlsStopped {
+			t.dtlsMu.Unlock()
+			conn.Close()
+			return
+		}
+		t.dtlsConns[conn] = struct{}{}
+		t.dtlsMu.Unlock()
+
+		go t.readDTLS(conn)
+	}
+}
+
+// readDTLS reads the traps sent in a DTLS session until it is closed.
+func (t *TrapListener) readDTLS(conn net.Conn) {
+	defer func() {
+		t.dtlsMu.Lock()
+		delete(t.dtlsConns, conn)
+		t.dtlsMu.Unlock()
+		conn.Close()
+	}()
+
+	addr, ok := conn.RemoteAddr().(*net.UDPAddr)
+	if !ok {
+		log.Debugf("Unexpected address %s of DTLS session on listener %s", conn.RemoteAddr(), t.config.Addr())
+		return
+	}
+
+	buf := make([]byte, maxTrapSize)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return
+		}
+
+		t.paramsMu.Lock()
+		packet, err := t.listener.Params.UnmarshalTrap(buf[:n], false)
+		t.paramsMu.Unlock()
+		if err != nil {
+			log.Debugf("Unable to decode the trap received from %s on listener %s: %s", addr, t.config.Addr(), err)
+			continue
+		}
+		if packet != nil {
+			t.receiveTrap(packet, addr)
+		}
+	}
+}
+
+func (t *TrapListener) stopDTLS() {
+	if t.dtlsListener == nil {
+		return
+	}
+
+	t.dtlsMu.Lock()
+	t.dtlsStopped = true
+	conns := make([]net.Conn, 0, len(t.dtlsConns))
+	for conn := range t.dtlsConns {
+		conns = append(conns, conn)
+	}
+	t.dtlsMu.Unlock()
+
+	t.dtlsListener.Close()
+	for _, conn := range conns {
+		conn.Close()
+	}
+}
@@ -0,0 +1,160 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package traps
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/pion/dtls/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testCertificates struct {
+	caFile     string
+	certFile   string
+	keyFile    string
+	caPool     *x509.CertPool
+	clientCert tls.Certificate
+}
+
+// generateTestCertificates generates a self-signed CA, and a server and a
+// client certificates signed by this CA.
+func generateTestCertificates(t *testing.T) testCertificates {
+	dir := t.TempDir()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	require.NoError(t, err)
+	caCert, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	signCertificate := func(serial int64, extKeyUsage x509.ExtKeyUsage) (*ecdsa.PrivateKey, []byte) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(serial),
+			Subject:      pkix.Name{CommonName: "localhost"},
+			DNSNames:     []string{"localhost"},
+			IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			KeyUsage:     x509.KeyUsageDigitalSignature,
+			ExtKeyUsage:  []x509.ExtKeyUsage{extKeyUsage},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, caCert, &key.PublicKey, caKey)
+		require.NoError(t, err)
+		return key, der
+	}
+
+	writePEM := func(name string, blockType string, bytes []byte) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bytes}), 0600))
+		return path
+	}
+
+	serverKey, serverDER := signCertificate(2, x509.ExtKeyUsageServerAuth)
+	serverKeyDER, err := x509.MarshalECPrivateKey(serverKey)
+	require.NoError(t, err)
+
+	clientKey, clientDER := signCertificate(3, x509.ExtKeyUsageClientAuth)
+
+	caPool := x509.NewCertPool()
+	caPool.AddCert(caCert)
+
+	return testCertificates{
+		caFile:     writePEM("ca.pem", "CERTIFICATE", caDER),
+		certFile:   writePEM("server.pem", "CERTIFICATE", serverDER),
+		keyFile:    writePEM("server.key", "EC PRIVATE KEY", serverKeyDER),
+		caPool:     caPool,
+		clientCert: tls.Certificate{Certificate: [][]byte{clientDER}, PrivateKey: clientKey},
+	}
+}
+
+func sendTestV2TrapOverDTLS(t *testing.T, trapConfig Config, community string, clientCert tls.Certificate, rootCAs *x509.CertPool) error {
+	addr, err := net.ResolveUDPAddr("udp", trapConfig.Addr())
+	require.NoError(t, err)
+	conn, err := dtls.Dial("udp", addr, &dtls.Config{
+		Certificates:         []tls.Certificate{clientCert},
+		RootCAs:              rootCAs,
+		ServerName:           "localhost",
+		ExtendedMasterSecret: dtls.RequireExtendedMasterSecret,
+	})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	params, err := trapConfig.BuildSNMPParams()
+	require.NoError(t, err)
+	params.Community = community
+	params.Timeout = 1 * time.Second // Must be non-zero when sending traps.
+	params.Retries = 1               // Must be non-zero when sending traps.
+
+	// Connect initializes the params used to send traps, the plain UDP
+	// connection it opens is then replaced with the DTLS one.
+	err = params.Connect()
+	require.NoError(t, err)
+	params.Conn.Close()
+	params.Conn = conn
+
+	_, err = params.SendTrap(NetSNMPExampleHeartbeatNotification)
+	return err
+}
+
+func TestListenDTLS(t *testing.T) {
+	certs := generateTestCertificates(t)
+	config := Config{Port: serverPort, BindHost: "127.0.0.1", CommunityStrings: []string{"public"}, TLSCertFile: certs.certFile, TLSKeyFile: certs.keyFile, TLSCAFile: certs.caFile}
+	_, trapListener := listenerTestSetup(t, config)
+	defer trapListener.Stop()
+
+	require.NoError(t, sendTestV2TrapOverDTLS(t, config, "public", certs.clientCert, certs.caPool))
+	packet, err := receivePacket(t, trapListener, defaultTimeout)
+	require.NoError(t, err)
+	assertIsValidV2Packet(t, packet, config)
+	assertVariables(t, packet)
+}
+
+func TestListenDTLSUnknownClientCertificate(t *testing.T) {
+	certs := generateTestCertificates(t)
+	otherCerts := generateTestCertificates(t)
+	config := Config{Port: serverPort, BindHost: "127.0.0.1", CommunityStrings: []string{"public"}, TLSCertFile: certs.certFile, TLSKeyFile: certs.keyFile, TLSCAFile: certs.caFile}
+	_, trapListener := listenerTestSetup(t, config)
+	defer trapListener.Stop()
+
+	assert.Error(t, sendTestV2TrapOverDTLS(t, config, "public", otherCerts.clientCert, certs.caPool))
+	assertNoPacketReceived(t, trapListener)
+}
+
+func TestListenDTLSInvalidCertificateFiles(t *testing.T) {
+	certs := generateTestCertificates(t)
+	config := Config{Port: serverPort, CommunityStrings: []string{"public"}, TLSCertFile: certs.certFile, TLSKeyFile: certs.keyFile, TLSCAFile: certs.keyFile}
+
+	_, err := NewTrapListener(config, nil, make(PacketsChannel))
+	assert.ErrorContains(t, err, "no valid certificate found in the TLS CA file")
+}
@@ -133,6 +133,28 @@ func TestListenerTrapsReceivedTelemetry(t *testing.T) {
 	mockSender.AssertMetric(t, "Count", "datadog.snmp_traps.received", 1, "", []string{"snmp_device:127.0.0.1", "device_namespace:totoro", "snmp_version:1"})
 }
 
+func TestListenerRateLimit(t *testing.T) {
+	config := Config{Port: serverPort, CommunityStrings: []string{"public"}, Namespace: "totoro", RateLimitPerSource: 0.001, RateLimitBurst: 2}
+	mockSender, trapListener := listenerTestSetup(t, config)
+	defer trapListener.Stop()
+	initialTrapsPacketsRateLimited := trapsPacketsRateLimited.Value()
+
+	for i := 0; i < 5; i++ {
+		sendTestV2Trap(t, config, "public")
+	}
+	for i := 0; i < 2; i++ {
+		_, err := receivePacket(t, trapListener, defaultTimeout)
+		require.NoError(t, err)
+	}
+	require.Eventually(t, func() bool {
+		return trapsPacketsRateLimited.Value()-initialTrapsPacketsRateLimited == 3
+	}, defaultTimeout, 10*time.Millisecond)
+	assertNoPacketReceived(t, trapListener)
+
+	mockSender.AssertNumberOfCalls(t, "Count", 8)
+	mockSender.AssertMetric(t, "Count", "datadog.snmp_traps.traps_dropped", 1, "", []string{"snmp_device:127.0.0.1", "device_namespace:totoro", "snmp_version:2", "reason:rate_limit"})
+}
+
 func receivePacket(t *testing.T, listener *TrapListener, timeoutDuration time.Duration) (*SnmpPacket, error) {
 	timeout := time.After(timeoutDuration)
 	ticker := time.NewTicker(20 * time.Millisecond)
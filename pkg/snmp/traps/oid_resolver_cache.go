@@ -0,0 +1,88 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package traps
+
+import (
+	lru "github.com/hashicorp/golang-lru/v2"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/sender"
+)
+
+const (
+	telemetryOIDCacheHits   = "datadog.snmp_traps.oid_cache_hits"
+	telemetryOIDCacheMisses = "datadog.snmp_traps.oid_cache_misses"
+)
+
+type trapLookup struct {
+	metadata TrapMetadata
+	err      error
+}
+
+type variableKey struct {
+	trapOID string
+	varOID  string
+}
+
+type variableLookup struct {
+	metadata VariableMetadata
+	err      error
+}
+
+// CachingOIDResolver is an OIDResolver that keeps the most recent lookups of
+// another OIDResolver in LRU caches, so that traps sent repeatedly by the same
+// devices are not resolved again. Failed lookups are cached as well.
+type CachingOIDResolver struct {
+	resolver   OIDResolver
+	aggregator sender.Sender
+	traps      *lru.Cache[string, trapLookup]
+	variables  *lru.Cache[variableKey, variableLookup]
+}
+
+// NewCachingOIDResolver creates a new CachingOIDResolver keeping at most size
+// trap lookups and size variable lookups.
+func NewCachingOIDResolver(resolver OIDResolver, size int, aggregator sender.Sender) (*CachingOIDResolver, error) {
+	traps, err := lru.New[string, trapLookup](size)
+	if err != nil {
+		return nil, err
+	}
+	variables, err := lru.New[variableKey, variableLookup](size)
+	if err != nil {
+		return nil, err
+	}
+	return &CachingOIDResolver{
+		resolver:   resolver,
+		aggregator: aggregator,
+		traps:      traps,
+		variables:  variables,
+	}, nil
+}
+
+// GetTrapMetadata returns TrapMetadata for a given trapOID
+func (or *CachingOIDResolver) GetTrapMetadata(trapOID string) (TrapMetadata, error) {
+	if lookup, ok := or.traps.Get(trapOID); ok {
+		or.aggregator.Count(telemetryOIDCacheHits, 1, "", []string{"lookup:trap"})
+		return lookup.metadata, lookup.err
+	}
+	or.aggregator.Count(telemetryOIDCacheMisses, 1, "", []string{"lookup:trap"})
+
+	metadata, err := or.resolver.GetTrapMetadata(trapOID)
+	or.traps.Add(trapOID, trapLookup{metadata, err})
+	return metadata, err
+}
+
+// GetVariableMetadata returns VariableMetadata for a given variableOID and trapOID.
+func (or *CachingOIDResolver) GetVariableMetadata(trapOID string, varOID string) (VariableMetadata, error) {
+	key := variableKey{trapOID, varOID}
+	if lookup, ok := or.variables.Get(key); ok {
+		or.aggregator.Count(telemetryOIDCacheHits, 1, "", []string{"lookup:variable"})
+		return lookup.metadata, lookup.err
+	}
+	or.aggregator.Count(telemetryOIDCacheMisses, 1, "", []string{"lookup:variable"})
+
+	metadata, err := or.resolver.GetVariableMetadata(trapOID, varOID)
+	or.variables.Add(key, variableLookup{metadata, err})
+	return metadata, err
+}
@@ -0,0 +1,82 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package traps
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+)
+
+// countingOIDResolver is an OIDResolver counting its lookups
+type countingOIDResolver struct {
+	trapLookups     int
+	variableLookups int
+}
+
+func (or *countingOIDResolver) GetTrapMetadata(trapOID string) (TrapMetadata, error) {
+	or.trapLookups++
+	if trapOID == "1.3.6.1.4.1.8072.2.3.0.1" {
+		return TrapMetadata{Name: "netSnmpExampleHeartbeatNotification", MIBName: "NET-SNMP-EXAMPLES-MIB"}, nil
+	}
+	return TrapMetadata{}, fmt.Errorf("trap OID %s is not defined", trapOID)
+}
+
+func (or *countingOIDResolver) GetVariableMetadata(trapOID string, varOID string) (VariableMetadata, error) {
+	or.variableLookups++
+	return VariableMetadata{Name: "netSnmpExampleHeartbeatRate"}, nil
+}
+
+func TestCachingOIDResolver(t *testing.T) {
+	mockSender := mocksender.NewMockSender("snmp-traps-telemetry")
+	mockSender.SetupAcceptAll()
+
+	resolver := &countingOIDResolver{}
+	cachingResolver, err := NewCachingOIDResolver(resolver, 10, mockSender)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		trapMetadata, err := cachingResolver.GetTrapMetadata("1.3.6.1.4.1.8072.2.3.0.1")
+		assert.NoError(t, err)
+		assert.Equal(t, "netSnmpExampleHeartbeatNotification", trapMetadata.Name)
+
+		_, err = cachingResolver.GetTrapMetadata("1.3.6.1.4.1.8072.2.3.0.2")
+		assert.EqualError(t, err, "trap OID 1.3.6.1.4.1.8072.2.3.0.2 is not defined")
+
+		varMetadata, err := cachingResolver.GetVariableMetadata("1.3.6.1.4.1.8072.2.3.0.1", "1.3.6.1.4.1.8072.2.3.2.1")
+		assert.NoError(t, err)
+		assert.Equal(t, "netSnmpExampleHeartbeatRate", varMetadata.Name)
+	}
+
+	assert.Equal(t, 2, resolver.trapLookups)
+	assert.Equal(t, 1, resolver.variableLookups)
+
+	mockSender.AssertNumberOfCalls(t, "Count", 6)
+	mockSender.AssertCalled(t, "Count", telemetryOIDCacheMisses, float64(1), "", []string{"lookup:trap"})
+	mockSender.AssertCalled(t, "Count", telemetryOIDCacheHits, float64(1), "", []string{"lookup:trap"})
+	mockSender.AssertCalled(t, "Count", telemetryOIDCacheMisses, float64(1), "", []string{"lookup:variable"})
+	mockSender.AssertCalled(t, "Count", telemetryOIDCacheHits, float64(1), "", []string{"lookup:variable"})
+}
+
+func TestCachingOIDResolverEviction(t *testing.T) {
+	mockSender := mocksender.NewMockSender("snmp-traps-telemetry")
+	mockSender.On("Count", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return()
+
+	resolver := &countingOIDResolver{}
+	cachingResolver, err := NewCachingOIDResolver(resolver, 1, mockSender)
+	require.NoError(t, err)
+
+	for _, trapOID := range []string{"1.3.6.1.1", "1.3.6.1.2", "1.3.6.1.1"} {
+		_, _ = cachingResolver.GetTrapMetadata(trapOID)
+	}
+
+	assert.Equal(t, 3, resolver.trapLookups)
+}
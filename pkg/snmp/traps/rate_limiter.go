@@ -0,0 +1,79 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package traps
+
+import (
+	"math"
+	"net"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"golang.org/x/time/rate"
+)
+
+// rateLimiterSourcesSize is the maximum number of sources tracked by the
+// rate limiter, so that devices sending traps from many different addresses
+// cannot make it grow indefinitely.
+const rateLimiterSourcesSize = 10000
+
+type trapSource struct {
+	ip        string
+	community string
+}
+
+// sourceRateLimiter limits the rate of traps accepted from each source, a
+// source being a device IP and community string pair, with a token bucket.
+type sourceRateLimiter struct {
+	limit rate.Limit
+	burst int
+
+	mu       sync.Mutex
+	limiters *lru.Cache[trapSource, *rate.Limiter]
+}
+
+// newSourceRateLimiter creates a sourceRateLimiter accepting limit traps per
+// second from each source, with bursts of at most burst traps. It returns nil
+// when rate limiting is disabled, ie when limit is not positive. When burst is
+// not positive, it defaults to the number of traps accepted in a second.
+func newSourceRateLimiter(limit float64, burst int) (*sourceRateLimiter, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+	if burst <= 0 {
+		burst = int(math.Ceil(limit))
+	}
+
+	limiters, err := lru.New[trapSource, *rate.Limiter](rateLimiterSourcesSize)
+	if err != nil {
+		return nil, err
+	}
+	return &sourceRateLimiter{
+		limit:    rate.Limit(limit),
+		burst:    burst,
+		limiters: limiters,
+	}, nil
+}
+
+// allow reports whether a trap received at the given time from the given
+// source can be accepted. A nil sourceRateLimiter accepts all traps.
+func (l *sourceRateLimiter) allow(addr *net.UDPAddr, community string, now time.Time) bool {
+	if l == nil {
+		return true
+	}
+
+	source := trapSource{ip: addr.IP.String(), community: community}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	limiter, ok := l.limiters.Get(source)
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters.Add(source, limiter)
+	}
+	return limiter.AllowN(now, 1)
+}
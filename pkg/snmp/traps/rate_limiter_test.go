@@ -0,0 +1,55 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package traps
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceRateLimiterDisabled(t *testing.T) {
+	limiter, err := newSourceRateLimiter(0, 10)
+	require.NoError(t, err)
+	assert.Nil(t, limiter)
+
+	now := time.Now()
+	addr := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)}
+	for i := 0; i < 100; i++ {
+		assert.True(t, limiter.allow(addr, "public", now))
+	}
+}
+
+func TestSourceRateLimiter(t *testing.T) {
+	limiter, err := newSourceRateLimiter(1, 2)
+	require.NoError(t, err)
+
+	now := time.Now()
+	device := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)}
+	otherDevice := &net.UDPAddr{IP: net.IPv4(127, 0, 0, 2)}
+
+	// the burst is accepted, then traps are dropped
+	assert.True(t, limiter.allow(device, "public", now))
+	assert.True(t, limiter.allow(device, "public", now))
+	assert.False(t, limiter.allow(device, "public", now))
+
+	// other sources have their own bucket
+	assert.True(t, limiter.allow(device, "private", now))
+	assert.True(t, limiter.allow(otherDevice, "public", now))
+
+	// tokens are refilled over time
+	assert.True(t, limiter.allow(device, "public", now.Add(time.Second)))
+	assert.False(t, limiter.allow(device, "public", now.Add(time.Second)))
+}
+
+func TestSourceRateLimiterDefaultBurst(t *testing.T) {
+	limiter, err := newSourceRateLimiter(2.5, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 3, limiter.burst)
+}
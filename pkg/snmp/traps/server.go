@@ -37,7 +37,11 @@ func StartServer(agentHostname string, demux aggregator.Demultiplexer) error {
 	if err != nil {
 		return err
 	}
-	oidResolver, err := NewMultiFilesOIDResolver()
+	multiFilesOIDResolver, err := NewMultiFilesOIDResolver()
+	if err != nil {
+		return err
+	}
+	oidResolver, err := NewCachingOIDResolver(multiFilesOIDResolver, config.OIDCacheSize, sender)
 	if err != nil {
 		return err
 	}
@@ -45,6 +49,12 @@ func StartServer(agentHostname string, demux aggregator.Demultiplexer) error {
 	if err != nil {
 		return err
 	}
+	varbindFormatters := NewVarbindFormatterRegistry()
+	varbindFormatters.Register(enterpriseOIDPrefix, loadConfdProfilesVarbindFormatter())
+	formatter.varbindFormatter = varbindFormatters
+	if config.EnrichWithSysname {
+		formatter.sysNameResolver = NewCachingSysNameResolver(time.Duration(config.SysnameCacheTTL) * time.Second)
+	}
 	server, err := NewTrapServer(*config, formatter, sender)
 	serverInstance = server
 	startError = err
@@ -74,7 +84,7 @@ func NewTrapServer(config Config, formatter Formatter, aggregator sender.Sender)
 		return nil, err
 	}
 
-	trapForwarder, err := startSNMPTrapForwarder(formatter, aggregator, packets)
+	trapForwarder, err := startSNMPTrapForwarder(config, formatter, aggregator, packets)
 	if err != nil {
 		return nil, fmt.Errorf("unable to start trapForwarder: %w. Will not listen for SNMP traps", err)
 	}
@@ -87,11 +97,12 @@ func NewTrapServer(config Config, formatter Formatter, aggregator sender.Sender)
 	return server, nil
 }
 
-func startSNMPTrapForwarder(formatter Formatter, aggregator sender.Sender, packets PacketsChannel) (*TrapForwarder, error) {
+func startSNMPTrapForwarder(c Config, formatter Formatter, aggregator sender.Sender, packets PacketsChannel) (*TrapForwarder, error) {
 	trapForwarder, err := NewTrapForwarder(formatter, aggregator, packets)
 	if err != nil {
 		return nil, err
 	}
+	trapForwarder.drainTimeout = time.Duration(c.DrainTimeout) * time.Second
 	trapForwarder.Start()
 	return trapForwarder, nil
 }
@@ -107,7 +118,8 @@ func startSNMPTrapListener(c Config, aggregator sender.Sender, packets PacketsCh
 	return trapListener, nil
 }
 
-// Stop stops the TrapServer.
+// Stop stops the TrapServer. New traps are not accepted anymore, and the ones
+// already received are forwarded for at most the drain timeout.
 func (s *TrapServer) Stop() {
 	stopped := make(chan interface{})
 
@@ -118,9 +130,11 @@ func (s *TrapServer) Stop() {
 		close(stopped)
 	}()
 
+	// the forwarder may take up to the drain timeout to forward the pending traps
+	timeout := s.config.StopTimeout + s.config.DrainTimeout
 	select {
 	case <-stopped:
-	case <-time.After(time.Duration(s.config.StopTimeout) * time.Second):
-		log.Errorf("Stopping server. Timeout after %d seconds", s.config.StopTimeout)
+	case <-time.After(time.Duration(timeout) * time.Second):
+		log.Errorf("Stopping server. Timeout after %d seconds", timeout)
 	}
 }
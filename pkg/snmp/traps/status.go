@@ -16,11 +16,14 @@ var (
 	trapsExpvars           = expvar.NewMap("snmp_traps")
 	trapsPackets           = expvar.Int{}
 	trapsPacketsAuthErrors = expvar.Int{}
+	// trapsPacketsRateLimited counts the traps dropped because their source exceeded the rate limit
+	trapsPacketsRateLimited = expvar.Int{}
 )
 
 func init() {
 	trapsExpvars.Set("Packets", &trapsPackets)
 	trapsExpvars.Set("PacketsAuthErrors", &trapsPacketsAuthErrors)
+	trapsExpvars.Set("PacketsRateLimited", &trapsPacketsRateLimited)
 }
 
 func getDroppedPackets() int64 {
@@ -0,0 +1,165 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package traps
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const (
+	sysNameInstanceOID = "1.3.6.1.2.1.1.5.0"
+	sysNameSNMPPort    = 161
+	sysNameGetTimeout  = 1 * time.Second
+	// maximum number of devices queried at the same time
+	sysNameMaxLookups = 8
+)
+
+// SysNameResolver returns the sysName of the device that sent a trap
+type SysNameResolver interface {
+	GetSysName(packet *SnmpPacket) (string, bool)
+}
+
+type sysNameCacheEntry struct {
+	name    string
+	found   bool
+	expires time.Time
+}
+
+// CachingSysNameResolver is a SysNameResolver querying sysName.0 on the device
+// that sent the trap, with the trap community string. Results, including failed
+// lookups, are cached per device IP for a fixed TTL.
+// Lookups run in the background so they never delay traps: traps received before
+// the sysName of their device is cached are forwarded without it. At most one
+// lookup runs per device, and at most sysNameMaxLookups run at the same time.
+// SNMPv3 traps are not resolved since their credentials cannot be reused for a GET.
+type CachingSysNameResolver struct {
+	ttl       time.Duration
+	mu        sync.Mutex
+	cache     map[string]sysNameCacheEntry
+	inFlight  map[string]struct{}
+	nextPurge time.Time
+	// limits the number of concurrent lookups
+	lookupSlots chan struct{}
+	lookups     sync.WaitGroup
+	// overridable for tests
+	getSysName func(ip string, version gosnmp.SnmpVersion, community string) (string, error)
+	now        func() time.Time
+}
+
+// NewCachingSysNameResolver creates a CachingSysNameResolver caching lookups for ttl
+func NewCachingSysNameResolver(ttl time.Duration) *CachingSysNameResolver {
+	return &CachingSysNameResolver{
+		ttl:         ttl,
+		cache:       make(map[string]sysNameCacheEntry),
+		inFlight:    make(map[string]struct{}),
+		lookupSlots: make(chan struct{}, sysNameMaxLookups),
+		getSysName:  getSysNameFromDevice,
+		now:         time.Now,
+	}
+}
+
+// GetSysName implements SysNameResolver#GetSysName
+func (r *CachingSysNameResolver) GetSysName(packet *SnmpPacket) (string, bool) {
+	if packet.Content.Version == gosnmp.Version3 || packet.Addr == nil {
+		return "", false
+	}
+	ip := packet.Addr.IP.String()
+	now := r.now()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.cache[ip]; ok && now.Before(entry.expires) {
+		return entry.name, entry.found
+	}
+
+	r.purgeExpired(now)
+
+	if _, ok := r.inFlight[ip]; ok {
+		return "", false
+	}
+	select {
+	case r.lookupSlots <- struct{}{}:
+	default:
+		// every slot is busy, the next trap from this device will try again
+		return "", false
+	}
+	r.inFlight[ip] = struct{}{}
+	r.lookups.Add(1)
+	go r.lookup(ip, packet.Content.Version, packet.Content.Community)
+
+	return "", false
+}
+
+// lookup queries the sysName of a device and caches the result
+func (r *CachingSysNameResolver) lookup(ip string, version gosnmp.SnmpVersion, community string) {
+	defer r.lookups.Done()
+
+	name, err := r.getSysName(ip, version, community)
+	if err != nil {
+		log.Debugf("Unable to get sysName of device %s: %s", ip, err)
+	}
+	<-r.lookupSlots
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.inFlight, ip)
+	r.cache[ip] = sysNameCacheEntry{
+		name:    name,
+		found:   err == nil && name != "",
+		expires: r.now().Add(r.ttl),
+	}
+}
+
+// purgeExpired drops expired entries so devices that stopped sending traps do not
+// accumulate. Entries live for a fixed TTL, so the cache is scanned at most once
+// per TTL. Must be called with r.mu held.
+func (r *CachingSysNameResolver) purgeExpired(now time.Time) {
+	if now.Before(r.nextPurge) {
+		return
+	}
+	for cachedIP, cachedEntry := range r.cache {
+		if !now.Before(cachedEntry.expires) {
+			delete(r.cache, cachedIP)
+		}
+	}
+	r.nextPurge = now.Add(r.ttl)
+}
+
+func getSysNameFromDevice(ip string, version gosnmp.SnmpVersion, community string) (string, error) {
+	params := &gosnmp.GoSNMP{
+		Target:    ip,
+		Port:      sysNameSNMPPort,
+		Transport: "udp",
+		Community: community,
+		Version:   version,
+		Timeout:   sysNameGetTimeout,
+		Retries:   0,
+	}
+	if err := params.Connect(); err != nil {
+		return "", err
+	}
+	defer params.Conn.Close()
+
+	result, err := params.Get([]string{sysNameInstanceOID})
+	if err != nil {
+		return "", err
+	}
+	if len(result.Variables) != 1 {
+		return "", fmt.Errorf("expected 1 variable, got %d", len(result.Variables))
+	}
+	name, ok := result.Variables[0].Value.([]byte)
+	if !ok {
+		return "", fmt.Errorf("unexpected sysName type %s", result.Variables[0].Type)
+	}
+	return string(name), nil
+}
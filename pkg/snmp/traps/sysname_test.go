@@ -0,0 +1,230 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package traps
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gosnmp/gosnmp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/exp/maps"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+)
+
+type fakeSysNameGetter struct {
+	mu    sync.Mutex
+	names map[string]string
+	calls int
+	// when set, lookups block until it is closed
+	release chan struct{}
+}
+
+func (g *fakeSysNameGetter) get(ip string, version gosnmp.SnmpVersion, community string) (string, error) {
+	if g.release != nil {
+		<-g.release
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.calls++
+	if community != "public" {
+		return "", errors.New("request timeout")
+	}
+	name, ok := g.names[ip]
+	if !ok {
+		return "", errors.New("request timeout")
+	}
+	return name, nil
+}
+
+func (g *fakeSysNameGetter) callCount() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.calls
+}
+
+func newTestSysNameResolver(getter *fakeSysNameGetter, now *time.Time) *CachingSysNameResolver {
+	resolver := NewCachingSysNameResolver(time.Minute)
+	resolver.getSysName = getter.get
+	resolver.now = func() time.Time { return *now }
+	return resolver
+}
+
+func createTestPacketFrom(ip string) *SnmpPacket {
+	packet := createTestPacket(NetSNMPExampleHeartbeatNotification)
+	packet.Addr = &net.UDPAddr{IP: net.ParseIP(ip), Port: 13156}
+	return packet
+}
+
+func TestSysNameResolverCachesLookups(t *testing.T) {
+	getter := &fakeSysNameGetter{names: map[string]string{"127.0.0.1": "core-switch-1"}}
+	now := time.Now()
+	resolver := newTestSysNameResolver(getter, &now)
+	packet := createTestPacket(NetSNMPExampleHeartbeatNotification)
+
+	// the lookup runs in the background
+	_, ok := resolver.GetSysName(packet)
+	assert.False(t, ok)
+	resolver.lookups.Wait()
+
+	name, ok := resolver.GetSysName(packet)
+	assert.True(t, ok)
+	assert.Equal(t, "core-switch-1", name)
+	assert.Equal(t, 1, getter.callCount())
+
+	now = now.Add(59 * time.Second)
+	name, ok = resolver.GetSysName(packet)
+	assert.True(t, ok)
+	assert.Equal(t, "core-switch-1", name)
+	assert.Equal(t, 1, getter.callCount())
+
+	// the cached entry expired
+	getter.names["127.0.0.1"] = "core-switch-2"
+	now = now.Add(time.Second)
+	_, ok = resolver.GetSysName(packet)
+	assert.False(t, ok)
+	resolver.lookups.Wait()
+	name, ok = resolver.GetSysName(packet)
+	assert.True(t, ok)
+	assert.Equal(t, "core-switch-2", name)
+	assert.Equal(t, 2, getter.callCount())
+}
+
+func TestSysNameResolverCachesFailedLookups(t *testing.T) {
+	getter := &fakeSysNameGetter{names: map[string]string{}}
+	now := time.Now()
+	resolver := newTestSysNameResolver(getter, &now)
+	packet := createTestPacket(NetSNMPExampleHeartbeatNotification)
+
+	_, ok := resolver.GetSysName(packet)
+	assert.False(t, ok)
+	resolver.lookups.Wait()
+	_, ok = resolver.GetSysName(packet)
+	assert.False(t, ok)
+	resolver.lookups.Wait()
+	assert.Equal(t, 1, getter.callCount())
+}
+
+func TestSysNameResolverDeduplicatesLookups(t *testing.T) {
+	getter := &fakeSysNameGetter{
+		names:   map[string]string{"127.0.0.1": "core-switch-1"},
+		release: make(chan struct{}),
+	}
+	now := time.Now()
+	resolver := newTestSysNameResolver(getter, &now)
+	packet := createTestPacket(NetSNMPExampleHeartbeatNotification)
+
+	// traps received while the lookup is in flight don't start another one
+	for i := 0; i < 10; i++ {
+		_, ok := resolver.GetSysName(packet)
+		assert.False(t, ok)
+	}
+	close(getter.release)
+	resolver.lookups.Wait()
+
+	name, ok := resolver.GetSysName(packet)
+	assert.True(t, ok)
+	assert.Equal(t, "core-switch-1", name)
+	assert.Equal(t, 1, getter.callCount())
+}
+
+func TestSysNameResolverBoundsConcurrentLookups(t *testing.T) {
+	getter := &fakeSysNameGetter{
+		names:   map[string]string{},
+		release: make(chan struct{}),
+	}
+	now := time.Now()
+	resolver := newTestSysNameResolver(getter, &now)
+
+	for i := 0; i < sysNameMaxLookups+5; i++ {
+		resolver.GetSysName(createTestPacketFrom(fmt.Sprintf("10.0.0.%d", i)))
+	}
+	resolver.mu.Lock()
+	assert.Len(t, resolver.inFlight, sysNameMaxLookups)
+	resolver.mu.Unlock()
+
+	close(getter.release)
+	resolver.lookups.Wait()
+	assert.Equal(t, sysNameMaxLookups, getter.callCount())
+
+	// the devices that were skipped are looked up on their next trap
+	resolver.GetSysName(createTestPacketFrom(fmt.Sprintf("10.0.0.%d", sysNameMaxLookups)))
+	resolver.lookups.Wait()
+	assert.Equal(t, sysNameMaxLookups+1, getter.callCount())
+}
+
+func TestSysNameResolverPurgesExpiredEntries(t *testing.T) {
+	getter := &fakeSysNameGetter{names: map[string]string{}}
+	start := time.Now()
+	now := start
+	resolver := newTestSysNameResolver(getter, &now)
+	lookupAt := func(elapsed time.Duration, ip string) {
+		now = start.Add(elapsed)
+		resolver.GetSysName(createTestPacketFrom(ip))
+		resolver.lookups.Wait()
+	}
+
+	lookupAt(0, "10.0.0.1")
+	lookupAt(30*time.Second, "10.0.0.2")
+	// the first miss after a TTL purges the cache
+	lookupAt(61*time.Second, "10.0.0.3")
+	assert.ElementsMatch(t, []string{"10.0.0.2", "10.0.0.3"}, maps.Keys(resolver.cache))
+
+	// 10.0.0.2 expired, but the cache was purged less than a TTL ago
+	lookupAt(100*time.Second, "10.0.0.4")
+	assert.ElementsMatch(t, []string{"10.0.0.2", "10.0.0.3", "10.0.0.4"}, maps.Keys(resolver.cache))
+
+	lookupAt(125*time.Second, "10.0.0.5")
+	assert.ElementsMatch(t, []string{"10.0.0.4", "10.0.0.5"}, maps.Keys(resolver.cache))
+}
+
+func TestSysNameResolverIgnoresV3Traps(t *testing.T) {
+	getter := &fakeSysNameGetter{names: map[string]string{"127.0.0.1": "core-switch-1"}}
+	now := time.Now()
+	resolver := newTestSysNameResolver(getter, &now)
+	packet := &SnmpPacket{
+		Content: &gosnmp.SnmpPacket{Version: gosnmp.Version3},
+		Addr:    &net.UDPAddr{IP: net.ParseIP("127.0.0.1"), Port: 13156},
+	}
+
+	_, ok := resolver.GetSysName(packet)
+	assert.False(t, ok)
+	resolver.lookups.Wait()
+	assert.Equal(t, 0, getter.callCount())
+}
+
+func TestFormatPacketWithSysName(t *testing.T) {
+	mockSender := mocksender.NewMockSender("snmp-traps-telemetry")
+	mockSender.SetupAcceptAll()
+
+	getter := &fakeSysNameGetter{names: map[string]string{"127.0.0.1": "core-switch-1"}}
+	now := time.Now()
+	formatter, err := NewJSONFormatter(NoOpOIDResolver{}, mockSender)
+	require.NoError(t, err)
+	resolver := newTestSysNameResolver(getter, &now)
+	formatter.sysNameResolver = resolver
+
+	formatTags := func() interface{} {
+		formattedPacket, err := formatter.FormatPacket(createTestPacket(NetSNMPExampleHeartbeatNotification))
+		require.NoError(t, err)
+		data := make(map[string]interface{})
+		err = json.Unmarshal(formattedPacket, &data)
+		require.NoError(t, err)
+		return data["trap"].(map[string]interface{})["ddtags"]
+	}
+
+	// the first trap is forwarded without waiting for the lookup
+	assert.Equal(t, "snmp_version:2,device_namespace:totoro,snmp_device:127.0.0.1", formatTags())
+	resolver.lookups.Wait()
+	assert.Equal(t, "snmp_version:2,device_namespace:totoro,snmp_device:127.0.0.1,snmp_device_name:core-switch-1", formatTags())
+}
@@ -0,0 +1,151 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package traps
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/DataDog/datadog-agent/pkg/config"
+	"github.com/DataDog/datadog-agent/pkg/networkdevice/profile/profiledefinition"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// enterpriseOIDPrefix is the root of the vendor-specific OIDs
+const enterpriseOIDPrefix = "1.3.6.1.4.1"
+
+// VarbindFormatter converts the value of a trap variable, eg. an opaque integer to a human-readable string.
+// It returns false when it does not know how to convert the value.
+type VarbindFormatter interface {
+	FormatVarbind(oid string, value interface{}) (interface{}, bool)
+}
+
+// NoopVarbindFormatter is a VarbindFormatter that never converts values
+type NoopVarbindFormatter struct{}
+
+// FormatVarbind implements VarbindFormatter#FormatVarbind
+func (NoopVarbindFormatter) FormatVarbind(_ string, value interface{}) (interface{}, bool) {
+	return value, false
+}
+
+// VarbindFormatterRegistry is a VarbindFormatter delegating to the formatter registered
+// for the longest prefix of the variable OID
+type VarbindFormatterRegistry struct {
+	formatters map[string]VarbindFormatter
+}
+
+// NewVarbindFormatterRegistry creates an empty VarbindFormatterRegistry
+func NewVarbindFormatterRegistry() *VarbindFormatterRegistry {
+	return &VarbindFormatterRegistry{formatters: make(map[string]VarbindFormatter)}
+}
+
+// Register sets the formatter of the variables under oidPrefix
+func (r *VarbindFormatterRegistry) Register(oidPrefix string, formatter VarbindFormatter) {
+	r.formatters[NormalizeOID(oidPrefix)] = formatter
+}
+
+// FormatVarbind implements VarbindFormatter#FormatVarbind
+func (r *VarbindFormatterRegistry) FormatVarbind(oid string, value interface{}) (interface{}, bool) {
+	formatter, ok := lookupLongestOIDPrefix(r.formatters, NormalizeOID(oid))
+	if !ok {
+		return value, false
+	}
+	return formatter.FormatVarbind(oid, value)
+}
+
+// MappingVarbindFormatter is a VarbindFormatter converting values with the `mapping`
+// tables of the metric tags of SNMP profile definitions
+type MappingVarbindFormatter struct {
+	mappings map[string]map[string]string
+}
+
+// NewMappingVarbindFormatter creates a MappingVarbindFormatter from profile definitions
+func NewMappingVarbindFormatter(definitions []*profiledefinition.ProfileDefinition) *MappingVarbindFormatter {
+	f := &MappingVarbindFormatter{mappings: make(map[string]map[string]string)}
+	for _, definition := range definitions {
+		f.addMetricTags(definition.MetricTags)
+		for _, metric := range definition.Metrics {
+			f.addMetricTags(metric.MetricTags)
+		}
+	}
+	return f
+}
+
+// LoadMappingVarbindFormatter creates a MappingVarbindFormatter from the profile definitions
+// found in the given directories. Missing directories and invalid files are skipped.
+func LoadMappingVarbindFormatter(profileDirs ...string) *MappingVarbindFormatter {
+	var definitions []*profiledefinition.ProfileDefinition
+	for _, dir := range profileDirs {
+		files, err := filepath.Glob(filepath.Join(dir, "*.yaml"))
+		if err != nil {
+			log.Debugf("unable to list profiles in %s: %s", dir, err)
+			continue
+		}
+		for _, file := range files {
+			definition, err := readProfileDefinition(file)
+			if err != nil {
+				log.Warnf("unable to load profile %s: %s", file, err)
+				continue
+			}
+			definitions = append(definitions, definition)
+		}
+	}
+	return NewMappingVarbindFormatter(definitions)
+}
+
+// loadConfdProfilesVarbindFormatter loads the default and the user SNMP profiles of the Agent
+func loadConfdProfilesVarbindFormatter() *MappingVarbindFormatter {
+	snmpConfdPath := filepath.Join(config.Datadog.GetString("confd_path"), "snmp.d")
+	return LoadMappingVarbindFormatter(
+		filepath.Join(snmpConfdPath, "default_profiles"),
+		filepath.Join(snmpConfdPath, "profiles"),
+	)
+}
+
+func readProfileDefinition(file string) (*profiledefinition.ProfileDefinition, error) {
+	buf, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	definition := profiledefinition.NewProfileDefinition()
+	if err := yaml.Unmarshal(buf, definition); err != nil {
+		return nil, err
+	}
+	return definition, nil
+}
+
+func (f *MappingVarbindFormatter) addMetricTags(metricTags []profiledefinition.MetricTagConfig) {
+	for _, metricTag := range metricTags {
+		if len(metricTag.Mapping) == 0 {
+			continue
+		}
+		oid := metricTag.OID
+		if oid == "" {
+			oid = metricTag.Column.OID
+		}
+		if oid == "" {
+			continue
+		}
+		f.mappings[NormalizeOID(oid)] = metricTag.Mapping
+	}
+}
+
+// FormatVarbind implements VarbindFormatter#FormatVarbind.
+// Scalar and column OIDs of the profiles match the variables under them, ie. with an index.
+func (f *MappingVarbindFormatter) FormatVarbind(oid string, value interface{}) (interface{}, bool) {
+	mapping, ok := lookupLongestOIDPrefix(f.mappings, NormalizeOID(oid))
+	if !ok {
+		return value, false
+	}
+	converted, ok := mapping[fmt.Sprint(value)]
+	if !ok {
+		return value, false
+	}
+	return converted, true
+}
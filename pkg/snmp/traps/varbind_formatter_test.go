@@ -0,0 +1,128 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2023-present Datadog, Inc.
+
+package traps
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/pkg/aggregator/mocksender"
+	"github.com/DataDog/datadog-agent/pkg/networkdevice/profile/profiledefinition"
+)
+
+var testProfileDefinitions = []*profiledefinition.ProfileDefinition{
+	{
+		MetricTags: []profiledefinition.MetricTagConfig{
+			{Tag: "heartbeat_rate", OID: "1.3.6.1.4.1.8072.2.3.2.1", Mapping: map[string]string{"1024": "fast"}},
+		},
+	},
+	{
+		Metrics: []profiledefinition.MetricsConfig{
+			{
+				MetricTags: []profiledefinition.MetricTagConfig{
+					{Tag: "fan_state", Column: profiledefinition.SymbolConfig{OID: ".1.3.6.1.4.1.9.9.13.1.4.1.3"}, Mapping: map[string]string{"1": "normal", "2": "warning"}},
+					{Tag: "fan_descr", Column: profiledefinition.SymbolConfig{OID: "1.3.6.1.4.1.9.9.13.1.4.1.2"}},
+				},
+			},
+		},
+	},
+}
+
+func TestNoopVarbindFormatter(t *testing.T) {
+	value, ok := NoopVarbindFormatter{}.FormatVarbind("1.3.6.1.4.1.9.9.13.1.4.1.3.1", 1)
+	assert.False(t, ok)
+	assert.Equal(t, 1, value)
+}
+
+func TestMappingVarbindFormatter(t *testing.T) {
+	f := NewMappingVarbindFormatter(testProfileDefinitions)
+
+	tests := []struct {
+		name          string
+		oid           string
+		value         interface{}
+		expectedValue interface{}
+		expectedOK    bool
+	}{
+		{"scalar", "1.3.6.1.4.1.8072.2.3.2.1", 1024, "fast", true},
+		{"column with index", "1.3.6.1.4.1.9.9.13.1.4.1.3.12", 2, "warning", true},
+		{"absolute OID", ".1.3.6.1.4.1.9.9.13.1.4.1.3.12", 1, "normal", true},
+		{"unmapped value", "1.3.6.1.4.1.9.9.13.1.4.1.3.12", 3, 3, false},
+		{"column without mapping", "1.3.6.1.4.1.9.9.13.1.4.1.2.12", "fan 1", "fan 1", false},
+		{"unknown OID", "1.3.6.1.4.1.9.9.13.1.4.1.30.12", 1, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := f.FormatVarbind(tt.oid, tt.value)
+			assert.Equal(t, tt.expectedOK, ok)
+			assert.Equal(t, tt.expectedValue, value)
+		})
+	}
+}
+
+func TestVarbindFormatterRegistry(t *testing.T) {
+	registry := NewVarbindFormatterRegistry()
+	registry.Register(enterpriseOIDPrefix, NewMappingVarbindFormatter(testProfileDefinitions))
+	registry.Register("1.3.6.1.4.1.9", NoopVarbindFormatter{})
+
+	value, ok := registry.FormatVarbind("1.3.6.1.4.1.8072.2.3.2.1", 1024)
+	assert.True(t, ok)
+	assert.Equal(t, "fast", value)
+
+	// the most specific prefix wins
+	value, ok = registry.FormatVarbind("1.3.6.1.4.1.9.9.13.1.4.1.3.12", 1)
+	assert.False(t, ok)
+	assert.Equal(t, 1, value)
+
+	value, ok = registry.FormatVarbind("1.3.6.1.2.1.2.2.1.8.1", 1)
+	assert.False(t, ok)
+	assert.Equal(t, 1, value)
+}
+
+func TestLoadMappingVarbindFormatter(t *testing.T) {
+	dir := t.TempDir()
+	profile := `
+metric_tags:
+  - OID: 1.3.6.1.4.1.8072.2.3.2.1
+    symbol: heartBeatRate
+    tag: heartbeat_rate
+    mapping:
+      1024: fast
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "net-snmp.yaml"), []byte(profile), 0644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "invalid.yaml"), []byte("metric_tags: {"), 0644))
+
+	f := LoadMappingVarbindFormatter(dir, filepath.Join(dir, "does-not-exist"))
+	value, ok := f.FormatVarbind("1.3.6.1.4.1.8072.2.3.2.1", 1024)
+	assert.True(t, ok)
+	assert.Equal(t, "fast", value)
+}
+
+func TestFormatPacketWithVarbindFormatter(t *testing.T) {
+	mockSender := mocksender.NewMockSender("snmp-traps-telemetry")
+	mockSender.SetupAcceptAll()
+
+	formatter, err := NewJSONFormatter(NoOpOIDResolver{}, mockSender)
+	require.NoError(t, err)
+	registry := NewVarbindFormatterRegistry()
+	registry.Register(enterpriseOIDPrefix, NewMappingVarbindFormatter(testProfileDefinitions))
+	formatter.varbindFormatter = registry
+
+	formattedPacket, err := formatter.FormatPacket(createTestPacket(NetSNMPExampleHeartbeatNotification))
+	require.NoError(t, err)
+	data := make(map[string]interface{})
+	err = json.Unmarshal(formattedPacket, &data)
+	require.NoError(t, err)
+	variables := data["trap"].(map[string]interface{})["variables"].([]interface{})
+
+	assert.Equal(t, "fast", variables[0].(map[string]interface{})["value"])
+	assert.Equal(t, "test", variables[1].(map[string]interface{})["value"])
+}
@@ -0,0 +1,50 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package api
+
+import (
+	"sort"
+	"unsafe"
+)
+
+const (
+	stringHeaderSize = int(unsafe.Sizeof(""))
+	sliceHeaderSize  = int(unsafe.Sizeof([]string(nil)))
+)
+
+// EstimateStringSize returns an estimate of the memory used by a string, in bytes.
+func EstimateStringSize(s string) int {
+	return stringHeaderSize + len(s)
+}
+
+// EstimateStringSliceSize returns an estimate of the memory used by a slice of
+// strings, in bytes. Strings shared between slices are counted every time.
+func EstimateStringSliceSize(strs []string) int {
+	size := sliceHeaderSize
+	for _, s := range strs {
+		size += EstimateStringSize(s)
+	}
+	return size
+}
+
+// NewTaggerMemoryDumpResponse builds a TaggerMemoryDumpResponse from a list of
+// entities, sorted by decreasing memory estimate.
+func NewTaggerMemoryDumpResponse(entities []TaggerMemoryDumpEntity) TaggerMemoryDumpResponse {
+	sort.Slice(entities, func(i, j int) bool {
+		if entities[i].MemoryEstimate != entities[j].MemoryEstimate {
+			return entities[i].MemoryEstimate > entities[j].MemoryEstimate
+		}
+		return entities[i].EntityID < entities[j].EntityID
+	})
+
+	resp := TaggerMemoryDumpResponse{
+		Entities: entities,
+	}
+	for _, e := range entities {
+		resp.TotalMemoryEstimate += e.MemoryEstimate
+	}
+	return resp
+}
@@ -14,3 +14,22 @@ type TaggerListResponse struct {
 type TaggerListEntity struct {
 	Tags map[string][]string `json:"tags"`
 }
+
+// TaggerMemoryDumpResponse holds the tagger memory dump response
+type TaggerMemoryDumpResponse struct {
+	Entities            []TaggerMemoryDumpEntity `json:"entities"`
+	TotalMemoryEstimate int                      `json:"total_memory_estimate_bytes"`
+}
+
+// StandardTagsCountKey is the key of the standard tags count in
+// TaggerMemoryDumpEntity.TagCounts, other keys are tag cardinalities
+const StandardTagsCountKey = "standard"
+
+// TaggerMemoryDumpEntity holds the tag counts and the estimated memory usage
+// of an entity
+type TaggerMemoryDumpEntity struct {
+	EntityID       string         `json:"entity_id"`
+	Sources        int            `json:"sources"`
+	TagCounts      map[string]int `json:"tag_counts"`
+	MemoryEstimate int            `json:"memory_estimate_bytes"`
+}
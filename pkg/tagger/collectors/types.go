@@ -6,6 +6,8 @@
 package collectors
 
 import (
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -22,6 +24,60 @@ type TagInfo struct {
 	ExpiryDate           time.Time // keep in cache until expiryDate
 }
 
+// DiffTagInfo returns a human-readable diff of the tags of two TagInfo, listing for
+// each cardinality the tags of actual missing from expected as "+tag" and the tags
+// of expected missing from actual as "-tag". It returns an empty string if they
+// have the same tags.
+func DiffTagInfo(expected, actual *TagInfo) string {
+	if expected == nil {
+		expected = &TagInfo{}
+	}
+	if actual == nil {
+		actual = &TagInfo{}
+	}
+
+	var b strings.Builder
+	for _, level := range []struct {
+		name             string
+		expected, actual []string
+	}{
+		{LowCardinalityString, expected.LowCardTags, actual.LowCardTags},
+		{OrchestratorCardinalityString, expected.OrchestratorCardTags, actual.OrchestratorCardTags},
+		{HighCardinalityString, expected.HighCardTags, actual.HighCardTags},
+		{"standard", expected.StandardTags, actual.StandardTags},
+	} {
+		added := tagsDifference(level.actual, level.expected)
+		missing := tagsDifference(level.expected, level.actual)
+		if len(added) == 0 && len(missing) == 0 {
+			continue
+		}
+		b.WriteString(level.name + " tags:\n")
+		for _, tag := range added {
+			b.WriteString("+" + tag + "\n")
+		}
+		for _, tag := range missing {
+			b.WriteString("-" + tag + "\n")
+		}
+	}
+	return b.String()
+}
+
+// tagsDifference returns the sorted tags of a that are not in b
+func tagsDifference(a, b []string) []string {
+	inB := make(map[string]struct{}, len(b))
+	for _, tag := range b {
+		inB[tag] = struct{}{}
+	}
+	var diff []string
+	for _, tag := range a {
+		if _, found := inB[tag]; !found {
+			diff = append(diff, tag)
+		}
+	}
+	sort.Strings(diff)
+	return diff
+}
+
 // CollectorPriority helps resolving dupe tags from collectors
 type CollectorPriority int
 
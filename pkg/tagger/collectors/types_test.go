@@ -0,0 +1,61 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package collectors
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffTagInfo(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected *TagInfo
+		actual   *TagInfo
+		diff     string
+	}{
+		{
+			name:     "same tags in a different order",
+			expected: &TagInfo{LowCardTags: []string{"a:1", "b:2"}, HighCardTags: []string{"c:3"}},
+			actual:   &TagInfo{LowCardTags: []string{"b:2", "a:1"}, HighCardTags: []string{"c:3"}},
+			diff:     "",
+		},
+		{
+			name: "added and missing tags",
+			expected: &TagInfo{
+				LowCardTags:          []string{"image_name:redis", "kube_namespace:default"},
+				OrchestratorCardTags: []string{"pod_name:redis"},
+				StandardTags:         []string{"env:prod"},
+			},
+			actual: &TagInfo{
+				LowCardTags:          []string{"kube_namespace:default", "image_tag:latest", "image_id:sha"},
+				OrchestratorCardTags: []string{"pod_name:redis"},
+				HighCardTags:         []string{"container_id:foo"},
+			},
+			diff: "low tags:\n" +
+				"+image_id:sha\n" +
+				"+image_tag:latest\n" +
+				"-image_name:redis\n" +
+				"high tags:\n" +
+				"+container_id:foo\n" +
+				"standard tags:\n" +
+				"-env:prod\n",
+		},
+		{
+			name:     "nil TagInfo",
+			expected: nil,
+			actual:   &TagInfo{LowCardTags: []string{"a:1"}},
+			diff:     "low tags:\n+a:1\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.diff, DiffTagInfo(tt.expected, tt.actual))
+		})
+	}
+}
@@ -9,7 +9,9 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/DataDog/datadog-agent/pkg/config"
 	"github.com/DataDog/datadog-agent/pkg/tagger/utils"
@@ -52,7 +54,14 @@ const (
 	dockerLabelVersion = "com.datadoghq.tags.version"
 	dockerLabelService = "com.datadoghq.tags.service"
 
+	// OCI image label keys
+	ociLabelVersion = "org.opencontainers.image.version"
+
 	autodiscoveryLabelTagsKey = "com.datadoghq.ad.tags"
+
+	// CloudFoundry - Environment variables
+	envVarCFVcapApplication = "VCAP_APPLICATION"
+	envVarCFInstanceIndex   = "CF_INSTANCE_INDEX"
 )
 
 var (
@@ -75,6 +84,14 @@ var (
 		"NOMAD_GROUP_NAME": "nomad_group",
 		"NOMAD_NAMESPACE":  "nomad_namespace",
 		"NOMAD_DC":         "nomad_dc",
+
+		"K_SERVICE":       "cloud_run_service",
+		"K_REVISION":      "cloud_run_revision",
+		"K_CONFIGURATION": "cloud_run_configuration",
+
+		"ACI_CONTAINER_GROUP": "aci_container_group",
+		"ACI_CONTAINER_NAME":  "aci_container_name",
+		"WEBSITE_SITE_NAME":   "azure_app_name",
 	}
 
 	orchCardOrchestratorEnvKeys = map[string]string{
@@ -186,6 +203,16 @@ func (c *WorkloadMetaCollector) handleContainer(ev workloadmeta.Event) []*TagInf
 	tags := utils.NewTagList()
 	tags.AddHigh("container_name", container.Name)
 	tags.AddHigh("container_id", container.ID)
+	tags.AddLow("container_runtime", string(container.Runtime))
+
+	tags.AddHigh("container_status", string(container.State.Status))
+	if !container.State.CreatedAt.IsZero() {
+		tags.AddHigh("container_created_at", strconv.FormatInt(container.State.CreatedAt.Unix(), 10))
+	}
+	// the exit code is only meaningful once the container has stopped
+	if !container.State.Running && container.State.ExitCode != nil {
+		tags.AddHigh("container_exit_code", strconv.FormatUint(uint64(*container.State.ExitCode), 10))
+	}
 
 	image := container.Image
 	tags.AddLow("image_name", image.Name)
@@ -201,11 +228,27 @@ func (c *WorkloadMetaCollector) handleContainer(ev workloadmeta.Event) []*TagInf
 		}
 	}
 
+	for _, port := range container.Ports {
+		if port.Protocol != "" {
+			tags.AddOrchestrator("container_port", fmt.Sprintf("%d/%s", port.Port, strings.ToLower(port.Protocol)))
+		} else {
+			tags.AddOrchestrator("container_port", strconv.Itoa(port.Port))
+		}
+	}
+
 	c.labelsToTags(container.Labels, tags)
 
 	// standard tags from environment
 	c.extractFromMapWithFn(container.EnvVars, standardEnvKeys, tags.AddStandard)
 
+	// the OCI image version is only a fallback for the version standard
+	// tag, when the container has no other source for it. Kubernetes
+	// containers can also get their version from their pod, so their
+	// fallback is handled with the tags of the pod instead.
+	if _, inPod := container.Labels[kubernetes.CriContainerNamespaceLabel]; !inPod && !containerHasVersion(container) {
+		tags.AddStandard(tagKeyVersion, container.Labels[ociLabelVersion])
+	}
+
 	// orchestrator tags from environment
 	c.extractFromMapWithFn(container.EnvVars, lowCardOrchestratorEnvKeys, tags.AddLow)
 	c.extractFromMapWithFn(container.EnvVars, orchCardOrchestratorEnvKeys, tags.AddOrchestrator)
@@ -310,12 +353,28 @@ func (c *WorkloadMetaCollector) handleKubePod(ev workloadmeta.Event) []*TagInfo
 
 	tags := utils.NewTagList()
 	tags.AddOrchestrator(kubernetes.PodTagName, pod.Name)
+	tags.AddOrchestrator("kube_nominated_node", pod.NominatedNodeName)
 	tags.AddLow(kubernetes.NamespaceTagName, pod.Namespace)
 	tags.AddLow("pod_phase", strings.ToLower(pod.Phase))
 	tags.AddLow("kube_priority_class", pod.PriorityClass)
+	tags.AddLow("kube_runtime_class", pod.RuntimeClass)
 	tags.AddLow("kube_qos", pod.QOSClass)
 
+	for condition, tagName := range c.podConditionsAsTags {
+		if status, found := pod.Conditions[condition]; found {
+			tags.AddLow(tagName, strconv.FormatBool(status))
+		}
+	}
+
+	if !pod.ScheduledAt.IsZero() {
+		tags.AddHigh("pod_scheduled_at", strconv.FormatInt(pod.ScheduledAt.Unix(), 10))
+	}
+	if pod.StartTime != nil {
+		tags.AddOrchestrator("pod_start_time", strconv.FormatInt(pod.StartTime.Unix(), 10))
+	}
+
 	c.extractTagsFromPodLabels(pod, tags)
+	c.extractTagsFromNamespaceAnnotations(pod, tags)
 
 	for name, value := range pod.Annotations {
 		utils.AddMetadataAsTags(name, value, c.annotationsAsTags, c.globAnnotations, tags)
@@ -325,6 +384,22 @@ func (c *WorkloadMetaCollector) handleKubePod(ev workloadmeta.Event) []*TagInfo
 		utils.AddMetadataAsTags(name, value, c.nsLabelsAsTags, c.globNsLabels, tags)
 	}
 
+	c.extractTagsFromPodConfigMaps(pod, tags)
+	c.extractTagsFromPodTopology(pod, tags)
+	c.extractTagsFromPodVirtualNode(pod, tags)
+	c.extractTagsFromPodNodeConditions(pod, tags)
+
+	for _, key := range pod.TolerationKeys {
+		tags.AddLow("kube_toleration", key)
+	}
+
+	// only the names of the secrets are used, never their values
+	if c.collectSecretNamesAsTags {
+		for _, secret := range pod.SecretNames {
+			tags.AddLow(kubernetes.SecretTagName, secret)
+		}
+	}
+
 	kubeServiceDisabled := false
 	for _, disabledTag := range config.Datadog.GetStringSlice("kubernetes_ad_tags_disabled") {
 		if disabledTag == "kube_service" {
@@ -362,6 +437,18 @@ func (c *WorkloadMetaCollector) handleKubePod(ev workloadmeta.Event) []*TagInfo
 		tags.AddLow(kubernetes.RcRevisionTagName, rcRev)
 	}
 
+	// Pod security policy admitting the pod. Skipped if the annotation is
+	// already mapped through kubernetes_pod_annotations_as_tags.
+	if psp, found := pod.Annotations[kubernetes.PodSecurityPolicyAnnotKey]; found {
+		if _, mapped := c.annotationsAsTags[kubernetes.PodSecurityPolicyAnnotKey]; !mapped {
+			tags.AddLow(kubernetes.PodSecurityPolicyTagName, psp)
+		}
+	}
+
+	// Helm release managing the pod
+	tags.AddLow(kubernetes.HelmReleaseTagName, pod.Annotations[kubernetes.HelmReleaseNameAnnotKey])
+	tags.AddLow(kubernetes.HelmReleaseNamespaceTagName, pod.Annotations[kubernetes.HelmReleaseNamespaceAnnotKey])
+
 	for _, owner := range pod.Owners {
 		tags.AddLow(kubernetes.OwnerRefKindTagName, strings.ToLower(owner.Kind))
 		tags.AddOrchestrator(kubernetes.OwnerRefNameTagName, owner.Name)
@@ -396,6 +483,21 @@ func (c *WorkloadMetaCollector) handleKubePod(ev workloadmeta.Event) []*TagInfo
 		tagInfos = append(tagInfos, cTagInfo)
 	}
 
+	// ephemeral containers are tagged so that metrics coming from debug
+	// sessions (kubectl debug) can be filtered out
+	for _, podContainer := range pod.EphemeralContainers {
+		cTags := tags.Copy()
+		cTags.AddHigh("kube_ephemeral_container", "true")
+
+		cTagInfo, err := c.extractTagsFromPodContainer(pod, podContainer, cTags)
+		if err != nil {
+			log.Debugf("cannot extract tags from pod ephemeral container: %s", err)
+			continue
+		}
+
+		tagInfos = append(tagInfos, cTagInfo)
+	}
+
 	return tagInfos
 }
 
@@ -433,6 +535,12 @@ func (c *WorkloadMetaCollector) handleECSTask(ev workloadmeta.Event) []*TagInfo
 	taskTags.AddLow("task_family", task.Family)
 	taskTags.AddLow("task_version", task.Version)
 	taskTags.AddOrchestrator("task_arn", task.ID)
+	if task.StartedAt != nil {
+		taskTags.AddOrchestrator("task_started_at", strconv.FormatInt(task.StartedAt.Unix(), 10))
+	}
+	if task.KnownStatus == "STOPPED" {
+		taskTags.AddHigh("ecs_task_stop_code", task.StopCode)
+	}
 
 	if task.ClusterName != "" {
 		if !config.Datadog.GetBool("disable_cluster_name_tag_key") {
@@ -493,15 +601,55 @@ func (c *WorkloadMetaCollector) handleECSTask(ev workloadmeta.Event) []*TagInfo
 }
 
 func (c *WorkloadMetaCollector) handleGardenContainer(container *workloadmeta.Container) []*TagInfo {
+	tags := utils.NewTagList()
+	extractTagsFromGardenEnv(container.EnvVars, tags)
+
+	low, orch, high, standard := tags.Compute()
 	return []*TagInfo{
 		{
-			Source:       containerSource,
-			Entity:       buildTaggerEntityID(container.EntityID),
-			HighCardTags: container.CollectorTags,
+			Source:               containerSource,
+			Entity:               buildTaggerEntityID(container.EntityID),
+			HighCardTags:         append(high, container.CollectorTags...),
+			OrchestratorCardTags: orch,
+			LowCardTags:          low,
+			StandardTags:         standard,
 		},
 	}
 }
 
+// vcapApplication holds the fields of the VCAP_APPLICATION environment
+// variable that are used for tagging CloudFoundry containers.
+type vcapApplication struct {
+	ApplicationID    string `json:"application_id"`
+	ApplicationName  string `json:"application_name"`
+	SpaceID          string `json:"space_id"`
+	SpaceName        string `json:"space_name"`
+	OrganizationID   string `json:"organization_id"`
+	OrganizationName string `json:"organization_name"`
+}
+
+func extractTagsFromGardenEnv(envVars map[string]string, tags *utils.TagList) {
+	tags.AddOrchestrator("cf_instance_index", envVars[envVarCFInstanceIndex])
+
+	vcap, ok := envVars[envVarCFVcapApplication]
+	if !ok || vcap == "" {
+		return
+	}
+
+	var app vcapApplication
+	if err := json.Unmarshal([]byte(vcap), &app); err != nil {
+		log.Debugf("Failed to parse %s env var: %v", envVarCFVcapApplication, err)
+		return
+	}
+
+	tags.AddLow("cf_app_id", app.ApplicationID)
+	tags.AddLow("cf_app_name", app.ApplicationName)
+	tags.AddLow("cf_space_id", app.SpaceID)
+	tags.AddLow("cf_space_name", app.SpaceName)
+	tags.AddLow("cf_org_id", app.OrganizationID)
+	tags.AddLow("cf_org_name", app.OrganizationName)
+}
+
 func (c *WorkloadMetaCollector) extractTagsFromPodLabels(pod *workloadmeta.KubernetesPod, tags *utils.TagList) {
 	for name, value := range pod.Labels {
 		switch name {
@@ -529,6 +677,115 @@ func (c *WorkloadMetaCollector) extractTagsFromPodLabels(pod *workloadmeta.Kuber
 	}
 }
 
+// extractTagsFromNamespaceAnnotations adds the standard tags set on the
+// namespace of the pod, unless the pod sets them through its own labels.
+func (c *WorkloadMetaCollector) extractTagsFromNamespaceAnnotations(pod *workloadmeta.KubernetesPod, tags *utils.TagList) {
+	for _, standardTag := range []struct {
+		annotation string
+		label      string
+		tagKey     string
+	}{
+		{kubernetes.ClusterEnvAnnotKey, kubernetes.EnvTagLabelKey, tagKeyEnv},
+		{kubernetes.ClusterServiceAnnotKey, kubernetes.ServiceTagLabelKey, tagKeyService},
+		{kubernetes.ClusterVersionAnnotKey, kubernetes.VersionTagLabelKey, tagKeyVersion},
+	} {
+		value, found := pod.NamespaceAnnotations[standardTag.annotation]
+		if !found {
+			continue
+		}
+		if _, overridden := pod.Labels[standardTag.label]; overridden {
+			continue
+		}
+		tags.AddStandard(standardTag.tagKey, value)
+	}
+}
+
+// extractTagsFromPodConfigMaps adds tags from the labels of the ConfigMaps
+// referenced by the pod. Changes to a ConfigMap are only reflected the next
+// time the pod itself is updated. ConfigMaps and their references are only
+// collected by the kubeapiserver collector, so this only applies to the
+// Cluster Agent.
+func (c *WorkloadMetaCollector) extractTagsFromPodConfigMaps(pod *workloadmeta.KubernetesPod, tags *utils.TagList) {
+	if len(c.configMapLabelsAsTags) == 0 {
+		return
+	}
+
+	for _, name := range pod.ConfigMapNames {
+		configMap, err := c.store.GetKubernetesConfigMap(pod.Namespace + "/" + name)
+		if err != nil {
+			log.Debugf("pod %q has reference to non-existing configmap %q", pod.Name, name)
+			continue
+		}
+
+		for labelName, labelValue := range configMap.Labels {
+			utils.AddMetadataAsTags(labelName, labelValue, c.configMapLabelsAsTags, c.globConfigMapLabels, tags)
+		}
+	}
+}
+
+// extractTagsFromPodTopology adds the zone of the node a pod runs on when the
+// pod is spread across zones through topology spread constraints. It relies on
+// the node being present in workloadmeta, which is the case in the Cluster
+// Agent.
+func (c *WorkloadMetaCollector) extractTagsFromPodTopology(pod *workloadmeta.KubernetesPod, tags *utils.TagList) {
+	if pod.NodeName == "" {
+		return
+	}
+
+	for _, key := range pod.TopologySpreadKeys {
+		if key != kubernetes.TopologyZoneLabelKey && key != kubernetes.LegacyTopologyZoneLabelKey {
+			continue
+		}
+
+		node, err := c.store.GetKubernetesNode(pod.NodeName)
+		if err != nil {
+			log.Debugf("pod %q has reference to non-existing node %q", pod.Name, pod.NodeName)
+			return
+		}
+
+		if zone, found := node.Labels[key]; found {
+			tags.AddLow(kubernetes.TopologyZoneTagName, zone)
+		}
+	}
+}
+
+func (c *WorkloadMetaCollector) extractTagsFromPodVirtualNode(pod *workloadmeta.KubernetesPod, tags *utils.TagList) {
+	if c.virtualNodeLabel == "" || pod.NodeName == "" {
+		return
+	}
+
+	node, err := c.store.GetKubernetesNode(pod.NodeName)
+	if err != nil {
+		log.Debugf("pod %q has reference to non-existing node %q", pod.Name, pod.NodeName)
+		return
+	}
+
+	if provider, found := node.Labels[c.virtualNodeLabel]; found {
+		tags.AddLow(kubernetes.VirtualNodeTagName, provider)
+	}
+}
+
+// extractTagsFromPodNodeConditions tags the pod with the conditions of its
+// node. The tags are only refreshed when the pod itself is updated.
+func (c *WorkloadMetaCollector) extractTagsFromPodNodeConditions(pod *workloadmeta.KubernetesPod, tags *utils.TagList) {
+	if !c.collectNodeConditions || pod.NodeName == "" {
+		return
+	}
+
+	node, err := c.store.GetKubernetesNode(pod.NodeName)
+	if err != nil {
+		log.Debugf("pod %q has reference to non-existing node %q", pod.Name, pod.NodeName)
+		return
+	}
+
+	if ready, found := node.Conditions["Ready"]; found {
+		tags.AddLow(kubernetes.NodeReadyTagName, strconv.FormatBool(ready))
+	}
+	if diskPressure, found := node.Conditions["DiskPressure"]; found {
+		tags.AddLow(kubernetes.NodeDiskPressureTagName, strconv.FormatBool(diskPressure))
+	}
+}
+
 func (c *WorkloadMetaCollector) extractTagsFromPodOwner(pod *workloadmeta.KubernetesPod, owner workloadmeta.KubernetesPodOwner, tags *utils.TagList) {
 	switch owner.Kind {
 	case kubernetes.DeploymentKind:
@@ -549,7 +806,7 @@ func (c *WorkloadMetaCollector) extractTagsFromPodOwner(pod *workloadmeta.Kubern
 		}
 
 	case kubernetes.JobKind:
-		cronjob, _ := kubernetes.ParseCronJobForJob(owner.Name)
+		cronjob := c.cronJobForJob(owner)
 		if cronjob != "" {
 			tags.AddOrchestrator(kubernetes.JobTagName, owner.Name)
 			tags.AddLow(kubernetes.CronJobTagName, cronjob)
@@ -566,6 +823,55 @@ func (c *WorkloadMetaCollector) extractTagsFromPodOwner(pod *workloadmeta.Kubern
 	}
 }
 
+// cronJobForJob returns the name of the CronJob that spawned the given Job
+// owner. The owners of the Job are looked up in workloadmeta when available
+// (Cluster Agent), otherwise the CronJob name is parsed from the Job name.
+func (c *WorkloadMetaCollector) cronJobForJob(owner workloadmeta.KubernetesPodOwner) string {
+	if entry, found := c.getCachedOwner(owner.ID); found {
+		return entry.cronJob
+	}
+
+	if job, err := c.store.GetKubernetesJob(owner.ID); err == nil {
+		cronjob := ""
+		for _, jobOwner := range job.Owners {
+			if jobOwner.Kind == kubernetes.CronJobKind {
+				cronjob = jobOwner.Name
+				break
+			}
+		}
+		c.cacheOwner(owner.ID, ownerCacheEntry{cronJob: cronjob})
+		return cronjob
+	}
+
+	cronjob, _ := kubernetes.ParseCronJobForJob(owner.Name)
+	return cronjob
+}
+
+// getCachedOwner returns the unexpired cache entry of the owner entity ownerID
+func (c *WorkloadMetaCollector) getCachedOwner(ownerID string) (ownerCacheEntry, bool) {
+	if c.ownerCache == nil || ownerID == "" {
+		return ownerCacheEntry{}, false
+	}
+	entry, found := c.ownerCache.Get(ownerID)
+	if !found {
+		return ownerCacheEntry{}, false
+	}
+	if time.Now().After(entry.expires) {
+		c.ownerCache.Remove(ownerID)
+		return ownerCacheEntry{}, false
+	}
+	return entry, true
+}
+
+// cacheOwner caches the tags derived from the owner entity ownerID for ownerCacheTTL
+func (c *WorkloadMetaCollector) cacheOwner(ownerID string, entry ownerCacheEntry) {
+	if c.ownerCache == nil || ownerID == "" {
+		return
+	}
+	entry.expires = time.Now().Add(ownerCacheTTL)
+	c.ownerCache.Add(ownerID, entry)
+}
+
 func (c *WorkloadMetaCollector) extractTagsFromPodContainer(pod *workloadmeta.KubernetesPod, podContainer workloadmeta.OrchestratorContainer, tags *utils.TagList) (*TagInfo, error) {
 	container, err := c.store.GetContainer(podContainer.ID)
 	if err != nil {
@@ -577,6 +883,10 @@ func (c *WorkloadMetaCollector) extractTagsFromPodContainer(pod *workloadmeta.Ku
 	tags.AddLow("kube_container_name", podContainer.Name)
 	tags.AddHigh("container_id", container.ID)
 
+	if podContainer.IsInit {
+		tags.AddLow("kube_init_container", "true")
+	}
+
 	if container.Name != "" && pod.Name != "" {
 		tags.AddHigh("display_container_name", fmt.Sprintf("%s_%s", container.Name, pod.Name))
 	}
@@ -586,6 +896,18 @@ func (c *WorkloadMetaCollector) extractTagsFromPodContainer(pod *workloadmeta.Ku
 	tags.AddLow("short_image", image.ShortName)
 	tags.AddLow("image_tag", image.Tag)
 	tags.AddLow("image_id", image.ID)
+	tags.AddLow("kube_image_pull_policy", podContainer.ImagePullPolicy)
+	tags.AddLow("kube_liveness_probe_type", podContainer.LivenessProbeType)
+
+	resources := podContainer.Resources
+	tags.AddLow("kube_cpu_request", resources.CPURequest)
+	tags.AddLow("kube_cpu_limit", resources.CPULimit)
+	tags.AddLow("kube_memory_request", resources.MemoryRequest)
+	tags.AddLow("kube_memory_limit", resources.MemoryLimit)
+
+	if podContainer.LastExitCode != nil {
+		tags.AddHigh("kube_container_exit_code", strconv.Itoa(int(*podContainer.LastExitCode)))
+	}
 
 	// enrich with standard tags from labels for this container if present
 	containerName := podContainer.Name
@@ -599,6 +921,12 @@ func (c *WorkloadMetaCollector) extractTagsFromPodContainer(pod *workloadmeta.Ku
 	// enrich with standard tags from environment variables
 	c.extractFromMapWithFn(container.EnvVars, standardEnvKeys, tags.AddStandard)
 
+	// the OCI image version is only a fallback for the version standard
+	// tag, when neither the pod nor the container set it
+	if !podHasVersion(pod, containerName) && !containerHasVersion(container) {
+		tags.AddStandard(tagKeyVersion, container.Labels[ociLabelVersion])
+	}
+
 	// container-specific tags provided through pod annotation
 	annotation := fmt.Sprintf(podContainerTagsAnnotationFormat, containerName)
 	c.extractTagsFromJSONInMap(annotation, pod.Annotations, tags)
@@ -616,6 +944,29 @@ func (c *WorkloadMetaCollector) extractTagsFromPodContainer(pod *workloadmeta.Ku
 	}, nil
 }
 
+// podHasVersion returns whether the pod sets the version standard tag of the
+// container with its labels or the annotations of its namespace
+func podHasVersion(pod *workloadmeta.KubernetesPod, containerName string) bool {
+	for _, label := range []string{
+		kubernetes.VersionTagLabelKey,
+		fmt.Sprintf(podStandardLabelPrefix+"%s.%s", containerName, tagKeyVersion),
+	} {
+		if _, found := pod.Labels[label]; found {
+			return true
+		}
+	}
+	_, found := pod.NamespaceAnnotations[kubernetes.ClusterVersionAnnotKey]
+	return found
+}
+
+// containerHasVersion returns whether the container sets the version standard
+// tag with its DD label or its DD_VERSION environment variable
+func containerHasVersion(container *workloadmeta.Container) bool {
+	_, fromLabel := container.Labels[dockerLabelVersion]
+	_, fromEnv := container.EnvVars[envVarVersion]
+	return fromLabel || fromEnv
+}
+
 func (c *WorkloadMetaCollector) registerChild(parent, child workloadmeta.EntityID) {
 	parentTaggerEntityID := buildTaggerEntityID(parent)
 	childTaggerEntityID := buildTaggerEntityID(child)
@@ -726,6 +1077,10 @@ func parseJSONValue(value string, tags *utils.TagList) error {
 
 	result := map[string]interface{}{}
 	if err := json.Unmarshal([]byte(value), &result); err != nil {
+		// the value may also be a bare array of "key:value" tags
+		if arrErr := parseJSONArrayValue(value, tags); arrErr == nil {
+			return nil
+		}
 		return fmt.Errorf("failed to unmarshal JSON: %s", err)
 	}
 
@@ -745,6 +1100,36 @@ func parseJSONValue(value string, tags *utils.TagList) error {
 	return nil
 }
 
+// parseJSONArrayValue parses a JSON array of "key:value" strings, such as
+// `["tag1:val1","tag2:val2"]`, and adds them to the tag list.
+func parseJSONArrayValue(value string, tags *utils.TagList) error {
+	if value == "" {
+		return errors.New("value is empty")
+	}
+
+	result := []interface{}{}
+	if err := json.Unmarshal([]byte(value), &result); err != nil {
+		return fmt.Errorf("failed to unmarshal JSON array: %s", err)
+	}
+
+	for _, item := range result {
+		tag, ok := item.(string)
+		if !ok {
+			log.Debugf("Tag %v is not valid, must be a string, skipping", item)
+			continue
+		}
+
+		key, val, found := strings.Cut(tag, ":")
+		if !found || key == "" {
+			log.Debugf("Tag '%s' is not in k:v format, skipping", tag)
+			continue
+		}
+		tags.AddAuto(key, val)
+	}
+
+	return nil
+}
+
 func parseContainerADTagsLabels(tags *utils.TagList, labelValue string) {
 	tagNames := []string{}
 	err := json.Unmarshal([]byte(labelValue), &tagNames)
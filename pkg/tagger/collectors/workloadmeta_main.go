@@ -7,9 +7,12 @@ package collectors
 
 import (
 	"context"
+	"regexp"
 	"strings"
+	"time"
 
 	"github.com/gobwas/glob"
+	lru "github.com/hashicorp/golang-lru/v2"
 
 	"github.com/DataDog/datadog-agent/pkg/config"
 	"github.com/DataDog/datadog-agent/pkg/status/health"
@@ -33,6 +36,11 @@ const (
 	processSource        = workloadmetaCollectorName + "-" + string(workloadmeta.KindProcess)
 
 	clusterTagNamePrefix = "kube_cluster_name"
+
+	// pods of the same workload share their owners, so the owners looked up
+	// in workloadmeta are cached for a while
+	ownerCacheSize = 1000
+	ownerCacheTTL  = 5 * time.Minute
 )
 
 // CollectorPriorities holds collector priorities
@@ -56,13 +64,27 @@ type WorkloadMetaCollector struct {
 	labelsAsTags           map[string]string
 	annotationsAsTags      map[string]string
 	nsLabelsAsTags         map[string]string
+	configMapLabelsAsTags  map[string]string
+	podConditionsAsTags    map[string]string
 	globLabels             map[string]glob.Glob
 	globAnnotations        map[string]glob.Glob
 	globNsLabels           map[string]glob.Glob
+	globConfigMapLabels    map[string]glob.Glob
 	globContainerLabels    map[string]glob.Glob
 	globContainerEnvLabels map[string]glob.Glob
 
-	collectEC2ResourceTags bool
+	collectEC2ResourceTags   bool
+	collectSecretNamesAsTags bool
+	virtualNodeLabel         string
+	collectNodeConditions    bool
+
+	// ownerCache holds the tags derived from the owner entities of pods, by owner entity ID
+	ownerCache *lru.Cache[string, ownerCacheEntry]
+}
+
+type ownerCacheEntry struct {
+	cronJob string
+	expires time.Time
 }
 
 func (c *WorkloadMetaCollector) initContainerMetaAsTags(labelsAsTags, envAsTags map[string]string) {
@@ -76,6 +98,22 @@ func (c *WorkloadMetaCollector) initPodMetaAsTags(labelsAsTags, annotationsAsTag
 	c.nsLabelsAsTags, c.globNsLabels = utils.InitMetadataAsTags(nsLabelsAsTags)
 }
 
+func (c *WorkloadMetaCollector) initConfigMapMetaAsTags(configMapLabelsAsTags map[string]string) {
+	c.configMapLabelsAsTags, c.globConfigMapLabels = utils.InitMetadataAsTags(configMapLabelsAsTags)
+}
+
+var matchAllCap = regexp.MustCompile("([a-z0-9])([A-Z])")
+
+// initPodConditionsAsTags maps each pod condition to its tag name, e.g.
+// ContainersReady to kube_condition_containers_ready
+func (c *WorkloadMetaCollector) initPodConditionsAsTags(conditions []string) {
+	c.podConditionsAsTags = make(map[string]string, len(conditions))
+	for _, condition := range conditions {
+		snake := strings.ToLower(matchAllCap.ReplaceAllString(condition, "${1}_${2}"))
+		c.podConditionsAsTags[condition] = "kube_condition_" + snake
+	}
+}
+
 // Run runs the continuous event watching loop and sends new tags to the
 // tagger based on the events sent by the workloadmeta.
 func (c *WorkloadMetaCollector) Run(ctx context.Context) {
@@ -153,12 +191,18 @@ func (c *WorkloadMetaCollector) stream(ctx context.Context) {
 // NewWorkloadMetaCollector returns a new WorkloadMetaCollector.
 func NewWorkloadMetaCollector(ctx context.Context, store workloadmeta.Store, p processor) *WorkloadMetaCollector {
 	c := &WorkloadMetaCollector{
-		tagProcessor:           p,
-		store:                  store,
-		children:               make(map[string]map[string]struct{}),
-		collectEC2ResourceTags: config.Datadog.GetBool("ecs_collect_resource_tags_ec2"),
+		tagProcessor:             p,
+		store:                    store,
+		children:                 make(map[string]map[string]struct{}),
+		collectEC2ResourceTags:   config.Datadog.GetBool("ecs_collect_resource_tags_ec2"),
+		collectSecretNamesAsTags: config.Datadog.GetBool("kubernetes_secret_names_as_tags"),
+		virtualNodeLabel:         config.Datadog.GetString("virtual_node_label"),
+		collectNodeConditions:    config.Datadog.GetBool("kubernetes_node_conditions_as_tags"),
 	}
 
+	// lru.New only fails with a non-positive size
+	c.ownerCache, _ = lru.New[string, ownerCacheEntry](ownerCacheSize)
+
 	containerLabelsAsTags := mergeMaps(
 		retrieveMappingFromConfig("docker_labels_as_tags"),
 		retrieveMappingFromConfig("container_labels_as_tags"),
@@ -175,6 +219,11 @@ func NewWorkloadMetaCollector(ctx context.Context, store workloadmeta.Store, p p
 	nsLabelsAsTags := config.Datadog.GetStringMapString("kubernetes_namespace_labels_as_tags")
 	c.initPodMetaAsTags(labelsAsTags, annotationsAsTags, nsLabelsAsTags)
 
+	configMapLabelsAsTags := config.Datadog.GetStringMapString("cluster_agent.kubernetes_configmap_labels_as_tags")
+	c.initConfigMapMetaAsTags(configMapLabelsAsTags)
+
+	c.initPodConditionsAsTags(config.Datadog.GetStringSlice("kubernetes_pod_conditions_as_tags"))
+
 	return c
 }
 
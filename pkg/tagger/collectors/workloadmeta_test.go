@@ -9,13 +9,16 @@ import (
 	"fmt"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/DataDog/datadog-agent/pkg/tagger/utils"
 	"github.com/DataDog/datadog-agent/pkg/util/kubernetes"
+	"github.com/DataDog/datadog-agent/pkg/util/pointer"
 	"github.com/DataDog/datadog-agent/pkg/workloadmeta"
 	workloadmetatesting "github.com/DataDog/datadog-agent/pkg/workloadmeta/testing"
 
 	"github.com/google/go-cmp/cmp"
+	lru "github.com/hashicorp/golang-lru/v2"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -23,6 +26,7 @@ func TestHandleKubePod(t *testing.T) {
 	const (
 		fullyFleshedContainerID = "foobarquux"
 		noEnvContainerID        = "foobarbaz"
+		ociVersionContainerID   = "foobarqux"
 		containerName           = "agent"
 		runtimeContainerName    = "k8s_datadog-agent_agent"
 		podName                 = "datadog-agent-foobar"
@@ -46,6 +50,7 @@ func TestHandleKubePod(t *testing.T) {
 	podTaggerEntityID := fmt.Sprintf("kubernetes_pod_uid://%s", podEntityID.ID)
 	fullyFleshedContainerTaggerEntityID := fmt.Sprintf("container_id://%s", fullyFleshedContainerID)
 	noEnvContainerTaggerEntityID := fmt.Sprintf("container_id://%s", noEnvContainerID)
+	ociVersionContainerTaggerEntityID := fmt.Sprintf("container_id://%s", ociVersionContainerID)
 
 	image := workloadmeta.ContainerImage{
 		ID:        "datadog/agent@sha256:a63d3f66fb2f69d955d4f2ca0b229385537a77872ffc04290acae65aed5317d2",
@@ -80,15 +85,92 @@ func TestHandleKubePod(t *testing.T) {
 			Name: runtimeContainerName,
 		},
 	})
+	store.Set(&workloadmeta.Container{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindContainer,
+			ID:   ociVersionContainerID,
+		},
+		EntityMeta: workloadmeta.EntityMeta{
+			Name: runtimeContainerName,
+			Labels: map[string]string{
+				"org.opencontainers.image.version": "1.2.3",
+			},
+		},
+	})
+	store.Set(&workloadmeta.KubernetesJob{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindKubernetesJob,
+			ID:   "manual-job-uid",
+		},
+		EntityMeta: workloadmeta.EntityMeta{
+			Name:      "backup-manual",
+			Namespace: podNamespace,
+		},
+		Owners: []workloadmeta.KubernetesPodOwner{
+			{
+				Kind: kubernetes.CronJobKind,
+				Name: "backup",
+				ID:   "cronjob-uid",
+			},
+		},
+	})
+	store.Set(&workloadmeta.KubernetesJob{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindKubernetesJob,
+			ID:   "standalone-job-uid",
+		},
+		EntityMeta: workloadmeta.EntityMeta{
+			Name:      "migrate-1234",
+			Namespace: podNamespace,
+		},
+	})
+	store.Set(&workloadmeta.KubernetesNode{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindKubernetesNode,
+			ID:   "node-1",
+		},
+		EntityMeta: workloadmeta.EntityMeta{
+			Name: "node-1",
+			Labels: map[string]string{
+				"topology.kubernetes.io/zone":    "us-east-1a",
+				"kubernetes.io/hostname":         "node-1",
+				"eks.amazonaws.com/compute-type": "fargate",
+			},
+		},
+		Conditions: map[string]bool{
+			"Ready":          true,
+			"DiskPressure":   false,
+			"MemoryPressure": false,
+		},
+	})
+	store.Set(&workloadmeta.KubernetesConfigMap{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindKubernetesConfigMap,
+			ID:   podNamespace + "/agent-config",
+		},
+		EntityMeta: workloadmeta.EntityMeta{
+			Name:      "agent-config",
+			Namespace: podNamespace,
+			Labels: map[string]string{
+				"team":    "container-integrations",
+				"ignored": "value",
+			},
+		},
+	})
 
 	tests := []struct {
-		name              string
-		staticTags        map[string]string
-		labelsAsTags      map[string]string
-		annotationsAsTags map[string]string
-		nsLabelsAsTags    map[string]string
-		pod               workloadmeta.KubernetesPod
-		expected          []*TagInfo
+		name                  string
+		staticTags            map[string]string
+		labelsAsTags          map[string]string
+		annotationsAsTags     map[string]string
+		nsLabelsAsTags        map[string]string
+		configMapLabelsAsTags map[string]string
+		secretNamesAsTags     bool
+		virtualNodeLabel      string
+		podConditionsAsTags   []string
+		nodeConditionsAsTags  bool
+		pod                   workloadmeta.KubernetesPod
+		expected              []*TagInfo
 	}{
 		{
 			name: "fully formed pod (no containers)",
@@ -305,16 +387,17 @@ func TestHandleKubePod(t *testing.T) {
 			},
 		},
 		{
-			name: "pod from openshift deployment",
+			name: "pod with container, version from opencontainers image version",
 			pod: workloadmeta.KubernetesPod{
 				EntityID: podEntityID,
 				EntityMeta: workloadmeta.EntityMeta{
 					Name:      podName,
 					Namespace: podNamespace,
-					Annotations: map[string]string{
-						"openshift.io/deployment-config.latest-version": "1",
-						"openshift.io/deployment-config.name":           "gitlab-ce",
-						"openshift.io/deployment.name":                  "gitlab-ce-1",
+				},
+				Containers: []workloadmeta.OrchestratorContainer{
+					{
+						ID:   ociVersionContainerID,
+						Name: containerName,
 					},
 				},
 			},
@@ -325,26 +408,48 @@ func TestHandleKubePod(t *testing.T) {
 					HighCardTags: []string{},
 					OrchestratorCardTags: []string{
 						fmt.Sprintf("pod_name:%s", podName),
-						"oshift_deployment:gitlab-ce-1",
 					},
 					LowCardTags: []string{
 						fmt.Sprintf("kube_namespace:%s", podNamespace),
-						"oshift_deployment_config:gitlab-ce",
 					},
 					StandardTags: []string{},
 				},
+				{
+					Source: podSource,
+					Entity: ociVersionContainerTaggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_id:%s", ociVersionContainerID),
+						fmt.Sprintf("display_container_name:%s_%s", runtimeContainerName, podName),
+					},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						fmt.Sprintf("kube_container_name:%s", containerName),
+						"version:1.2.3",
+					},
+					StandardTags: []string{
+						"version:1.2.3",
+					},
+				},
 			},
 		},
 		{
-			name: "pod with admission + remote config annotations",
+			name: "pod with container, version label takes precedence over opencontainers image version",
 			pod: workloadmeta.KubernetesPod{
 				EntityID: podEntityID,
 				EntityMeta: workloadmeta.EntityMeta{
 					Name:      podName,
 					Namespace: podNamespace,
-					Annotations: map[string]string{
-						"admission.datadoghq.com/rc.id":  "id",
-						"admission.datadoghq.com/rc.rev": "123",
+					Labels: map[string]string{
+						"tags.datadoghq.com/agent.version": version,
+					},
+				},
+				Containers: []workloadmeta.OrchestratorContainer{
+					{
+						ID:   ociVersionContainerID,
+						Name: containerName,
 					},
 				},
 			},
@@ -358,24 +463,47 @@ func TestHandleKubePod(t *testing.T) {
 					},
 					LowCardTags: []string{
 						fmt.Sprintf("kube_namespace:%s", podNamespace),
-						"dd_remote_config_id:id",
-						"dd_remote_config_rev:123",
 					},
 					StandardTags: []string{},
 				},
+				{
+					Source: podSource,
+					Entity: ociVersionContainerTaggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_id:%s", ociVersionContainerID),
+						fmt.Sprintf("display_container_name:%s_%s", runtimeContainerName, podName),
+					},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						fmt.Sprintf("kube_container_name:%s", containerName),
+						fmt.Sprintf("version:%s", version),
+					},
+					StandardTags: []string{
+						fmt.Sprintf("version:%s", version),
+					},
+				},
 			},
 		},
 		{
-			name: "static tags",
-			staticTags: map[string]string{
-				"eks_fargate_node": "foobar",
-			},
+			name: "pod with container, namespace version takes precedence over opencontainers image version",
 			pod: workloadmeta.KubernetesPod{
 				EntityID: podEntityID,
 				EntityMeta: workloadmeta.EntityMeta{
 					Name:      podName,
 					Namespace: podNamespace,
 				},
+				NamespaceAnnotations: map[string]string{
+					"ad.datadoghq.com/cluster.version": version,
+				},
+				Containers: []workloadmeta.OrchestratorContainer{
+					{
+						ID:   ociVersionContainerID,
+						Name: containerName,
+					},
+				},
 			},
 			expected: []*TagInfo{
 				{
@@ -387,25 +515,47 @@ func TestHandleKubePod(t *testing.T) {
 					},
 					LowCardTags: []string{
 						fmt.Sprintf("kube_namespace:%s", podNamespace),
-						"eks_fargate_node:foobar",
+						fmt.Sprintf("version:%s", version),
+					},
+					StandardTags: []string{
+						fmt.Sprintf("version:%s", version),
+					},
+				},
+				{
+					Source: podSource,
+					Entity: ociVersionContainerTaggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_id:%s", ociVersionContainerID),
+						fmt.Sprintf("display_container_name:%s_%s", runtimeContainerName, podName),
+					},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						fmt.Sprintf("kube_container_name:%s", containerName),
+						fmt.Sprintf("version:%s", version),
+					},
+					StandardTags: []string{
+						fmt.Sprintf("version:%s", version),
 					},
-					StandardTags: []string{},
 				},
 			},
 		},
 		{
-			name: "disable kube_service",
+			name: "pod with ephemeral container",
 			pod: workloadmeta.KubernetesPod{
 				EntityID: podEntityID,
 				EntityMeta: workloadmeta.EntityMeta{
 					Name:      podName,
 					Namespace: podNamespace,
-					Annotations: map[string]string{
-						"tags.datadoghq.com/disable": "kube_service",
+				},
+				EphemeralContainers: []workloadmeta.OrchestratorContainer{
+					{
+						ID:   noEnvContainerID,
+						Name: "debugger",
 					},
 				},
-				// kube_service tags
-				KubeServices: []string{"service1", "service2"},
 			},
 			expected: []*TagInfo{
 				{
@@ -420,235 +570,2743 @@ func TestHandleKubePod(t *testing.T) {
 					},
 					StandardTags: []string{},
 				},
+				{
+					Source: podSource,
+					Entity: noEnvContainerTaggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_id:%s", noEnvContainerID),
+						fmt.Sprintf("display_container_name:%s_%s", runtimeContainerName, podName),
+						"kube_ephemeral_container:true",
+					},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_container_name:debugger",
+					},
+					StandardTags: []string{},
+				},
 			},
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			collector := &WorkloadMetaCollector{
-				store:      store,
-				children:   make(map[string]map[string]struct{}),
-				staticTags: tt.staticTags,
-			}
-
-			collector.initPodMetaAsTags(tt.labelsAsTags, tt.annotationsAsTags, tt.nsLabelsAsTags)
-
-			actual := collector.handleKubePod(workloadmeta.Event{
-				Type:   workloadmeta.EventTypeSet,
-				Entity: &tt.pod,
-			})
-
-			assertTagInfoListEqual(t, tt.expected, actual)
-		})
-	}
-}
-
-func TestHandleECSTask(t *testing.T) {
-	const (
-		containerID   = "foobarquux"
-		containerName = "agent"
-	)
-
-	entityID := workloadmeta.EntityID{
-		Kind: workloadmeta.KindECSTask,
-		ID:   "foobar",
-	}
-
-	taggerEntityID := fmt.Sprintf("container_id://%s", containerID)
-
-	store := workloadmetatesting.NewStore()
-	store.Set(&workloadmeta.Container{
-		EntityID: workloadmeta.EntityID{
-			Kind: workloadmeta.KindContainer,
-			ID:   containerID,
-		},
-		EntityMeta: workloadmeta.EntityMeta{
-			Name: containerName,
-		},
-	})
-
-	tests := []struct {
-		name     string
-		task     workloadmeta.ECSTask
-		expected []*TagInfo
-	}{
 		{
-			name: "basic ECS EC2 task",
-			task: workloadmeta.ECSTask{
-				EntityID: entityID,
+			name: "pod with init container",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
 				EntityMeta: workloadmeta.EntityMeta{
-					Name: "foobar",
-				},
-				Tags: map[string]string{
-					"aws:ecs:clusterName": "ecs-cluster",
-					"aws:ecs:serviceName": "datadog-agent",
-					"owner_team":          "container-integrations",
-				},
-				ContainerInstanceTags: map[string]string{
-					"instance_type": "g4dn.xlarge",
+					Name:      podName,
+					Namespace: podNamespace,
 				},
-				ClusterName: "ecs-cluster",
-				Family:      "datadog-agent",
-				Version:     "1",
-				LaunchType:  workloadmeta.ECSLaunchTypeEC2,
-				Containers: []workloadmeta.OrchestratorContainer{
+				InitContainers: []workloadmeta.OrchestratorContainer{
 					{
-						ID:   containerID,
-						Name: containerName,
+						ID:     noEnvContainerID,
+						Name:   "init",
+						IsInit: true,
 					},
 				},
 			},
 			expected: []*TagInfo{
 				{
-					Source:       taskSource,
-					Entity:       taggerEntityID,
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
 					HighCardTags: []string{},
 					OrchestratorCardTags: []string{
-						"task_arn:foobar",
+						fmt.Sprintf("pod_name:%s", podName),
 					},
 					LowCardTags: []string{
-						"cluster_name:ecs-cluster",
-						"ecs_cluster_name:ecs-cluster",
-						"ecs_container_name:agent",
-						"instance_type:g4dn.xlarge",
-						"owner_team:container-integrations",
-						"task_family:datadog-agent",
-						"task_name:datadog-agent",
-						"task_version:1",
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+				{
+					Source: podSource,
+					Entity: noEnvContainerTaggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_id:%s", noEnvContainerID),
+						fmt.Sprintf("display_container_name:%s_%s", runtimeContainerName, podName),
+					},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_container_name:init",
+						"kube_init_container:true",
 					},
 					StandardTags: []string{},
 				},
 			},
 		},
 		{
-			name: "basic ECS Fargate task",
-			task: workloadmeta.ECSTask{
-				EntityID: entityID,
+			name: "pod with container terminated by a signal",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
 				EntityMeta: workloadmeta.EntityMeta{
-					Name: "foobar",
+					Name:      podName,
+					Namespace: podNamespace,
 				},
-				ClusterName: "ecs-cluster",
-				Family:      "datadog-agent",
-				Version:     "1",
-				LaunchType:  workloadmeta.ECSLaunchTypeFargate,
 				Containers: []workloadmeta.OrchestratorContainer{
 					{
-						ID:   containerID,
-						Name: containerName,
+						ID:           noEnvContainerID,
+						Name:         containerName,
+						LastExitCode: pointer.Ptr(int32(137)),
 					},
 				},
-				AvailabilityZone: "us-east-1c",
 			},
 			expected: []*TagInfo{
 				{
-					Source:       taskSource,
-					Entity:       taggerEntityID,
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
 					HighCardTags: []string{},
 					OrchestratorCardTags: []string{
-						"task_arn:foobar",
+						fmt.Sprintf("pod_name:%s", podName),
 					},
 					LowCardTags: []string{
-						"cluster_name:ecs-cluster",
-						"ecs_cluster_name:ecs-cluster",
-						"ecs_container_name:agent",
-						"task_family:datadog-agent",
-						"task_name:datadog-agent",
-						"task_version:1",
-						"availability_zone:us-east-1c",
-						"availability-zone:us-east-1c",
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
 					},
 					StandardTags: []string{},
 				},
 				{
-					Source:       taskSource,
-					Entity:       GlobalEntityID,
+					Source: podSource,
+					Entity: noEnvContainerTaggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_id:%s", noEnvContainerID),
+						fmt.Sprintf("display_container_name:%s_%s", runtimeContainerName, podName),
+						"kube_container_exit_code:137",
+					},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						fmt.Sprintf("kube_container_name:%s", containerName),
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with container that exited successfully",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				Containers: []workloadmeta.OrchestratorContainer{
+					{
+						ID:           noEnvContainerID,
+						Name:         containerName,
+						LastExitCode: pointer.Ptr(int32(0)),
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
 					HighCardTags: []string{},
 					OrchestratorCardTags: []string{
-						"task_arn:foobar",
+						fmt.Sprintf("pod_name:%s", podName),
 					},
 					LowCardTags: []string{
-						"cluster_name:ecs-cluster",
-						"ecs_cluster_name:ecs-cluster",
-						"task_family:datadog-agent",
-						"task_name:datadog-agent",
-						"task_version:1",
-						"availability_zone:us-east-1c",
-						"availability-zone:us-east-1c",
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+				{
+					Source: podSource,
+					Entity: noEnvContainerTaggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_id:%s", noEnvContainerID),
+						fmt.Sprintf("display_container_name:%s_%s", runtimeContainerName, podName),
+						"kube_container_exit_code:0",
+					},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						fmt.Sprintf("kube_container_name:%s", containerName),
 					},
 					StandardTags: []string{},
 				},
 			},
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			collector := &WorkloadMetaCollector{
-				store:                  store,
-				children:               make(map[string]map[string]struct{}),
-				collectEC2ResourceTags: true,
-			}
-
-			actual := collector.handleECSTask(workloadmeta.Event{
-				Type:   workloadmeta.EventTypeSet,
-				Entity: &tt.task,
-			})
-
-			assertTagInfoListEqual(t, tt.expected, actual)
-		})
-	}
-}
-
-func TestHandleContainer(t *testing.T) {
-	const (
-		containerName = "foobar"
-		podNamespace  = "default"
-		env           = "production"
-		svc           = "datadog-agent"
-		version       = "7.32.0"
-	)
-
-	standardTags := []string{
-		fmt.Sprintf("env:%s", env),
-		fmt.Sprintf("service:%s", svc),
-		fmt.Sprintf("version:%s", version),
-	}
-
-	entityID := workloadmeta.EntityID{
-		Kind: workloadmeta.KindContainer,
-		ID:   "foobar",
-	}
-
-	taggerEntityID := fmt.Sprintf("container_id://%s", entityID.ID)
-
-	tests := []struct {
-		name         string
-		staticTags   map[string]string
-		labelsAsTags map[string]string
-		envAsTags    map[string]string
-		container    workloadmeta.Container
-		expected     []*TagInfo
-	}{
 		{
-			name: "fully formed container",
-			container: workloadmeta.Container{
-				EntityID: entityID,
+			name: "pod with Always image pull policy",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
 				EntityMeta: workloadmeta.EntityMeta{
-					Name: containerName,
-					Labels: map[string]string{
-						"com.datadoghq.tags.env":     env,
-						"com.datadoghq.tags.service": svc,
-						"com.datadoghq.tags.version": version,
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				Containers: []workloadmeta.OrchestratorContainer{
+					{
+						ID:              noEnvContainerID,
+						Name:            containerName,
+						ImagePullPolicy: "Always",
 					},
 				},
-				Runtime: workloadmeta.ContainerRuntimeDocker,
-				Image: workloadmeta.ContainerImage{
-					ID:        "datadog/agent@sha256:a63d3f66fb2f69d955d4f2ca0b229385537a77872ffc04290acae65aed5317d2",
-					RawName:   "datadog/agent@sha256:a63d3f66fb2f69d955d4f2ca0b229385537a77872ffc04290acae65aed5317d2",
-					Name:      "datadog/agent",
-					ShortName: "agent",
-					Tag:       "latest",
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+				{
+					Source: podSource,
+					Entity: noEnvContainerTaggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_id:%s", noEnvContainerID),
+						fmt.Sprintf("display_container_name:%s_%s", runtimeContainerName, podName),
+					},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						fmt.Sprintf("kube_container_name:%s", containerName),
+						"kube_image_pull_policy:Always",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with IfNotPresent image pull policy",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				Containers: []workloadmeta.OrchestratorContainer{
+					{
+						ID:              noEnvContainerID,
+						Name:            containerName,
+						ImagePullPolicy: "IfNotPresent",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+				{
+					Source: podSource,
+					Entity: noEnvContainerTaggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_id:%s", noEnvContainerID),
+						fmt.Sprintf("display_container_name:%s_%s", runtimeContainerName, podName),
+					},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						fmt.Sprintf("kube_container_name:%s", containerName),
+						"kube_image_pull_policy:IfNotPresent",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with Never image pull policy",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				Containers: []workloadmeta.OrchestratorContainer{
+					{
+						ID:              noEnvContainerID,
+						Name:            containerName,
+						ImagePullPolicy: "Never",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+				{
+					Source: podSource,
+					Entity: noEnvContainerTaggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_id:%s", noEnvContainerID),
+						fmt.Sprintf("display_container_name:%s_%s", runtimeContainerName, podName),
+					},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						fmt.Sprintf("kube_container_name:%s", containerName),
+						"kube_image_pull_policy:Never",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod from statefulset",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				Owners: []workloadmeta.KubernetesPodOwner{
+					{
+						Kind: kubernetes.StatefulSetKind,
+						Name: "redis",
+					},
+				},
+				PersistentVolumeClaimNames: []string{"data-redis-0"},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+						"kube_ownerref_name:redis",
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_ownerref_kind:statefulset",
+						"kube_stateful_set:redis",
+						"persistentvolumeclaim:data-redis-0",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod from job owned by cronjob",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				Owners: []workloadmeta.KubernetesPodOwner{
+					{
+						Kind: kubernetes.JobKind,
+						Name: "backup-manual",
+						ID:   "manual-job-uid",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+						"kube_ownerref_name:backup-manual",
+						"kube_job:backup-manual",
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_ownerref_kind:job",
+						"kube_cronjob:backup",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod from standalone job",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				Owners: []workloadmeta.KubernetesPodOwner{
+					{
+						Kind: kubernetes.JobKind,
+						Name: "migrate-1234",
+						ID:   "standalone-job-uid",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+						"kube_ownerref_name:migrate-1234",
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_ownerref_kind:job",
+						"kube_job:migrate-1234",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod from unknown job",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				Owners: []workloadmeta.KubernetesPodOwner{
+					{
+						Kind: kubernetes.JobKind,
+						Name: "hello-28245420",
+						ID:   "unknown-job-uid",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+						"kube_ownerref_name:hello-28245420",
+						"kube_job:hello-28245420",
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_ownerref_kind:job",
+						"kube_cronjob:hello",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with http liveness probe",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				Containers: []workloadmeta.OrchestratorContainer{
+					{
+						ID:                noEnvContainerID,
+						Name:              containerName,
+						LivenessProbeType: "http",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+				{
+					Source: podSource,
+					Entity: noEnvContainerTaggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_id:%s", noEnvContainerID),
+						fmt.Sprintf("display_container_name:%s_%s", runtimeContainerName, podName),
+					},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						fmt.Sprintf("kube_container_name:%s", containerName),
+						"kube_liveness_probe_type:http",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with container resources",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				Containers: []workloadmeta.OrchestratorContainer{
+					{
+						ID:   noEnvContainerID,
+						Name: containerName,
+						Resources: workloadmeta.OrchestratorContainerResources{
+							CPURequest:    "250m",
+							CPULimit:      "1",
+							MemoryRequest: "128Mi",
+							MemoryLimit:   "256Mi",
+						},
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+				{
+					Source: podSource,
+					Entity: noEnvContainerTaggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_id:%s", noEnvContainerID),
+						fmt.Sprintf("display_container_name:%s_%s", runtimeContainerName, podName),
+					},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						fmt.Sprintf("kube_container_name:%s", containerName),
+						"kube_cpu_request:250m",
+						"kube_cpu_limit:1",
+						"kube_memory_request:128Mi",
+						"kube_memory_limit:256Mi",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with Guaranteed QoS class",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				QOSClass: "Guaranteed",
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_qos:Guaranteed",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with Burstable QoS class",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				QOSClass: "Burstable",
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_qos:Burstable",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with BestEffort QoS class",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				QOSClass: "BestEffort",
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_qos:BestEffort",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod without QoS class",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "scheduled pod",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				ScheduledAt: time.Date(2023, time.October, 2, 12, 30, 0, 0, time.UTC),
+			},
+			expected: []*TagInfo{
+				{
+					Source: podSource,
+					Entity: podTaggerEntityID,
+					HighCardTags: []string{
+						"pod_scheduled_at:1696249800",
+					},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name:             "pod on virtual node",
+			virtualNodeLabel: "eks.amazonaws.com/compute-type",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				NodeName: "node-1",
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_virtual_node:fargate",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name:             "pod on node without virtual node label",
+			virtualNodeLabel: "kubernetes.azure.com/os-sku",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				NodeName: "node-1",
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod on virtual node without virtual node label configured",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				NodeName: "node-1",
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "started pod",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				StartTime: pointer.Ptr(time.Date(2023, time.October, 2, 12, 30, 5, 0, time.UTC)),
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+						"pod_start_time:1696249805",
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name:                "pod conditions as tags",
+			podConditionsAsTags: []string{"PodScheduled", "ContainersReady", "Initialized"},
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				Conditions: map[string]bool{
+					"PodScheduled":    true,
+					"ContainersReady": false,
+					"Ready":           false,
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_condition_pod_scheduled:true",
+						"kube_condition_containers_ready:false",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod conditions not configured as tags",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				Conditions: map[string]bool{
+					"PodScheduled": true,
+					"Ready":        true,
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name:                 "pod with node conditions",
+			nodeConditionsAsTags: true,
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				NodeName: "node-1",
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_node_ready:true",
+						"kube_node_disk_pressure:false",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with node conditions disabled",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				NodeName: "node-1",
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name:                 "pod with node conditions on unknown node",
+			nodeConditionsAsTags: true,
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				NodeName: "node-2",
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod preempting another pod",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				Phase:             "Pending",
+				NominatedNodeName: "node-1",
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+						"kube_nominated_node:node-1",
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"pod_phase:pending",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with tolerations",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				TolerationKeys: []string{
+					"dedicated",
+					"node.kubernetes.io/not-ready",
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_toleration:dedicated",
+						"kube_toleration:node.kubernetes.io/not-ready",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod from helm release",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+					Annotations: map[string]string{
+						"meta.helm.sh/release-name":      "datadog",
+						"meta.helm.sh/release-namespace": "monitoring",
+					},
+					Labels: map[string]string{
+						"app.kubernetes.io/managed-by": "Helm",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_app_managed_by:Helm",
+						"helm_release:datadog",
+						"helm_release_namespace:monitoring",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod from helm release without namespace annotation",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+					Annotations: map[string]string{
+						"meta.helm.sh/release-name": "datadog",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"helm_release:datadog",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with standard tags from namespace annotations",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				NamespaceAnnotations: map[string]string{
+					"ad.datadoghq.com/cluster.env":     env,
+					"ad.datadoghq.com/cluster.service": svc,
+					"ad.datadoghq.com/cluster.version": version,
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: append([]string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					}, standardTags...),
+					StandardTags: standardTags,
+				},
+			},
+		},
+		{
+			name: "pod with standard tags from namespace annotations overridden by pod labels",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+					Labels: map[string]string{
+						"tags.datadoghq.com/env": "staging",
+					},
+				},
+				NamespaceAnnotations: map[string]string{
+					"ad.datadoghq.com/cluster.env":     env,
+					"ad.datadoghq.com/cluster.service": svc,
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"env:staging",
+						fmt.Sprintf("service:%s", svc),
+					},
+					StandardTags: []string{
+						"env:staging",
+						fmt.Sprintf("service:%s", svc),
+					},
+				},
+			},
+		},
+		{
+			name: "pod with value templated annotations as tags",
+			annotationsAsTags: map[string]string{
+				"owner.team":  "team_%%value%%",
+				"owner.email": "+owner",
+				"tier":        "+tier_%%value%%",
+			},
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+					Annotations: map[string]string{
+						"owner.team":  "platform",
+						"owner.email": "platform@example.com",
+						"tier":        "backend",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: podSource,
+					Entity: podTaggerEntityID,
+					HighCardTags: []string{
+						"owner:platform@example.com",
+						"tier_backend",
+					},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"team_platform",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod from openshift deployment",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+					Annotations: map[string]string{
+						"openshift.io/deployment-config.latest-version": "1",
+						"openshift.io/deployment-config.name":           "gitlab-ce",
+						"openshift.io/deployment.name":                  "gitlab-ce-1",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+						"oshift_deployment:gitlab-ce-1",
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"oshift_deployment_config:gitlab-ce",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with admission + remote config annotations",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+					Annotations: map[string]string{
+						"admission.datadoghq.com/rc.id":  "id",
+						"admission.datadoghq.com/rc.rev": "123",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"dd_remote_config_id:id",
+						"dd_remote_config_rev:123",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with pod security policy annotation",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+					Annotations: map[string]string{
+						"kubernetes.io/psp": "eks.privileged",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_pod_security_policy:eks.privileged",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with pod security policy annotation already mapped as tag",
+			annotationsAsTags: map[string]string{
+				"kubernetes.io/psp": "psp",
+			},
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+					Annotations: map[string]string{
+						"kubernetes.io/psp": "eks.privileged",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"psp:eks.privileged",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with priority class",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				PriorityClass: "system-node-critical",
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_priority_class:system-node-critical",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod without priority class",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				PriorityClass: "",
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with configmap labels as tags",
+			configMapLabelsAsTags: map[string]string{
+				"team": "owner_team",
+			},
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				ConfigMapNames: []string{"agent-config", "missing-config"},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"owner_team:container-integrations",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with configmap, configmap labels as tags disabled",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				ConfigMapNames: []string{"agent-config"},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with runtime class",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				RuntimeClass: "gvisor",
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_runtime_class:gvisor",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod without runtime class",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name:              "pod with secrets, secret names as tags enabled",
+			secretNamesAsTags: true,
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				SecretNames: []string{"env-secret", "tls-secret"},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_secret:env-secret",
+						"kube_secret:tls-secret",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod with secrets, secret names as tags disabled",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				SecretNames: []string{"env-secret", "tls-secret"},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod spread across zones",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				NodeName:           "node-1",
+				TopologySpreadKeys: []string{"topology.kubernetes.io/zone"},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"kube_topology_zone:us-east-1a",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod spread across hosts",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				NodeName:           "node-1",
+				TopologySpreadKeys: []string{"kubernetes.io/hostname"},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "pod spread across zones on unknown node",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+				NodeName:           "node-2",
+				TopologySpreadKeys: []string{"topology.kubernetes.io/zone"},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "static tags",
+			staticTags: map[string]string{
+				"eks_fargate_node": "foobar",
+			},
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+						"eks_fargate_node:foobar",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "disable kube_service",
+			pod: workloadmeta.KubernetesPod{
+				EntityID: podEntityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      podName,
+					Namespace: podNamespace,
+					Annotations: map[string]string{
+						"tags.datadoghq.com/disable": "kube_service",
+					},
+				},
+				// kube_service tags
+				KubeServices: []string{"service1", "service2"},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       podSource,
+					Entity:       podTaggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						fmt.Sprintf("pod_name:%s", podName),
+					},
+					LowCardTags: []string{
+						fmt.Sprintf("kube_namespace:%s", podNamespace),
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := &WorkloadMetaCollector{
+				store:                    store,
+				children:                 make(map[string]map[string]struct{}),
+				staticTags:               tt.staticTags,
+				collectSecretNamesAsTags: tt.secretNamesAsTags,
+				virtualNodeLabel:         tt.virtualNodeLabel,
+				collectNodeConditions:    tt.nodeConditionsAsTags,
+			}
+
+			collector.initPodMetaAsTags(tt.labelsAsTags, tt.annotationsAsTags, tt.nsLabelsAsTags)
+			collector.initConfigMapMetaAsTags(tt.configMapLabelsAsTags)
+			collector.initPodConditionsAsTags(tt.podConditionsAsTags)
+
+			actual := collector.handleKubePod(workloadmeta.Event{
+				Type:   workloadmeta.EventTypeSet,
+				Entity: &tt.pod,
+			})
+
+			assertTagInfoListEqual(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestHandleECSTask(t *testing.T) {
+	const (
+		containerID   = "foobarquux"
+		containerName = "agent"
+	)
+
+	entityID := workloadmeta.EntityID{
+		Kind: workloadmeta.KindECSTask,
+		ID:   "foobar",
+	}
+
+	taggerEntityID := fmt.Sprintf("container_id://%s", containerID)
+
+	store := workloadmetatesting.NewStore()
+	store.Set(&workloadmeta.Container{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindContainer,
+			ID:   containerID,
+		},
+		EntityMeta: workloadmeta.EntityMeta{
+			Name: containerName,
+		},
+	})
+
+	tests := []struct {
+		name     string
+		task     workloadmeta.ECSTask
+		expected []*TagInfo
+	}{
+		{
+			name: "basic ECS EC2 task",
+			task: workloadmeta.ECSTask{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: "foobar",
+				},
+				Tags: map[string]string{
+					"aws:ecs:clusterName": "ecs-cluster",
+					"aws:ecs:serviceName": "datadog-agent",
+					"owner_team":          "container-integrations",
+				},
+				ContainerInstanceTags: map[string]string{
+					"instance_type": "g4dn.xlarge",
+				},
+				ClusterName: "ecs-cluster",
+				Family:      "datadog-agent",
+				Version:     "1",
+				LaunchType:  workloadmeta.ECSLaunchTypeEC2,
+				Containers: []workloadmeta.OrchestratorContainer{
+					{
+						ID:   containerID,
+						Name: containerName,
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       taskSource,
+					Entity:       taggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						"task_arn:foobar",
+					},
+					LowCardTags: []string{
+						"cluster_name:ecs-cluster",
+						"ecs_cluster_name:ecs-cluster",
+						"ecs_container_name:agent",
+						"instance_type:g4dn.xlarge",
+						"owner_team:container-integrations",
+						"task_family:datadog-agent",
+						"task_name:datadog-agent",
+						"task_version:1",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "basic ECS Fargate task",
+			task: workloadmeta.ECSTask{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: "foobar",
+				},
+				ClusterName: "ecs-cluster",
+				Family:      "datadog-agent",
+				Version:     "1",
+				LaunchType:  workloadmeta.ECSLaunchTypeFargate,
+				Containers: []workloadmeta.OrchestratorContainer{
+					{
+						ID:   containerID,
+						Name: containerName,
+					},
+				},
+				AvailabilityZone: "us-east-1c",
+			},
+			expected: []*TagInfo{
+				{
+					Source:       taskSource,
+					Entity:       taggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						"task_arn:foobar",
+					},
+					LowCardTags: []string{
+						"cluster_name:ecs-cluster",
+						"ecs_cluster_name:ecs-cluster",
+						"ecs_container_name:agent",
+						"task_family:datadog-agent",
+						"task_name:datadog-agent",
+						"task_version:1",
+						"availability_zone:us-east-1c",
+						"availability-zone:us-east-1c",
+					},
+					StandardTags: []string{},
+				},
+				{
+					Source:       taskSource,
+					Entity:       GlobalEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						"task_arn:foobar",
+					},
+					LowCardTags: []string{
+						"cluster_name:ecs-cluster",
+						"ecs_cluster_name:ecs-cluster",
+						"task_family:datadog-agent",
+						"task_name:datadog-agent",
+						"task_version:1",
+						"availability_zone:us-east-1c",
+						"availability-zone:us-east-1c",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "started ECS EC2 task",
+			task: workloadmeta.ECSTask{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: "foobar",
+				},
+				Family:     "datadog-agent",
+				Version:    "1",
+				LaunchType: workloadmeta.ECSLaunchTypeEC2,
+				StartedAt:  pointer.Ptr(time.Date(2023, time.October, 2, 12, 30, 0, 0, time.UTC)),
+				Containers: []workloadmeta.OrchestratorContainer{
+					{
+						ID:   containerID,
+						Name: containerName,
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       taskSource,
+					Entity:       taggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						"task_arn:foobar",
+						"task_started_at:1696249800",
+					},
+					LowCardTags: []string{
+						"ecs_container_name:agent",
+						"task_family:datadog-agent",
+						"task_name:datadog-agent",
+						"task_version:1",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "stopping ECS Fargate task",
+			task: workloadmeta.ECSTask{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: "foobar",
+				},
+				Family:        "datadog-agent",
+				Version:       "1",
+				LaunchType:    workloadmeta.ECSLaunchTypeFargate,
+				KnownStatus:   "RUNNING",
+				StopCode:      "EssentialContainerExited",
+				StoppedReason: "Essential container in task exited",
+				Containers: []workloadmeta.OrchestratorContainer{
+					{
+						ID:   containerID,
+						Name: containerName,
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       taskSource,
+					Entity:       taggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						"task_arn:foobar",
+					},
+					LowCardTags: []string{
+						"ecs_container_name:agent",
+						"task_family:datadog-agent",
+						"task_name:datadog-agent",
+						"task_version:1",
+					},
+					StandardTags: []string{},
+				},
+				{
+					Source:       taskSource,
+					Entity:       GlobalEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						"task_arn:foobar",
+					},
+					LowCardTags: []string{
+						"task_family:datadog-agent",
+						"task_name:datadog-agent",
+						"task_version:1",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "stopped ECS Fargate task",
+			task: workloadmeta.ECSTask{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: "foobar",
+				},
+				Family:        "datadog-agent",
+				Version:       "1",
+				LaunchType:    workloadmeta.ECSLaunchTypeFargate,
+				KnownStatus:   "STOPPED",
+				StopCode:      "EssentialContainerExited",
+				StoppedReason: "Essential container in task exited",
+				Containers: []workloadmeta.OrchestratorContainer{
+					{
+						ID:   containerID,
+						Name: containerName,
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: taskSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						"ecs_task_stop_code:EssentialContainerExited",
+					},
+					OrchestratorCardTags: []string{
+						"task_arn:foobar",
+					},
+					LowCardTags: []string{
+						"ecs_container_name:agent",
+						"task_family:datadog-agent",
+						"task_name:datadog-agent",
+						"task_version:1",
+					},
+					StandardTags: []string{},
+				},
+				{
+					Source: taskSource,
+					Entity: GlobalEntityID,
+					HighCardTags: []string{
+						"ecs_task_stop_code:EssentialContainerExited",
+					},
+					OrchestratorCardTags: []string{
+						"task_arn:foobar",
+					},
+					LowCardTags: []string{
+						"task_family:datadog-agent",
+						"task_name:datadog-agent",
+						"task_version:1",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collector := &WorkloadMetaCollector{
+				store:                  store,
+				children:               make(map[string]map[string]struct{}),
+				collectEC2ResourceTags: true,
+			}
+
+			actual := collector.handleECSTask(workloadmeta.Event{
+				Type:   workloadmeta.EventTypeSet,
+				Entity: &tt.task,
+			})
+
+			assertTagInfoListEqual(t, tt.expected, actual)
+		})
+	}
+}
+
+func TestHandleContainer(t *testing.T) {
+	const (
+		containerName = "foobar"
+		podNamespace  = "default"
+		env           = "production"
+		svc           = "datadog-agent"
+		version       = "7.32.0"
+	)
+
+	standardTags := []string{
+		fmt.Sprintf("env:%s", env),
+		fmt.Sprintf("service:%s", svc),
+		fmt.Sprintf("version:%s", version),
+	}
+
+	entityID := workloadmeta.EntityID{
+		Kind: workloadmeta.KindContainer,
+		ID:   "foobar",
+	}
+
+	taggerEntityID := fmt.Sprintf("container_id://%s", entityID.ID)
+
+	tests := []struct {
+		name         string
+		staticTags   map[string]string
+		labelsAsTags map[string]string
+		envAsTags    map[string]string
+		container    workloadmeta.Container
+		expected     []*TagInfo
+	}{
+		{
+			name: "fully formed container",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+					Labels: map[string]string{
+						"com.datadoghq.tags.env":     env,
+						"com.datadoghq.tags.service": svc,
+						"com.datadoghq.tags.version": version,
+					},
+				},
+				Runtime: workloadmeta.ContainerRuntimeDocker,
+				Image: workloadmeta.ContainerImage{
+					ID:        "datadog/agent@sha256:a63d3f66fb2f69d955d4f2ca0b229385537a77872ffc04290acae65aed5317d2",
+					RawName:   "datadog/agent@sha256:a63d3f66fb2f69d955d4f2ca0b229385537a77872ffc04290acae65aed5317d2",
+					Name:      "datadog/agent",
+					ShortName: "agent",
+					Tag:       "latest",
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags: append([]string{
+						"container_runtime:docker",
+						"docker_image:datadog/agent:latest",
+						"image_name:datadog/agent",
+						"image_tag:latest",
+						"short_image:agent",
+						"image_id:datadog/agent@sha256:a63d3f66fb2f69d955d4f2ca0b229385537a77872ffc04290acae65aed5317d2",
+					}, standardTags...),
+					StandardTags: standardTags,
+				},
+			},
+		},
+		{
+			name: "tags from environment",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+				},
+				EnvVars: map[string]string{
+					// env as tags
+					"TEAM": "container-integrations",
+					"TIER": "node",
+
+					// standard tags
+					"DD_ENV":     env,
+					"DD_SERVICE": svc,
+					"DD_VERSION": version,
+				},
+			},
+			envAsTags: map[string]string{
+				"team": "owner_team",
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags: append([]string{
+						"owner_team:container-integrations",
+					}, standardTags...),
+					StandardTags: standardTags,
+				},
+			},
+		},
+		{
+			name: "tags from labels",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+					Labels: map[string]string{
+						// labels as tags
+						"team": "container-integrations",
+						"tier": "node",
+
+						// custom tags from label
+						"com.datadoghq.ad.tags": `["app_name:datadog-agent"]`,
+					},
+				},
+			},
+			labelsAsTags: map[string]string{
+				"team": "owner_team",
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+						"app_name:datadog-agent",
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags: []string{
+						"owner_team:container-integrations",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "tags from labels and envs with prefix (using *)",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+					Labels: map[string]string{
+						"team": "container-integrations",
+					},
+				},
+				EnvVars: map[string]string{
+					"some_env": "some_env_val",
+				},
+			},
+			labelsAsTags: map[string]string{
+				"*": "custom_label_prefix_%%label%%",
+			},
+			envAsTags: map[string]string{
+				"*": "custom_env_prefix_%%env%%",
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags: []string{
+						// Notice that the names include the custom prefixes
+						// added in labelsAsTags and envAsTags.
+						"custom_label_prefix_team:container-integrations",
+						"custom_env_prefix_some_env:some_env_val",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "tags from labels with overlapping prefix wildcards",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+					Labels: map[string]string{
+						"team":         "container-integrations",
+						"app.name":     "agent",
+						"app.dd.owner": "containers",
+					},
+				},
+			},
+			labelsAsTags: map[string]string{
+				"*":        "custom_%%label%%",
+				"app.*":    "app_%%label%%",
+				"app.dd.*": "dd_%%label%%",
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags: []string{
+						"custom_team:container-integrations",
+						"app_app.name:agent",
+						"dd_app.dd.owner:containers",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "docker container with image that has no tag",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+				},
+				Runtime: workloadmeta.ContainerRuntimeDocker,
+				Image: workloadmeta.ContainerImage{
+					RawName:   "redis",
+					Name:      "redis",
+					ShortName: "redis",
+					Tag:       "",
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags: []string{
+						"container_runtime:docker",
+						"docker_image:redis", // Notice that there's no tag
+						"image_name:redis",
+						"short_image:redis",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "docker container runtime",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+				},
+				Runtime: workloadmeta.ContainerRuntimeDocker,
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags: []string{
+						"container_runtime:docker",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "containerd container runtime",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+				},
+				Runtime: workloadmeta.ContainerRuntimeContainerd,
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags: []string{
+						"container_runtime:containerd",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "unknown container runtime",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags:          []string{},
+					StandardTags:         []string{},
+				},
+			},
+		},
+		{
+			name: "garden container",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+				},
+				Runtime: workloadmeta.ContainerRuntimeGarden,
+				CollectorTags: []string{
+					"container_name:app-instance-guid",
+					"app_instance_guid:app-instance-guid",
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						"container_name:app-instance-guid",
+						"app_instance_guid:app-instance-guid",
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags:          []string{},
+					StandardTags:         []string{},
+				},
+			},
+		},
+		{
+			name: "garden container with vcap application",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+				},
+				Runtime: workloadmeta.ContainerRuntimeGarden,
+				EnvVars: map[string]string{
+					"CF_INSTANCE_INDEX": "2",
+					"CF_INSTANCE_GUID":  "app-instance-guid",
+					"VCAP_APPLICATION": `{"application_id":"app-id","application_name":"my-app",` +
+						`"space_id":"space-id","space_name":"my-space",` +
+						`"organization_id":"org-id","organization_name":"my-org","instance_index":2}`,
+				},
+				CollectorTags: []string{
+					"app_instance_guid:app-instance-guid",
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						"app_instance_guid:app-instance-guid",
+					},
+					OrchestratorCardTags: []string{
+						"cf_instance_index:2",
+					},
+					LowCardTags: []string{
+						"cf_app_id:app-id",
+						"cf_app_name:my-app",
+						"cf_space_id:space-id",
+						"cf_space_name:my-space",
+						"cf_org_id:org-id",
+						"cf_org_name:my-org",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "garden container with partial vcap application",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+				},
+				Runtime: workloadmeta.ContainerRuntimeGarden,
+				EnvVars: map[string]string{
+					"VCAP_APPLICATION": `{"application_id":"app-id","application_name":"my-app"}`,
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:               containerSource,
+					Entity:               taggerEntityID,
+					HighCardTags:         []string{},
+					OrchestratorCardTags: []string{},
+					LowCardTags: []string{
+						"cf_app_id:app-id",
+						"cf_app_name:my-app",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "garden container with invalid vcap application",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+				},
+				Runtime: workloadmeta.ContainerRuntimeGarden,
+				EnvVars: map[string]string{
+					"CF_INSTANCE_INDEX": "0",
+					"VCAP_APPLICATION":  `{"application_id":`,
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source:       containerSource,
+					Entity:       taggerEntityID,
+					HighCardTags: []string{},
+					OrchestratorCardTags: []string{
+						"cf_instance_index:0",
+					},
+					LowCardTags:  []string{},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "nomad container",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+				},
+				EnvVars: map[string]string{
+					"NOMAD_TASK_NAME":  "test-task",
+					"NOMAD_JOB_NAME":   "test-job",
+					"NOMAD_GROUP_NAME": "test-group",
+					"NOMAD_NAMESPACE":  "test-namespace",
+					"NOMAD_DC":         "test-dc",
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags: []string{
+						"nomad_task:test-task",
+						"nomad_job:test-job",
+						"nomad_group:test-group",
+						"nomad_namespace:test-namespace",
+						"nomad_dc:test-dc",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "cloud run container",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+				},
+				EnvVars: map[string]string{
+					"K_SERVICE":       "test-service",
+					"K_REVISION":      "test-service-00001-abc",
+					"K_CONFIGURATION": "test-service",
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags: []string{
+						"cloud_run_service:test-service",
+						"cloud_run_revision:test-service-00001-abc",
+						"cloud_run_configuration:test-service",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "cloud run container with partial env",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+				},
+				EnvVars: map[string]string{
+					"K_SERVICE": "test-service",
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags: []string{
+						"cloud_run_service:test-service",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "container without cloud run env",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+				},
+				EnvVars: map[string]string{
+					"PORT": "8080",
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags:          []string{},
+					StandardTags:         []string{},
+				},
+			},
+		},
+		{
+			name: "azure container instances container",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+				},
+				EnvVars: map[string]string{
+					"ACI_CONTAINER_GROUP": "test-group",
+					"ACI_CONTAINER_NAME":  "test-container",
+					"WEBSITE_SITE_NAME":   "test-app",
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags: []string{
+						"aci_container_group:test-group",
+						"aci_container_name:test-container",
+						"azure_app_name:test-app",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "mesos dc/os container",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+				},
+				EnvVars: map[string]string{
+					"MARATHON_APP_ID":   "/system/dd-agent",
+					"CHRONOS_JOB_NAME":  "app1_process-orders",
+					"CHRONOS_JOB_OWNER": "qa",
+					"MESOS_TASK_ID":     "system_dd-agent.dcc75b42-4b87-11e7-9a62-70b3d5800001",
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{
+						"mesos_task:system_dd-agent.dcc75b42-4b87-11e7-9a62-70b3d5800001",
+					},
+					LowCardTags: []string{
+						"chronos_job:app1_process-orders",
+						"chronos_job_owner:qa",
+						"marathon_app:/system/dd-agent",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "rancher container",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+					Labels: map[string]string{
+						"io.rancher.cni.network":             "ipsec",
+						"io.rancher.cni.wait":                "true",
+						"io.rancher.container.ip":            "10.42.234.7/16",
+						"io.rancher.container.mac_address":   "02:f1:dd:48:4c:d9",
+						"io.rancher.container.name":          "testAD-redis-1",
+						"io.rancher.container.pull_image":    "always",
+						"io.rancher.container.uuid":          "8e969193-2bc7-4a58-9a54-9eed44b01bb2",
+						"io.rancher.environment.uuid":        "adminProject",
+						"io.rancher.project.name":            "testAD",
+						"io.rancher.project_service.name":    "testAD/redis",
+						"io.rancher.service.deployment.unit": "06c082fc-4b66-4b6c-b098-30dbf29ed204",
+						"io.rancher.service.launch.config":   "io.rancher.service.primary.launch.config",
+						"io.rancher.stack.name":              "testAD",
+						"io.rancher.stack_service.name":      "testAD/redis",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+						"rancher_container:testAD-redis-1",
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags: []string{
+						"rancher_service:testAD/redis",
+						"rancher_stack:testAD",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "docker swarm container",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+					Labels: map[string]string{
+						"com.docker.swarm.node.id":      "zdtab51ei97djzrpa1y2tz8li",
+						"com.docker.swarm.service.id":   "tef96xrdmlj82c7nt57jdntl8",
+						"com.docker.swarm.service.name": "helloworld",
+						"com.docker.swarm.task":         "",
+						"com.docker.swarm.task.id":      "knk1rz1szius7pvyznn9zolld",
+						"com.docker.swarm.task.name":    "helloworld.1.knk1rz1szius7pvyznn9zolld",
+						"com.docker.stack.namespace":    "default",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags: []string{
+						"swarm_namespace:default",
+						"swarm_service:helloworld",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "opencontainers image revision and source",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+					Labels: map[string]string{
+						"org.opencontainers.image.revision": "758691a28aa920070651d360814c559bc26af907",
+						"org.opencontainers.image.source":   "https://github.com/my-company/repo",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags: []string{
+						"git.commit.sha:758691a28aa920070651d360814c559bc26af907",
+						"git.repository_url:https://github.com/my-company/repo",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "opencontainers image source only",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+					Labels: map[string]string{
+						"org.opencontainers.image.source": "https://github.com/my-company/repo",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags: []string{
+						"git.repository_url:https://github.com/my-company/repo",
+					},
+					StandardTags: []string{},
+				},
+			},
+		},
+		{
+			name: "opencontainers image version as version fallback",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+					Labels: map[string]string{
+						"org.opencontainers.image.version": "1.2.3",
+					},
+				},
+			},
+			expected: []*TagInfo{
+				{
+					Source: containerSource,
+					Entity: taggerEntityID,
+					HighCardTags: []string{
+						fmt.Sprintf("container_name:%s", containerName),
+						fmt.Sprintf("container_id:%s", entityID.ID),
+					},
+					OrchestratorCardTags: []string{},
+					LowCardTags: []string{
+						"version:1.2.3",
+					},
+					StandardTags: []string{
+						"version:1.2.3",
+					},
+				},
+			},
+		},
+		{
+			name: "DD version label takes precedence over opencontainers image version",
+			container: workloadmeta.Container{
+				EntityID: entityID,
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: containerName,
+					Labels: map[string]string{
+						"org.opencontainers.image.version": "1.2.3",
+						"com.datadoghq.tags.version":       "2.0.0",
+					},
 				},
 			},
 			expected: []*TagInfo{
@@ -660,37 +3318,26 @@ func TestHandleContainer(t *testing.T) {
 						fmt.Sprintf("container_id:%s", entityID.ID),
 					},
 					OrchestratorCardTags: []string{},
-					LowCardTags: append([]string{
-						"docker_image:datadog/agent:latest",
-						"image_name:datadog/agent",
-						"image_tag:latest",
-						"short_image:agent",
-						"image_id:datadog/agent@sha256:a63d3f66fb2f69d955d4f2ca0b229385537a77872ffc04290acae65aed5317d2",
-					}, standardTags...),
-					StandardTags: standardTags,
+					LowCardTags: []string{
+						"version:2.0.0",
+					},
+					StandardTags: []string{
+						"version:2.0.0",
+					},
 				},
 			},
 		},
 		{
-			name: "tags from environment",
+			name: "opencontainers image version of kubernetes container left to the pod",
 			container: workloadmeta.Container{
 				EntityID: entityID,
 				EntityMeta: workloadmeta.EntityMeta{
 					Name: containerName,
+					Labels: map[string]string{
+						"org.opencontainers.image.version": "1.2.3",
+						"io.kubernetes.pod.namespace":      "default",
+					},
 				},
-				EnvVars: map[string]string{
-					// env as tags
-					"TEAM": "container-integrations",
-					"TIER": "node",
-
-					// standard tags
-					"DD_ENV":     env,
-					"DD_SERVICE": svc,
-					"DD_VERSION": version,
-				},
-			},
-			envAsTags: map[string]string{
-				"team": "owner_team",
 			},
 			expected: []*TagInfo{
 				{
@@ -701,31 +3348,24 @@ func TestHandleContainer(t *testing.T) {
 						fmt.Sprintf("container_id:%s", entityID.ID),
 					},
 					OrchestratorCardTags: []string{},
-					LowCardTags: append([]string{
-						"owner_team:container-integrations",
-					}, standardTags...),
-					StandardTags: standardTags,
+					LowCardTags:          []string{},
+					StandardTags:         []string{},
 				},
 			},
 		},
 		{
-			name: "tags from labels",
+			name: "DD_VERSION takes precedence over opencontainers image version",
 			container: workloadmeta.Container{
 				EntityID: entityID,
 				EntityMeta: workloadmeta.EntityMeta{
 					Name: containerName,
 					Labels: map[string]string{
-						// labels as tags
-						"team": "container-integrations",
-						"tier": "node",
-
-						// custom tags from label
-						"com.datadoghq.ad.tags": `["app_name:datadog-agent"]`,
+						"org.opencontainers.image.version": "1.2.3",
 					},
 				},
-			},
-			labelsAsTags: map[string]string{
-				"team": "owner_team",
+				EnvVars: map[string]string{
+					"DD_VERSION": "3.0.0",
+				},
 			},
 			expected: []*TagInfo{
 				{
@@ -734,36 +3374,29 @@ func TestHandleContainer(t *testing.T) {
 					HighCardTags: []string{
 						fmt.Sprintf("container_name:%s", containerName),
 						fmt.Sprintf("container_id:%s", entityID.ID),
-						"app_name:datadog-agent",
 					},
 					OrchestratorCardTags: []string{},
 					LowCardTags: []string{
-						"owner_team:container-integrations",
+						"version:3.0.0",
+					},
+					StandardTags: []string{
+						"version:3.0.0",
 					},
-					StandardTags: []string{},
 				},
 			},
 		},
 		{
-			name: "tags from labels and envs with prefix (using *)",
+			name: "running container",
 			container: workloadmeta.Container{
 				EntityID: entityID,
 				EntityMeta: workloadmeta.EntityMeta{
 					Name: containerName,
-					Labels: map[string]string{
-						"team": "container-integrations",
-					},
 				},
-				EnvVars: map[string]string{
-					"some_env": "some_env_val",
+				State: workloadmeta.ContainerState{
+					Running: true,
+					Status:  workloadmeta.ContainerStatusRunning,
 				},
 			},
-			labelsAsTags: map[string]string{
-				"*": "custom_label_prefix_%%label%%",
-			},
-			envAsTags: map[string]string{
-				"*": "custom_env_prefix_%%env%%",
-			},
 			expected: []*TagInfo{
 				{
 					Source: containerSource,
@@ -771,31 +3404,25 @@ func TestHandleContainer(t *testing.T) {
 					HighCardTags: []string{
 						fmt.Sprintf("container_name:%s", containerName),
 						fmt.Sprintf("container_id:%s", entityID.ID),
+						"container_status:running",
 					},
 					OrchestratorCardTags: []string{},
-					LowCardTags: []string{
-						// Notice that the names include the custom prefixes
-						// added in labelsAsTags and envAsTags.
-						"custom_label_prefix_team:container-integrations",
-						"custom_env_prefix_some_env:some_env_val",
-					},
-					StandardTags: []string{},
+					LowCardTags:          []string{},
+					StandardTags:         []string{},
 				},
 			},
 		},
 		{
-			name: "docker container with image that has no tag",
+			name: "container exited with zero exit code",
 			container: workloadmeta.Container{
 				EntityID: entityID,
 				EntityMeta: workloadmeta.EntityMeta{
 					Name: containerName,
 				},
-				Runtime: workloadmeta.ContainerRuntimeDocker,
-				Image: workloadmeta.ContainerImage{
-					RawName:   "redis",
-					Name:      "redis",
-					ShortName: "redis",
-					Tag:       "",
+				State: workloadmeta.ContainerState{
+					Running:  false,
+					Status:   workloadmeta.ContainerStatusStopped,
+					ExitCode: pointer.Ptr(uint32(0)),
 				},
 			},
 			expected: []*TagInfo{
@@ -805,30 +3432,26 @@ func TestHandleContainer(t *testing.T) {
 					HighCardTags: []string{
 						fmt.Sprintf("container_name:%s", containerName),
 						fmt.Sprintf("container_id:%s", entityID.ID),
+						"container_status:stopped",
+						"container_exit_code:0",
 					},
 					OrchestratorCardTags: []string{},
-					LowCardTags: []string{
-						"docker_image:redis", // Notice that there's no tag
-						"image_name:redis",
-						"short_image:redis",
-					},
-					StandardTags: []string{},
+					LowCardTags:          []string{},
+					StandardTags:         []string{},
 				},
 			},
 		},
 		{
-			name: "nomad container",
+			name: "container exited with non-zero exit code",
 			container: workloadmeta.Container{
 				EntityID: entityID,
 				EntityMeta: workloadmeta.EntityMeta{
 					Name: containerName,
 				},
-				EnvVars: map[string]string{
-					"NOMAD_TASK_NAME":  "test-task",
-					"NOMAD_JOB_NAME":   "test-job",
-					"NOMAD_GROUP_NAME": "test-group",
-					"NOMAD_NAMESPACE":  "test-namespace",
-					"NOMAD_DC":         "test-dc",
+				State: workloadmeta.ContainerState{
+					Running:  false,
+					Status:   workloadmeta.ContainerStatusStopped,
+					ExitCode: pointer.Ptr(uint32(137)),
 				},
 			},
 			expected: []*TagInfo{
@@ -838,31 +3461,26 @@ func TestHandleContainer(t *testing.T) {
 					HighCardTags: []string{
 						fmt.Sprintf("container_name:%s", containerName),
 						fmt.Sprintf("container_id:%s", entityID.ID),
+						"container_status:stopped",
+						"container_exit_code:137",
 					},
 					OrchestratorCardTags: []string{},
-					LowCardTags: []string{
-						"nomad_task:test-task",
-						"nomad_job:test-job",
-						"nomad_group:test-group",
-						"nomad_namespace:test-namespace",
-						"nomad_dc:test-dc",
-					},
-					StandardTags: []string{},
+					LowCardTags:          []string{},
+					StandardTags:         []string{},
 				},
 			},
 		},
 		{
-			name: "mesos dc/os container",
+			name: "container with creation time",
 			container: workloadmeta.Container{
 				EntityID: entityID,
 				EntityMeta: workloadmeta.EntityMeta{
 					Name: containerName,
 				},
-				EnvVars: map[string]string{
-					"MARATHON_APP_ID":   "/system/dd-agent",
-					"CHRONOS_JOB_NAME":  "app1_process-orders",
-					"CHRONOS_JOB_OWNER": "qa",
-					"MESOS_TASK_ID":     "system_dd-agent.dcc75b42-4b87-11e7-9a62-70b3d5800001",
+				State: workloadmeta.ContainerState{
+					Running:   true,
+					Status:    workloadmeta.ContainerStatusRunning,
+					CreatedAt: time.Date(2023, time.October, 2, 12, 30, 0, 123456789, time.UTC),
 				},
 			},
 			expected: []*TagInfo{
@@ -872,42 +3490,23 @@ func TestHandleContainer(t *testing.T) {
 					HighCardTags: []string{
 						fmt.Sprintf("container_name:%s", containerName),
 						fmt.Sprintf("container_id:%s", entityID.ID),
+						"container_status:running",
+						"container_created_at:1696249800",
 					},
-					OrchestratorCardTags: []string{
-						"mesos_task:system_dd-agent.dcc75b42-4b87-11e7-9a62-70b3d5800001",
-					},
-					LowCardTags: []string{
-						"chronos_job:app1_process-orders",
-						"chronos_job_owner:qa",
-						"marathon_app:/system/dd-agent",
-					},
-					StandardTags: []string{},
+					OrchestratorCardTags: []string{},
+					LowCardTags:          []string{},
+					StandardTags:         []string{},
 				},
 			},
 		},
 		{
-			name: "rancher container",
+			name: "container without ports",
 			container: workloadmeta.Container{
 				EntityID: entityID,
 				EntityMeta: workloadmeta.EntityMeta{
 					Name: containerName,
-					Labels: map[string]string{
-						"io.rancher.cni.network":             "ipsec",
-						"io.rancher.cni.wait":                "true",
-						"io.rancher.container.ip":            "10.42.234.7/16",
-						"io.rancher.container.mac_address":   "02:f1:dd:48:4c:d9",
-						"io.rancher.container.name":          "testAD-redis-1",
-						"io.rancher.container.pull_image":    "always",
-						"io.rancher.container.uuid":          "8e969193-2bc7-4a58-9a54-9eed44b01bb2",
-						"io.rancher.environment.uuid":        "adminProject",
-						"io.rancher.project.name":            "testAD",
-						"io.rancher.project_service.name":    "testAD/redis",
-						"io.rancher.service.deployment.unit": "06c082fc-4b66-4b6c-b098-30dbf29ed204",
-						"io.rancher.service.launch.config":   "io.rancher.service.primary.launch.config",
-						"io.rancher.stack.name":              "testAD",
-						"io.rancher.stack_service.name":      "testAD/redis",
-					},
 				},
+				Ports: []workloadmeta.ContainerPort{},
 			},
 			expected: []*TagInfo{
 				{
@@ -916,31 +3515,25 @@ func TestHandleContainer(t *testing.T) {
 					HighCardTags: []string{
 						fmt.Sprintf("container_name:%s", containerName),
 						fmt.Sprintf("container_id:%s", entityID.ID),
-						"rancher_container:testAD-redis-1",
 					},
 					OrchestratorCardTags: []string{},
-					LowCardTags: []string{
-						"rancher_service:testAD/redis",
-						"rancher_stack:testAD",
-					},
-					StandardTags: []string{},
+					LowCardTags:          []string{},
+					StandardTags:         []string{},
 				},
 			},
 		},
 		{
-			name: "docker swarm container",
+			name: "container with one port",
 			container: workloadmeta.Container{
 				EntityID: entityID,
 				EntityMeta: workloadmeta.EntityMeta{
 					Name: containerName,
-					Labels: map[string]string{
-						"com.docker.swarm.node.id":      "zdtab51ei97djzrpa1y2tz8li",
-						"com.docker.swarm.service.id":   "tef96xrdmlj82c7nt57jdntl8",
-						"com.docker.swarm.service.name": "helloworld",
-						"com.docker.swarm.task":         "",
-						"com.docker.swarm.task.id":      "knk1rz1szius7pvyznn9zolld",
-						"com.docker.swarm.task.name":    "helloworld.1.knk1rz1szius7pvyznn9zolld",
-						"com.docker.stack.namespace":    "default",
+				},
+				Ports: []workloadmeta.ContainerPort{
+					{
+						Name:     "http",
+						Port:     8080,
+						Protocol: "TCP",
 					},
 				},
 			},
@@ -952,24 +3545,32 @@ func TestHandleContainer(t *testing.T) {
 						fmt.Sprintf("container_name:%s", containerName),
 						fmt.Sprintf("container_id:%s", entityID.ID),
 					},
-					OrchestratorCardTags: []string{},
-					LowCardTags: []string{
-						"swarm_namespace:default",
-						"swarm_service:helloworld",
+					OrchestratorCardTags: []string{
+						"container_port:8080/tcp",
 					},
+					LowCardTags:  []string{},
 					StandardTags: []string{},
 				},
 			},
 		},
 		{
-			name: "opencontainers image revision and source",
+			name: "container with multiple ports",
 			container: workloadmeta.Container{
 				EntityID: entityID,
 				EntityMeta: workloadmeta.EntityMeta{
 					Name: containerName,
-					Labels: map[string]string{
-						"org.opencontainers.image.revision": "758691a28aa920070651d360814c559bc26af907",
-						"org.opencontainers.image.source":   "https://github.com/my-company/repo",
+				},
+				Ports: []workloadmeta.ContainerPort{
+					{
+						Port:     8125,
+						Protocol: "udp",
+					},
+					{
+						Port:     8126,
+						Protocol: "tcp",
+					},
+					{
+						Port: 5000,
 					},
 				},
 			},
@@ -981,11 +3582,12 @@ func TestHandleContainer(t *testing.T) {
 						fmt.Sprintf("container_name:%s", containerName),
 						fmt.Sprintf("container_id:%s", entityID.ID),
 					},
-					OrchestratorCardTags: []string{},
-					LowCardTags: []string{
-						"git.commit.sha:758691a28aa920070651d360814c559bc26af907",
-						"git.repository_url:https://github.com/my-company/repo",
+					OrchestratorCardTags: []string{
+						"container_port:8125/udp",
+						"container_port:8126/tcp",
+						"container_port:5000",
 					},
+					LowCardTags:  []string{},
 					StandardTags: []string{},
 				},
 			},
@@ -1324,6 +3926,42 @@ func TestParseJSONValue(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:  "bare array",
+			value: `["key1:val1", "key2:val2"]`,
+			want: []string{
+				"key1:val1",
+				"key2:val2",
+			},
+			wantErr: false,
+		},
+		{
+			name:  "bare array with invalid items",
+			value: `["key1:val1", "novalue", 0, ":val", "key2:val2:extra"]`,
+			want: []string{
+				"key1:val1",
+				"key2:val2:extra",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "empty array",
+			value:   `[]`,
+			want:    []string{},
+			wantErr: false,
+		},
+		{
+			name:    "invalid array",
+			value:   `["key1:val1",`,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "scalar",
+			value:   `"key1:val1"`,
+			want:    nil,
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1341,6 +3979,60 @@ func TestParseJSONValue(t *testing.T) {
 	}
 }
 
+func TestParseJSONArrayValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "empty json",
+			value:   ``,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "object",
+			value:   `{"key1": "val1"}`,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:  "strings",
+			value: `["key1:val1", "key1:val11", "key2:val2"]`,
+			want: []string{
+				"key1:val1",
+				"key1:val11",
+				"key2:val2",
+			},
+			wantErr: false,
+		},
+		{
+			name:  "mixed types",
+			value: `["key1:val1", 1, true, null, ["key2:val2"]]`,
+			want: []string{
+				"key1:val1",
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tags := utils.NewTagList()
+			err := parseJSONArrayValue(tt.value, tags)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("parseJSONArrayValue() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			low, _, _, _ := tags.Compute()
+			assert.ElementsMatch(t, tt.want, low)
+		})
+	}
+}
+
 func Test_mergeMaps(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -1377,6 +4069,52 @@ func Test_mergeMaps(t *testing.T) {
 	}
 }
 
+func TestCronJobForJobOwnerCache(t *testing.T) {
+	job := &workloadmeta.KubernetesJob{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindKubernetesJob,
+			ID:   "job-uid",
+		},
+		EntityMeta: workloadmeta.EntityMeta{
+			Name:      "backup-manual",
+			Namespace: "default",
+		},
+		Owners: []workloadmeta.KubernetesPodOwner{
+			{
+				Kind: kubernetes.CronJobKind,
+				Name: "backup",
+				ID:   "cronjob-uid",
+			},
+		},
+	}
+	owner := workloadmeta.KubernetesPodOwner{
+		Kind: kubernetes.JobKind,
+		Name: job.Name,
+		ID:   job.ID,
+	}
+
+	store := workloadmetatesting.NewStore()
+	store.Set(job)
+
+	ownerCache, err := lru.New[string, ownerCacheEntry](ownerCacheSize)
+	assert.NoError(t, err)
+	collector := &WorkloadMetaCollector{
+		store:      store,
+		ownerCache: ownerCache,
+	}
+
+	assert.Equal(t, "backup", collector.cronJobForJob(owner))
+
+	// the job is not looked up again while cached
+	store.Unset(job)
+	assert.Equal(t, "backup", collector.cronJobForJob(owner))
+
+	// once expired, the cronjob name is parsed from the job name
+	ownerCache.Add(job.ID, ownerCacheEntry{cronJob: "backup", expires: time.Now().Add(-time.Second)})
+	assert.Equal(t, "", collector.cronJobForJob(owner))
+	assert.False(t, ownerCache.Contains(job.ID))
+}
+
 func assertTagInfoEqual(t *testing.T, expected *TagInfo, item *TagInfo) bool {
 	t.Helper()
 	sort.Strings(expected.LowCardTags)
@@ -1391,7 +4129,7 @@ func assertTagInfoEqual(t *testing.T, expected *TagInfo, item *TagInfo) bool {
 	sort.Strings(expected.StandardTags)
 	sort.Strings(item.StandardTags)
 
-	return assert.Equal(t, expected, item)
+	return assert.Equal(t, expected, item, "tags diff:\n%s", DiffTagInfo(expected, item))
 }
 
 func assertTagInfoListEqual(t *testing.T, expectedUpdates []*TagInfo, updates []*TagInfo) {
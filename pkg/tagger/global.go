@@ -225,6 +225,11 @@ func List(cardinality collectors.TagCardinality) tagger_api.TaggerListResponse {
 	return defaultTagger.List(cardinality)
 }
 
+// DumpMemory returns the tag counts and memory estimate of the entities of the defaultTagger
+func DumpMemory() tagger_api.TaggerMemoryDumpResponse {
+	return defaultTagger.DumpMemory()
+}
+
 // SetDefaultTagger sets the global Tagger instance
 func SetDefaultTagger(tagger Tagger) {
 	// reset initOnce so that this new tagger's Init(..) will get called
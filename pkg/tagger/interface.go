@@ -30,6 +30,7 @@ type Tagger interface {
 	AccumulateTagsFor(entity string, cardinality collectors.TagCardinality, tb tagset.TagsAccumulator) error
 	Standard(entity string) ([]string, error)
 	List(cardinality collectors.TagCardinality) tagger_api.TaggerListResponse
+	DumpMemory() tagger_api.TaggerMemoryDumpResponse
 	GetEntity(entityID string) (*types.Entity, error)
 
 	Subscribe(cardinality collectors.TagCardinality) chan []types.EntityEvent
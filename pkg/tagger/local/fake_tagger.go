@@ -112,6 +112,11 @@ func (f *FakeTagger) List(cardinality collectors.TagCardinality) tagger_api.Tagg
 	return f.store.List()
 }
 
+// DumpMemory fake implementation
+func (f *FakeTagger) DumpMemory() tagger_api.TaggerMemoryDumpResponse {
+	return f.store.DumpMemory()
+}
+
 // Subscribe fake implementation
 func (f *FakeTagger) Subscribe(cardinality collectors.TagCardinality) chan []types.EntityEvent {
 	return f.store.Subscribe(cardinality)
@@ -119,6 +119,11 @@ func (t *Tagger) List(cardinality collectors.TagCardinality) tagger_api.TaggerLi
 	return t.tagStore.List()
 }
 
+// DumpMemory returns the tag counts and memory estimate of the entities of the tagger
+func (t *Tagger) DumpMemory() tagger_api.TaggerMemoryDumpResponse {
+	return t.tagStore.DumpMemory()
+}
+
 // Subscribe returns a channel that receives a slice of events whenever an entity is
 // added, modified or deleted. It can send an initial burst of events only to the new
 // subscriber, without notifying all of the others.
@@ -230,6 +230,33 @@ func (t *Tagger) List(cardinality collectors.TagCardinality) tagger_api.TaggerLi
 	return resp
 }
 
+// DumpMemory returns the tag counts and memory estimate of the entities
+// currently stored by the tagger.
+func (t *Tagger) DumpMemory() tagger_api.TaggerMemoryDumpResponse {
+	entities := t.store.listEntities()
+	dump := make([]tagger_api.TaggerMemoryDumpEntity, 0, len(entities))
+
+	for _, e := range entities {
+		dump = append(dump, tagger_api.TaggerMemoryDumpEntity{
+			EntityID: e.ID,
+			Sources:  1,
+			TagCounts: map[string]int{
+				collectors.LowCardinalityString:          len(e.LowCardinalityTags),
+				collectors.OrchestratorCardinalityString: len(e.OrchestratorCardinalityTags),
+				collectors.HighCardinalityString:         len(e.HighCardinalityTags),
+				tagger_api.StandardTagsCountKey:          len(e.StandardTags),
+			},
+			MemoryEstimate: tagger_api.EstimateStringSize(e.ID) +
+				tagger_api.EstimateStringSliceSize(e.LowCardinalityTags) +
+				tagger_api.EstimateStringSliceSize(e.OrchestratorCardinalityTags) +
+				tagger_api.EstimateStringSliceSize(e.HighCardinalityTags) +
+				tagger_api.EstimateStringSliceSize(e.StandardTags),
+		})
+	}
+
+	return tagger_api.NewTaggerMemoryDumpResponse(dump)
+}
+
 // Subscribe returns a channel that receives a slice of events whenever an entity is
 // added, modified or deleted. It can send an initial burst of events only to the new
 // subscriber, without notifying all of the others.
@@ -103,6 +103,11 @@ func (t *Tagger) List(cardinality collectors.TagCardinality) tagger_api.TaggerLi
 	return t.store.List()
 }
 
+// DumpMemory returns the tag counts and memory estimate of the entities stored by the tagger.
+func (t *Tagger) DumpMemory() tagger_api.TaggerMemoryDumpResponse {
+	return t.store.DumpMemory()
+}
+
 // Subscribe does nothing in the replay tagger this tagger does not respond to events.
 func (t *Tagger) Subscribe(cardinality collectors.TagCardinality) chan []types.EntityEvent {
 	// NOP
@@ -8,15 +8,19 @@ package tagstore
 import (
 	"sort"
 	"strings"
+	"sync/atomic"
+	"time"
 
+	tagger_api "github.com/DataDog/datadog-agent/pkg/tagger/api"
 	"github.com/DataDog/datadog-agent/pkg/tagger/collectors"
+	"github.com/DataDog/datadog-agent/pkg/tagger/telemetry"
 	"github.com/DataDog/datadog-agent/pkg/tagger/types"
 	"github.com/DataDog/datadog-agent/pkg/tagset"
 )
 
 // EntityTags holds the tag information for a given entity. It is not
 // thread-safe, so should not be shared outside of the store. Usage inside the
-// store is safe since it relies on a global lock.
+// store is safe since it relies on the lock of the shard holding the entity.
 type EntityTags struct {
 	entityID           string
 	sourceTags         map[string]sourceTags
@@ -24,6 +28,11 @@ type EntityTags struct {
 	cachedAll          tagset.HashedTags // Low + orchestrator + high
 	cachedOrchestrator tagset.HashedTags // Low + orchestrator (subslice of cachedAll)
 	cachedLow          tagset.HashedTags // Sub-slice of cachedAll
+	deduplicatedTags   int               // Duplicated tags dropped from the cache
+
+	// lastAccess is the time of the last lookup or update of the entity, in
+	// nanoseconds since the epoch. It's atomic as lookups only hold a read lock.
+	lastAccess atomic.Int64
 }
 
 func newEntityTags(entityID string) *EntityTags {
@@ -34,6 +43,10 @@ func newEntityTags(entityID string) *EntityTags {
 	}
 }
 
+func (e *EntityTags) touch(now time.Time) {
+	e.lastAccess.Store(now.UnixNano())
+}
+
 func (e *EntityTags) getStandard() []string {
 	tags := []string{}
 	for _, t := range e.sourceTags {
@@ -75,6 +88,45 @@ func (e *EntityTags) toEntity() types.Entity {
 	}
 }
 
+// memoryDump returns the tag counts of the entity and an estimate of its memory
+// usage, including the tags cache.
+func (e *EntityTags) memoryDump() tagger_api.TaggerMemoryDumpEntity {
+	tagCounts := map[string]int{
+		collectors.LowCardinalityString:          0,
+		collectors.OrchestratorCardinalityString: 0,
+		collectors.HighCardinalityString:         0,
+		tagger_api.StandardTagsCountKey:          0,
+	}
+
+	size := tagger_api.EstimateStringSize(e.entityID)
+	for source, st := range e.sourceTags {
+		tagCounts[collectors.LowCardinalityString] += len(st.lowCardTags)
+		tagCounts[collectors.OrchestratorCardinalityString] += len(st.orchestratorCardTags)
+		tagCounts[collectors.HighCardinalityString] += len(st.highCardTags)
+		tagCounts[tagger_api.StandardTagsCountKey] += len(st.standardTags)
+
+		size += tagger_api.EstimateStringSize(source) +
+			tagger_api.EstimateStringSliceSize(st.lowCardTags) +
+			tagger_api.EstimateStringSliceSize(st.orchestratorCardTags) +
+			tagger_api.EstimateStringSliceSize(st.highCardTags) +
+			tagger_api.EstimateStringSliceSize(st.standardTags)
+	}
+
+	if e.cacheValid {
+		// cachedLow and cachedOrchestrator are sub-slices of cachedAll, each
+		// tag of the cache also has a 64 bits hash
+		cached := e.cachedAll.Get()
+		size += tagger_api.EstimateStringSliceSize(cached) + 8*len(cached)
+	}
+
+	return tagger_api.TaggerMemoryDumpEntity{
+		EntityID:       e.entityID,
+		Sources:        len(e.sourceTags),
+		TagCounts:      tagCounts,
+		MemoryEstimate: size,
+	}
+}
+
 func (e *EntityTags) computeCache() {
 	if e.cacheValid {
 		return
@@ -89,11 +141,17 @@ func (e *EntityTags) computeCache() {
 	}
 
 	// sort sources in descending order of priority. assumes lowest if
-	// priority is not declared
-	sort.Slice(sources, func(i, j int) bool {
+	// priority is not declared. sources of the same priority are sorted by
+	// name, so the tags are merged in the same order on every run.
+	sort.SliceStable(sources, func(i, j int) bool {
 		sourceI := sources[i]
 		sourceJ := sources[j]
-		return collectors.CollectorPriorities[sourceI] > collectors.CollectorPriorities[sourceJ]
+		prioI := collectors.CollectorPriorities[sourceI]
+		prioJ := collectors.CollectorPriorities[sourceJ]
+		if prioI != prioJ {
+			return prioI > prioJ
+		}
+		return sourceI < sourceJ
 	})
 
 	// insertWithPriority prevents two collectors of different priorities
@@ -123,6 +181,39 @@ func (e *EntityTags) computeCache() {
 		insertWithPriority(source, tags.highCardTags, collectors.HighCardinality)
 	}
 
+	// sources of the same priority can report the same key:value pair (eg.
+	// task_family from both the ECS task and the container), possibly at
+	// different cardinalities. only keep it once, at the lowest cardinality
+	// it's reported at, so lookups of every cardinality still return it.
+	lowestCardinality := make(map[string]collectors.TagCardinality)
+	for _, cardinality := range []collectors.TagCardinality{collectors.HighCardinality, collectors.OrchestratorCardinality, collectors.LowCardinality} {
+		for _, t := range tagList[cardinality] {
+			lowestCardinality[t] = cardinality
+		}
+	}
+
+	seenTags := make(map[string]struct{})
+	deduplicatedTags := 0
+	for _, cardinality := range []collectors.TagCardinality{collectors.LowCardinality, collectors.OrchestratorCardinality, collectors.HighCardinality} {
+		deduplicated := tagList[cardinality][:0]
+		for _, t := range tagList[cardinality] {
+			if _, seen := seenTags[t]; seen || lowestCardinality[t] != cardinality {
+				deduplicatedTags++
+				continue
+			}
+			seenTags[t] = struct{}{}
+			deduplicated = append(deduplicated, t)
+		}
+		tagList[cardinality] = deduplicated
+	}
+
+	// the cache is computed again on every change of the sources, only
+	// count the duplicates that were not already dropped from the cache
+	if newDuplicates := deduplicatedTags - e.deduplicatedTags; newDuplicates > 0 {
+		telemetry.DeduplicatedTags.Add(float64(newDuplicates))
+	}
+	e.deduplicatedTags = deduplicatedTags
+
 	tags := append(tagList[collectors.LowCardinality], tagList[collectors.OrchestratorCardinality]...)
 	tags = append(tags, tagList[collectors.HighCardinality]...)
 
@@ -0,0 +1,92 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package tagstore
+
+import (
+	"container/heap"
+
+	"github.com/DataDog/datadog-agent/pkg/tagger/telemetry"
+	"github.com/DataDog/datadog-agent/pkg/tagger/types"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// evictionBatchDivisor sets the share of maxEntities evicted in a single
+// batch once the store exceeds it: the store is scanned once to evict
+// maxEntities/evictionBatchDivisor entities, instead of on every write.
+const evictionBatchDivisor = 10
+
+// evictionCandidate is an entity that may be evicted from the store
+type evictionCandidate struct {
+	entityID   string
+	lastAccess int64
+}
+
+// evictionHeap is a max-heap on the access time, used to keep the n least
+// recently used entities while scanning the store.
+type evictionHeap []evictionCandidate
+
+func (h evictionHeap) Len() int           { return len(h) }
+func (h evictionHeap) Less(i, j int) bool { return h[i].lastAccess > h[j].lastAccess }
+func (h evictionHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *evictionHeap) Push(x interface{}) {
+	*h = append(*h, x.(evictionCandidate))
+}
+
+func (h *evictionHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
+
+// evictLeastRecentlyUsed removes the least recently accessed entities once
+// the store holds more than maxEntities, down to a low watermark below it, and
+// returns the resulting events. The caller must hold writeMutex.
+func (s *TagStore) evictLeastRecentlyUsed() []types.EntityEvent {
+	if s.maxEntities <= 0 || s.entityCount <= s.maxEntities {
+		return nil
+	}
+
+	lowWatermark := s.maxEntities - s.maxEntities/evictionBatchDivisor
+	n := s.entityCount - lowWatermark
+	candidates := make(evictionHeap, 0, n+1)
+	for _, shard := range s.store {
+		shard.RLock()
+		for entityID, storedTags := range shard.entities {
+			lastAccess := storedTags.lastAccess.Load()
+			if len(candidates) == n && lastAccess >= candidates[0].lastAccess {
+				continue
+			}
+			heap.Push(&candidates, evictionCandidate{entityID: entityID, lastAccess: lastAccess})
+			if len(candidates) > n {
+				heap.Pop(&candidates)
+			}
+		}
+		shard.RUnlock()
+	}
+
+	events := make([]types.EntityEvent, 0, len(candidates))
+	for _, candidate := range candidates {
+		shard := s.store.shardFor(candidate.entityID)
+		shard.Lock()
+		if storedTags, ok := shard.entities[candidate.entityID]; ok {
+			delete(shard.entities, candidate.entityID)
+			s.entityCount--
+			telemetry.Evictions.Inc()
+			events = append(events, types.EntityEvent{
+				EventType: types.EventTypeDeleted,
+				Entity:    storedTags.toEntity(),
+			})
+		}
+		shard.Unlock()
+	}
+
+	log.Debugf("evicted %d entities from the tagger, the store reached its limit of %d entities", len(events), s.maxEntities)
+
+	return events
+}
@@ -0,0 +1,60 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package tagstore
+
+import "sync"
+
+const (
+	storeShardCount = 256
+
+	// FNV-1a 32 bits parameters
+	fnvOffset32 = 2166136261
+	fnvPrime32  = 16777619
+)
+
+// storeShard holds a subset of the entities of the store. Its lock protects
+// both the map and the EntityTags it contains, so that lookups on an entity
+// only contend with operations on entities of the same shard.
+type storeShard struct {
+	sync.RWMutex
+	entities map[string]*EntityTags
+}
+
+// shardedEntities splits the entities of the store in a fixed number of
+// shards keyed by the hash of the entity ID.
+type shardedEntities [storeShardCount]*storeShard
+
+func newShardedEntities() *shardedEntities {
+	var s shardedEntities
+	for i := range s {
+		s[i] = &storeShard{
+			entities: make(map[string]*EntityTags),
+		}
+	}
+	return &s
+}
+
+// shardFor returns the shard responsible for the given entity ID.
+func (s *shardedEntities) shardFor(entityID string) *storeShard {
+	// inlined FNV-1a to avoid allocating a hash.Hash32 on every lookup
+	h := uint32(fnvOffset32)
+	for i := 0; i < len(entityID); i++ {
+		h ^= uint32(entityID[i])
+		h *= fnvPrime32
+	}
+	return s[h%storeShardCount]
+}
+
+// len returns the number of entities across all the shards.
+func (s *shardedEntities) len() int {
+	n := 0
+	for _, shard := range s {
+		shard.RLock()
+		n += len(shard.entities)
+		shard.RUnlock()
+	}
+	return n
+}
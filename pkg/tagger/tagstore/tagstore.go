@@ -14,6 +14,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/DataDog/datadog-agent/pkg/config"
 	"github.com/DataDog/datadog-agent/pkg/status/health"
 	tagger_api "github.com/DataDog/datadog-agent/pkg/tagger/api"
 	"github.com/DataDog/datadog-agent/pkg/tagger/collectors"
@@ -36,12 +37,23 @@ var ErrNotFound = errors.New("entity not found")
 
 // TagStore stores entity tags in memory and handles search and collation.
 // Queries should go through the Tagger for cache-miss handling
+//
+// Entities are spread across shards, each with its own lock, so that lookups
+// are not serialized with each other nor with writes to unrelated entities.
+// Writes and subscriptions are additionally serialized by writeMutex so that
+// subscribers receive events in the same order as they were applied.
 type TagStore struct {
-	sync.RWMutex
+	writeMutex sync.Mutex
 
-	store     map[string]*EntityTags
+	store     *shardedEntities
 	telemetry map[string]map[string]float64
 
+	// entityCount is the number of entities in the store, protected by writeMutex
+	entityCount int
+	// maxEntities is the number of entities above which the least recently
+	// used ones are evicted in a batch, 0 disables the limit
+	maxEntities int
+
 	subscriber *subscriber.Subscriber
 
 	clock clock.Clock
@@ -54,10 +66,11 @@ func NewTagStore() *TagStore {
 
 func newTagStoreWithClock(clock clock.Clock) *TagStore {
 	return &TagStore{
-		telemetry:  make(map[string]map[string]float64),
-		store:      make(map[string]*EntityTags),
-		subscriber: subscriber.NewSubscriber(),
-		clock:      clock,
+		telemetry:   make(map[string]map[string]float64),
+		store:       newShardedEntities(),
+		subscriber:  subscriber.NewSubscriber(),
+		clock:       clock,
+		maxEntities: config.Datadog.GetInt("tagger.max_entities"),
 	}
 }
 
@@ -90,8 +103,8 @@ func (s *TagStore) Run(ctx context.Context) {
 func (s *TagStore) ProcessTagInfo(tagInfos []*collectors.TagInfo) {
 	events := []types.EntityEvent{}
 
-	s.Lock()
-	defer s.Unlock()
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
 
 	for _, info := range tagInfos {
 		if info == nil {
@@ -107,53 +120,73 @@ func (s *TagStore) ProcessTagInfo(tagInfos []*collectors.TagInfo) {
 			continue
 		}
 
-		storedTags, exist := s.store[info.Entity]
+		if event, changed := s.processTagInfo(info); changed {
+			events = append(events, event)
+		}
+	}
 
-		if info.DeleteEntity {
-			if exist {
-				st, ok := storedTags.sourceTags[info.Source]
-				if ok {
-					st.expiryDate = s.clock.Now().Add(deletedTTL)
-					storedTags.sourceTags[info.Source] = st
-				}
-			}
+	events = append(events, s.evictLeastRecentlyUsed()...)
 
-			continue
-		}
+	if len(events) > 0 {
+		s.notifySubscribers(events)
+	}
+}
 
-		newSt := sourceTags{
-			lowCardTags:          info.LowCardTags,
-			orchestratorCardTags: info.OrchestratorCardTags,
-			highCardTags:         info.HighCardTags,
-			standardTags:         info.StandardTags,
-			expiryDate:           info.ExpiryDate,
-		}
+// processTagInfo applies a single TagInfo to the shard holding its entity, and
+// returns the resulting event if the entity was added or modified.
+func (s *TagStore) processTagInfo(info *collectors.TagInfo) (types.EntityEvent, bool) {
+	shard := s.store.shardFor(info.Entity)
+	shard.Lock()
+	defer shard.Unlock()
 
-		eventType := types.EventTypeModified
+	storedTags, exist := shard.entities[info.Entity]
+
+	if info.DeleteEntity {
 		if exist {
 			st, ok := storedTags.sourceTags[info.Source]
-			if ok && reflect.DeepEqual(st, newSt) {
-				continue
+			if ok {
+				st.expiryDate = s.clock.Now().Add(deletedTTL)
+				storedTags.sourceTags[info.Source] = st
 			}
-		} else {
-			eventType = types.EventTypeAdded
-			storedTags = newEntityTags(info.Entity)
-			s.store[info.Entity] = storedTags
 		}
 
-		telemetry.UpdatedEntities.Inc()
-		storedTags.cacheValid = false
-		storedTags.sourceTags[info.Source] = newSt
+		return types.EntityEvent{}, false
+	}
 
-		events = append(events, types.EntityEvent{
-			EventType: eventType,
-			Entity:    storedTags.toEntity(),
-		})
+	if exist {
+		storedTags.touch(s.clock.Now())
 	}
 
-	if len(events) > 0 {
-		s.notifySubscribers(events)
+	newSt := sourceTags{
+		lowCardTags:          info.LowCardTags,
+		orchestratorCardTags: info.OrchestratorCardTags,
+		highCardTags:         info.HighCardTags,
+		standardTags:         info.StandardTags,
+		expiryDate:           info.ExpiryDate,
+	}
+
+	eventType := types.EventTypeModified
+	if exist {
+		st, ok := storedTags.sourceTags[info.Source]
+		if ok && reflect.DeepEqual(st, newSt) {
+			return types.EntityEvent{}, false
+		}
+	} else {
+		eventType = types.EventTypeAdded
+		storedTags = newEntityTags(info.Entity)
+		storedTags.touch(s.clock.Now())
+		shard.entities[info.Entity] = storedTags
+		s.entityCount++
 	}
+
+	telemetry.UpdatedEntities.Inc()
+	storedTags.cacheValid = false
+	storedTags.sourceTags[info.Source] = newSt
+
+	return types.EntityEvent{
+		EventType: eventType,
+		Entity:    storedTags.toEntity(),
+	}, true
 }
 
 func (s *TagStore) collectTelemetry() {
@@ -162,19 +195,23 @@ func (s *TagStore) collectTelemetry() {
 	// to zero after we're done to ensure a new run of collectTelemetry
 	// will not forget to clear them if they disappear.
 
-	s.Lock()
-	defer s.Unlock()
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
 
-	for _, entityTags := range s.store {
-		prefix, _ := containers.SplitEntityName(entityTags.entityID)
+	for _, shard := range s.store {
+		shard.RLock()
+		for _, entityTags := range shard.entities {
+			prefix, _ := containers.SplitEntityName(entityTags.entityID)
 
-		for source := range entityTags.sourceTags {
-			if _, ok := s.telemetry[prefix]; !ok {
-				s.telemetry[prefix] = make(map[string]float64)
-			}
+			for source := range entityTags.sourceTags {
+				if _, ok := s.telemetry[prefix]; !ok {
+					s.telemetry[prefix] = make(map[string]float64)
+				}
 
-			s.telemetry[prefix][source]++
+				s.telemetry[prefix][source]++
+			}
 		}
+		shard.RUnlock()
 	}
 
 	for prefix, sources := range s.telemetry {
@@ -189,15 +226,20 @@ func (s *TagStore) collectTelemetry() {
 // added, modified or deleted. It can send an initial burst of events only to the new
 // subscriber, without notifying all of the others.
 func (s *TagStore) Subscribe(cardinality collectors.TagCardinality) chan []types.EntityEvent {
-	s.RLock()
-	defer s.RUnlock()
-
-	events := make([]types.EntityEvent, 0, len(s.store))
-	for _, storedTags := range s.store {
-		events = append(events, types.EntityEvent{
-			EventType: types.EventTypeAdded,
-			Entity:    storedTags.toEntity(),
-		})
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
+
+	events := make([]types.EntityEvent, 0, s.store.len())
+	for _, shard := range s.store {
+		// toEntity can update the tags cache, hence the write lock
+		shard.Lock()
+		for _, storedTags := range shard.entities {
+			events = append(events, types.EntityEvent{
+				EventType: types.EventTypeAdded,
+				Entity:    storedTags.toEntity(),
+			})
+		}
+		shard.Unlock()
 	}
 
 	return s.subscriber.Subscribe(cardinality, events)
@@ -215,13 +257,29 @@ func (s *TagStore) notifySubscribers(events []types.EntityEvent) {
 // Prune deletes tags for entities that have been marked as deleted. This is to
 // be called regularly from the user class.
 func (s *TagStore) Prune() {
-	s.Lock()
-	defer s.Unlock()
+	s.writeMutex.Lock()
+	defer s.writeMutex.Unlock()
 
 	now := s.clock.Now()
 	events := []types.EntityEvent{}
 
-	for entity, storedTags := range s.store {
+	for _, shard := range s.store {
+		shard.Lock()
+		events = append(events, s.pruneShard(shard, now)...)
+		shard.Unlock()
+	}
+
+	if len(events) > 0 {
+		s.notifySubscribers(events)
+	}
+}
+
+// pruneShard deletes expired tags from the entities of a shard, and returns
+// the resulting events. The shard must be locked by the caller.
+func (s *TagStore) pruneShard(shard *storeShard, now time.Time) []types.EntityEvent {
+	var events []types.EntityEvent
+
+	for entity, storedTags := range shard.entities {
 		changed := false
 
 		// remove any sourceTags that have expired
@@ -244,7 +302,8 @@ func (s *TagStore) Prune() {
 
 		if len(storedTags.sourceTags) == 0 {
 			telemetry.PrunedEntities.Inc()
-			delete(s.store, entity)
+			delete(shard.entities, entity)
+			s.entityCount--
 			events = append(events, types.EntityEvent{
 				EventType: types.EventTypeDeleted,
 				Entity:    storedTags.toEntity(),
@@ -258,19 +317,34 @@ func (s *TagStore) Prune() {
 		}
 	}
 
-	if len(events) > 0 {
-		s.notifySubscribers(events)
-	}
+	return events
 }
 
 // LookupHashed gets tags from the store and returns them as a HashedTags instance. It
 // returns the source names in the second slice to allow the client to trigger manual
 // lookups on missing sources.
 func (s *TagStore) LookupHashed(entity string, cardinality collectors.TagCardinality) tagset.HashedTags {
-	s.RLock()
-	defer s.RUnlock()
-	storedTags, present := s.store[entity]
+	shard := s.store.shardFor(entity)
+
+	shard.RLock()
+	storedTags, present := shard.entities[entity]
+	if !present {
+		shard.RUnlock()
+		return tagset.HashedTags{}
+	}
+	storedTags.touch(s.clock.Now())
+	if storedTags.cacheValid {
+		tags := storedTags.getHashedTags(cardinality)
+		shard.RUnlock()
+		return tags
+	}
+	shard.RUnlock()
 
+	// the cache needs to be recomputed, which requires the write lock
+	shard.Lock()
+	defer shard.Unlock()
+
+	storedTags, present = shard.entities[entity]
 	if !present {
 		return tagset.HashedTags{}
 	}
@@ -286,20 +360,26 @@ func (s *TagStore) Lookup(entity string, cardinality collectors.TagCardinality)
 
 // LookupStandard returns the standard tags recorded for a given entity
 func (s *TagStore) LookupStandard(entityID string) ([]string, error) {
-	storedTags, err := s.GetEntityTags(entityID)
-	if err != nil {
-		return nil, err
+	shard := s.store.shardFor(entityID)
+	shard.RLock()
+	defer shard.RUnlock()
+
+	storedTags, present := shard.entities[entityID]
+	if !present {
+		return nil, ErrNotFound
 	}
+	storedTags.touch(s.clock.Now())
 
 	return storedTags.getStandard(), nil
 }
 
 // GetEntityTags returns the EntityTags for a given entity
 func (s *TagStore) GetEntityTags(entityID string) (*EntityTags, error) {
-	s.RLock()
-	defer s.RUnlock()
+	shard := s.store.shardFor(entityID)
+	shard.RLock()
+	defer shard.RUnlock()
 
-	storedTags, present := s.store[entityID]
+	storedTags, present := shard.entities[entityID]
 	if !present {
 		return nil, ErrNotFound
 	}
@@ -313,34 +393,57 @@ func (s *TagStore) List() tagger_api.TaggerListResponse {
 		Entities: make(map[string]tagger_api.TaggerListEntity),
 	}
 
-	s.RLock()
-	defer s.RUnlock()
+	for _, shard := range s.store {
+		shard.RLock()
+		for entityID, et := range shard.entities {
+			entity := tagger_api.TaggerListEntity{
+				Tags: make(map[string][]string),
+			}
 
-	for entityID, et := range s.store {
-		entity := tagger_api.TaggerListEntity{
-			Tags: make(map[string][]string),
-		}
+			for source, sourceTags := range et.sourceTags {
+				tags := append([]string(nil), sourceTags.lowCardTags...)
+				tags = append(tags, sourceTags.orchestratorCardTags...)
+				tags = append(tags, sourceTags.highCardTags...)
+				entity.Tags[source] = tags
+			}
 
-		for source, sourceTags := range et.sourceTags {
-			tags := append([]string(nil), sourceTags.lowCardTags...)
-			tags = append(tags, sourceTags.orchestratorCardTags...)
-			tags = append(tags, sourceTags.highCardTags...)
-			entity.Tags[source] = tags
+			r.Entities[entityID] = entity
 		}
-
-		r.Entities[entityID] = entity
+		shard.RUnlock()
 	}
 
 	return r
 }
 
+// DumpMemory returns the tag counts and an estimate of the memory used by each
+// entity of the store.
+func (s *TagStore) DumpMemory() tagger_api.TaggerMemoryDumpResponse {
+	entities := make([]tagger_api.TaggerMemoryDumpEntity, 0, s.store.len())
+
+	for _, shard := range s.store {
+		shard.RLock()
+		for _, et := range shard.entities {
+			entities = append(entities, et.memoryDump())
+		}
+		shard.RUnlock()
+	}
+
+	return tagger_api.NewTaggerMemoryDumpResponse(entities)
+}
+
 // GetEntity returns the entity corresponding to the specified id and an error
 func (s *TagStore) GetEntity(entityID string) (*types.Entity, error) {
-	tags, err := s.GetEntityTags(entityID)
-	if err != nil {
-		return nil, err
+	shard := s.store.shardFor(entityID)
+	// toEntity can update the tags cache, hence the write lock
+	shard.Lock()
+	defer shard.Unlock()
+
+	storedTags, present := shard.entities[entityID]
+	if !present {
+		return nil, ErrNotFound
 	}
+	storedTags.touch(s.clock.Now())
 
-	entity := tags.toEntity()
+	entity := storedTags.toEntity()
 	return &entity, nil
 }
@@ -0,0 +1,109 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package tagstore
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/DataDog/datadog-agent/pkg/tagger/collectors"
+	"github.com/DataDog/datadog-agent/pkg/tagset"
+)
+
+const (
+	benchReaders  = 100
+	benchWriters  = 10
+	benchEntities = 10000
+	benchOps      = 100
+)
+
+type benchStore interface {
+	ProcessTagInfo([]*collectors.TagInfo)
+	LookupHashed(string, collectors.TagCardinality) tagset.HashedTags
+}
+
+// globalLockStore serializes every access to the underlying store behind a
+// single lock, to reproduce the locking strategy used before sharding.
+type globalLockStore struct {
+	sync.RWMutex
+	store *TagStore
+}
+
+func (g *globalLockStore) ProcessTagInfo(tagInfos []*collectors.TagInfo) {
+	g.Lock()
+	defer g.Unlock()
+	g.store.ProcessTagInfo(tagInfos)
+}
+
+func (g *globalLockStore) LookupHashed(entity string, cardinality collectors.TagCardinality) tagset.HashedTags {
+	g.RLock()
+	defer g.RUnlock()
+	return g.store.LookupHashed(entity, cardinality)
+}
+
+// BenchmarkTagStoreLocking compares the throughput of a mixed read/write
+// workload between a single global lock and the sharded locks.
+func BenchmarkTagStoreLocking(b *testing.B) {
+	entityIDs := make([]string, 0, benchEntities)
+	for i := 0; i < benchEntities; i++ {
+		entityIDs = append(entityIDs, fmt.Sprintf("container_id://%d", i))
+	}
+
+	for name, newStore := range map[string]func() benchStore{
+		"global lock": func() benchStore {
+			return &globalLockStore{store: NewTagStore()}
+		},
+		"sharded locks": func() benchStore {
+			return NewTagStore()
+		},
+	} {
+		b.Run(name, func(b *testing.B) {
+			store := newStore()
+			for _, id := range entityIDs {
+				store.ProcessTagInfo([]*collectors.TagInfo{benchTagInfo(id, 0)})
+			}
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				var wg sync.WaitGroup
+				wg.Add(benchReaders + benchWriters)
+
+				for r := 0; r < benchReaders; r++ {
+					go func(r int) {
+						defer wg.Done()
+						for op := 0; op < benchOps; op++ {
+							id := entityIDs[(r*benchOps+op)%benchEntities]
+							store.LookupHashed(id, collectors.HighCardinality)
+						}
+					}(r)
+				}
+
+				for w := 0; w < benchWriters; w++ {
+					go func(w int) {
+						defer wg.Done()
+						for op := 0; op < benchOps; op++ {
+							id := entityIDs[(w*benchOps+op)%benchEntities]
+							store.ProcessTagInfo([]*collectors.TagInfo{benchTagInfo(id, i)})
+						}
+					}(w)
+				}
+
+				wg.Wait()
+			}
+		})
+	}
+}
+
+func benchTagInfo(entityID string, generation int) *collectors.TagInfo {
+	return &collectors.TagInfo{
+		Source:               "source",
+		Entity:               entityID,
+		LowCardTags:          []string{"low:tag", fmt.Sprintf("generation:%d", generation)},
+		OrchestratorCardTags: []string{"orchestrator:tag"},
+		HighCardTags:         []string{"high:tag"},
+	}
+}
@@ -6,15 +6,18 @@
 package tagstore
 
 import (
+	"fmt"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/benbjohnson/clock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/DataDog/datadog-agent/pkg/tagger/collectors"
+	"github.com/DataDog/datadog-agent/pkg/tagger/telemetry"
 	"github.com/DataDog/datadog-agent/pkg/tagger/types"
 )
 
@@ -47,8 +50,10 @@ func (s *StoreTestSuite) TestIngest() {
 		},
 	})
 
-	assert.Len(s.T(), s.store.store, 1)
-	assert.Len(s.T(), s.store.store["test"].sourceTags, 2)
+	assert.Equal(s.T(), 1, s.store.store.len())
+	storedTags, err := s.store.GetEntityTags("test")
+	assert.NoError(s.T(), err)
+	assert.Len(s.T(), storedTags.sourceTags, 2)
 }
 
 func (s *StoreTestSuite) TestLookup() {
@@ -75,9 +80,10 @@ func (s *StoreTestSuite) TestLookup() {
 	tagsOrch := s.store.Lookup("test", collectors.OrchestratorCardinality)
 	tagsLow := s.store.Lookup("test", collectors.LowCardinality)
 
-	assert.Len(s.T(), tagsHigh, 4)
-	assert.Len(s.T(), tagsLow, 2)
-	assert.Len(s.T(), tagsOrch, 3)
+	// the tag is only kept once, at the lowest cardinality it's reported at
+	assert.Equal(s.T(), []string{"tag"}, tagsHigh)
+	assert.Equal(s.T(), []string{"tag"}, tagsLow)
+	assert.Equal(s.T(), []string{"tag"}, tagsOrch)
 }
 
 func (s *StoreTestSuite) TestLookupStandard() {
@@ -143,11 +149,11 @@ func (s *StoreTestSuite) TestPrune__deletedEntities() {
 
 	// Data should still be in the store
 	tagsHigh := s.store.Lookup("test1", collectors.HighCardinality)
-	assert.Len(s.T(), tagsHigh, 4)
+	assert.Len(s.T(), tagsHigh, 2)
 	tagsOrch := s.store.Lookup("test1", collectors.OrchestratorCardinality)
-	assert.Len(s.T(), tagsOrch, 2)
+	assert.Len(s.T(), tagsOrch, 1)
 	tagsHigh = s.store.Lookup("test2", collectors.HighCardinality)
-	assert.Len(s.T(), tagsHigh, 2)
+	assert.Len(s.T(), tagsHigh, 1)
 
 	s.clock.Add(10 * time.Minute)
 	s.store.Prune()
@@ -160,7 +166,7 @@ func (s *StoreTestSuite) TestPrune__deletedEntities() {
 
 	// test2 should still be present
 	tagsHigh = s.store.Lookup("test2", collectors.HighCardinality)
-	assert.Len(s.T(), tagsHigh, 2)
+	assert.Len(s.T(), tagsHigh, 1)
 
 	s.store.ProcessTagInfo([]*collectors.TagInfo{
 		// re-add tags from removed source, then remove another one
@@ -183,7 +189,7 @@ func (s *StoreTestSuite) TestPrune__deletedEntities() {
 	tagsHigh = s.store.Lookup("test1", collectors.HighCardinality)
 	assert.Len(s.T(), tagsHigh, 1)
 	tagsHigh = s.store.Lookup("test2", collectors.HighCardinality)
-	assert.Len(s.T(), tagsHigh, 2)
+	assert.Len(s.T(), tagsHigh, 1)
 }
 
 func (s *StoreTestSuite) TestPrune__emptyEntries() {
@@ -222,15 +228,15 @@ func (s *StoreTestSuite) TestPrune__emptyEntries() {
 		},
 	})
 
-	assert.Len(s.T(), s.store.store, 5)
+	assert.Equal(s.T(), 5, s.store.store.len())
 	s.store.Prune()
-	assert.Len(s.T(), s.store.store, 3)
+	assert.Equal(s.T(), 3, s.store.store.len())
 
 	// Assert non-empty tags aren't deleted
 	tagsHigh := s.store.Lookup("test1", collectors.HighCardinality)
-	assert.Len(s.T(), tagsHigh, 3)
+	assert.Len(s.T(), tagsHigh, 1)
 	tagsOrch := s.store.Lookup("test1", collectors.OrchestratorCardinality)
-	assert.Len(s.T(), tagsOrch, 2)
+	assert.Len(s.T(), tagsOrch, 1)
 	tagsHigh = s.store.Lookup("test2", collectors.HighCardinality)
 	assert.Len(s.T(), tagsHigh, 1)
 	tagsLow := s.store.Lookup("test3", collectors.LowCardinality)
@@ -346,6 +352,89 @@ func TestDuplicateSourceTags(t *testing.T) {
 	assert.ElementsMatch(t, tags, []string{"foo", "bar", "tag1:sourceClusterLow", "tag2:sourceHigh", "tag3:sourceClusterHigh"})
 }
 
+func TestDeduplicateSourceTags(t *testing.T) {
+	etags := newEntityTags("deadbeef")
+
+	// sources with the same priority
+	collectors.CollectorPriorities = map[string]collectors.CollectorPriority{
+		"sourceTask":      collectors.NodeOrchestrator,
+		"sourceContainer": collectors.NodeOrchestrator,
+	}
+
+	etags.sourceTags["sourceTask"] = sourceTags{
+		lowCardTags:  []string{"task_family:redis", "task_version:2"},
+		highCardTags: []string{"task_arn:foo"},
+	}
+	etags.sourceTags["sourceContainer"] = sourceTags{
+		lowCardTags:  []string{"task_family:redis", "image_name:redis"},
+		highCardTags: []string{"task_arn:foo", "container_id:bar"},
+	}
+	etags.cacheValid = false
+
+	tags := etags.get(collectors.HighCardinality)
+	assert.ElementsMatch(t, []string{"task_family:redis", "task_version:2", "image_name:redis", "task_arn:foo", "container_id:bar"}, tags)
+	tags = etags.get(collectors.LowCardinality)
+	assert.ElementsMatch(t, []string{"task_family:redis", "task_version:2", "image_name:redis"}, tags)
+}
+
+func TestDeduplicateSourceTagsAcrossCardinalities(t *testing.T) {
+	etags := newEntityTags("deadbeef")
+
+	collectors.CollectorPriorities = map[string]collectors.CollectorPriority{
+		"sourceA": collectors.NodeOrchestrator,
+		"sourceB": collectors.NodeOrchestrator,
+	}
+
+	// whichever source is merged first, the tag is kept at low cardinality
+	etags.sourceTags["sourceA"] = sourceTags{highCardTags: []string{"task_family:redis"}}
+	etags.sourceTags["sourceB"] = sourceTags{lowCardTags: []string{"task_family:redis"}}
+	etags.cacheValid = false
+
+	entity := etags.toEntity()
+	assert.Equal(t, []string{"task_family:redis"}, entity.LowCardinalityTags)
+	assert.Empty(t, entity.OrchestratorCardinalityTags)
+	assert.Empty(t, entity.HighCardinalityTags)
+
+	etags.sourceTags["sourceA"] = sourceTags{lowCardTags: []string{"task_family:redis"}}
+	etags.sourceTags["sourceB"] = sourceTags{orchestratorCardTags: []string{"task_family:redis"}}
+	etags.cacheValid = false
+
+	entity = etags.toEntity()
+	assert.Equal(t, []string{"task_family:redis"}, entity.LowCardinalityTags)
+	assert.Empty(t, entity.OrchestratorCardinalityTags)
+	assert.Empty(t, entity.HighCardinalityTags)
+}
+
+func TestDeduplicatedTagsTelemetry(t *testing.T) {
+	etags := newEntityTags("deadbeef")
+	collectors.CollectorPriorities = map[string]collectors.CollectorPriority{
+		"sourceTask":      collectors.NodeOrchestrator,
+		"sourceContainer": collectors.NodeOrchestrator,
+	}
+	deduplicatedTags := func() float64 {
+		return telemetry.DeduplicatedTags.WithValues().Get()
+	}
+	initial := deduplicatedTags()
+
+	etags.sourceTags["sourceTask"] = sourceTags{lowCardTags: []string{"task_family:redis"}}
+	etags.sourceTags["sourceContainer"] = sourceTags{lowCardTags: []string{"task_family:redis", "image_name:redis"}}
+	etags.cacheValid = false
+	etags.get(collectors.LowCardinality)
+	assert.Equal(t, initial+1, deduplicatedTags())
+
+	// a change that doesn't introduce a duplicate
+	etags.sourceTags["sourceContainer"] = sourceTags{lowCardTags: []string{"task_family:redis", "image_name:redis:7"}}
+	etags.cacheValid = false
+	etags.get(collectors.LowCardinality)
+	assert.Equal(t, initial+1, deduplicatedTags())
+
+	// a new duplicate
+	etags.sourceTags["sourceTask"] = sourceTags{lowCardTags: []string{"task_family:redis", "image_name:redis:7"}}
+	etags.cacheValid = false
+	etags.get(collectors.LowCardinality)
+	assert.Equal(t, initial+2, deduplicatedTags())
+}
+
 type entityEventExpectation struct {
 	eventType    types.EventType
 	id           string
@@ -470,3 +559,199 @@ func checkEvents(t *testing.T, expectations []entityEventExpectation, events []t
 		}
 	}
 }
+
+func TestConcurrentAccess(t *testing.T) {
+	store := NewTagStore()
+
+	entityIDs := make([]string, 0, 50)
+	for i := 0; i < 50; i++ {
+		entityIDs = append(entityIDs, fmt.Sprintf("container_id://%d", i))
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < 5; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for _, id := range entityIDs {
+				store.ProcessTagInfo([]*collectors.TagInfo{
+					{
+						Source:       fmt.Sprintf("writer%d", w),
+						Entity:       id,
+						LowCardTags:  []string{fmt.Sprintf("writer%d:true", w)},
+						StandardTags: []string{"env:test"},
+					},
+				})
+			}
+		}(w)
+	}
+
+	for r := 0; r < 20; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, id := range entityIDs {
+				store.Lookup(id, collectors.HighCardinality)
+				_, _ = store.LookupStandard(id)
+				_, _ = store.GetEntity(id)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	assert.Equal(t, len(entityIDs), store.store.len())
+	for _, id := range entityIDs {
+		tags := store.Lookup(id, collectors.LowCardinality)
+		assert.ElementsMatch(t, []string{"writer0:true", "writer1:true", "writer2:true", "writer3:true", "writer4:true"}, tags)
+	}
+}
+
+func TestDumpMemory(t *testing.T) {
+	store := NewTagStore()
+	store.ProcessTagInfo([]*collectors.TagInfo{
+		{
+			Source:               "source1",
+			Entity:               "small",
+			LowCardTags:          []string{"low:tag"},
+			OrchestratorCardTags: []string{"orch:tag"},
+		},
+		{
+			Source:       "source1",
+			Entity:       "large",
+			LowCardTags:  []string{"low:tag", "other_low:tag"},
+			HighCardTags: []string{"high:tag"},
+		},
+		{
+			Source:       "source2",
+			Entity:       "large",
+			StandardTags: []string{"env:prod"},
+			LowCardTags:  []string{"env:prod"},
+		},
+	})
+
+	dump := store.DumpMemory()
+	require.Len(t, dump.Entities, 2)
+
+	// entities are sorted by decreasing memory estimate
+	large, small := dump.Entities[0], dump.Entities[1]
+	assert.Equal(t, "large", large.EntityID)
+	assert.Equal(t, 2, large.Sources)
+	assert.Equal(t, map[string]int{"low": 3, "orchestrator": 0, "high": 1, "standard": 1}, large.TagCounts)
+
+	assert.Equal(t, "small", small.EntityID)
+	assert.Equal(t, 1, small.Sources)
+	assert.Equal(t, map[string]int{"low": 1, "orchestrator": 1, "high": 0, "standard": 0}, small.TagCounts)
+
+	assert.Greater(t, large.MemoryEstimate, small.MemoryEstimate)
+	assert.Greater(t, small.MemoryEstimate, 0)
+	assert.Equal(t, large.MemoryEstimate+small.MemoryEstimate, dump.TotalMemoryEstimate)
+}
+
+func (s *StoreTestSuite) TestEvictLeastRecentlyUsed() {
+	s.store.maxEntities = 3
+
+	ch := s.store.Subscribe(collectors.LowCardinality)
+	defer s.store.Unsubscribe(ch)
+
+	addEntity := func(entityID string) {
+		s.clock.Add(time.Second)
+		s.store.ProcessTagInfo([]*collectors.TagInfo{
+			{
+				Source:      "source",
+				Entity:      entityID,
+				LowCardTags: []string{"entity:" + entityID},
+			},
+		})
+	}
+
+	addEntity("test1")
+	addEntity("test2")
+	addEntity("test3")
+	assert.Equal(s.T(), 3, s.store.store.len())
+
+	// test1 is accessed, test2 becomes the least recently used entity
+	s.clock.Add(time.Second)
+	assert.Equal(s.T(), []string{"entity:test1"}, s.store.Lookup("test1", collectors.LowCardinality))
+
+	addEntity("test4")
+	assert.Equal(s.T(), 3, s.store.store.len())
+	assert.Empty(s.T(), s.store.Lookup("test2", collectors.LowCardinality))
+
+	// updating test3 counts as an access, test1 is now the oldest
+	s.clock.Add(time.Second)
+	s.store.ProcessTagInfo([]*collectors.TagInfo{
+		{
+			Source:      "source",
+			Entity:      "test3",
+			LowCardTags: []string{"entity:test3", "updated:true"},
+		},
+	})
+
+	addEntity("test5")
+	assert.Equal(s.T(), 3, s.store.store.len())
+	assert.Empty(s.T(), s.store.Lookup("test1", collectors.LowCardinality))
+
+	for _, entityID := range []string{"test3", "test4", "test5"} {
+		assert.NotEmpty(s.T(), s.store.Lookup(entityID, collectors.LowCardinality), entityID)
+	}
+
+	var deleted []string
+	for len(ch) > 0 {
+		for _, event := range <-ch {
+			if event.EventType == types.EventTypeDeleted {
+				deleted = append(deleted, event.Entity.ID)
+			}
+		}
+	}
+	assert.Equal(s.T(), []string{"test2", "test1"}, deleted)
+}
+
+func (s *StoreTestSuite) TestEvictLeastRecentlyUsed_batch() {
+	s.store.maxEntities = 20
+
+	addEntity := func(i int) {
+		s.clock.Add(time.Second)
+		s.store.ProcessTagInfo([]*collectors.TagInfo{
+			{
+				Source:      "source",
+				Entity:      fmt.Sprintf("test%d", i),
+				LowCardTags: []string{"tag"},
+			},
+		})
+	}
+
+	for i := 0; i < 20; i++ {
+		addEntity(i)
+	}
+	assert.Equal(s.T(), 20, s.store.store.len())
+
+	// going over the limit evicts the 3 oldest entities, down to 18
+	addEntity(20)
+	assert.Equal(s.T(), 18, s.store.store.len())
+	for i := 0; i < 3; i++ {
+		assert.Empty(s.T(), s.store.Lookup(fmt.Sprintf("test%d", i), collectors.LowCardinality))
+	}
+
+	// no eviction until the limit is exceeded again
+	addEntity(21)
+	addEntity(22)
+	assert.Equal(s.T(), 20, s.store.store.len())
+	assert.NotEmpty(s.T(), s.store.Lookup("test3", collectors.LowCardinality))
+}
+
+func (s *StoreTestSuite) TestEvictLeastRecentlyUsed_disabled() {
+	s.store.maxEntities = 0
+
+	for i := 0; i < 10; i++ {
+		s.store.ProcessTagInfo([]*collectors.TagInfo{
+			{
+				Source:      "source",
+				Entity:      fmt.Sprintf("test%d", i),
+				LowCardTags: []string{"tag"},
+			},
+		})
+	}
+
+	assert.Equal(s.T(), 10, s.store.store.len())
+}
@@ -37,6 +37,19 @@ var (
 		[]string{}, "Number of pruned tagger entities.",
 		telemetry.Options{NoDoubleUnderscoreSep: true})
 
+	// Evictions tracks the number of tagger entities evicted because the
+	// store reached its maximum number of entities.
+	Evictions = telemetry.NewCounterWithOpts(subsystem, "evictions",
+		[]string{}, "Number of tagger entities evicted when the store is full.",
+		telemetry.Options{NoDoubleUnderscoreSep: true})
+
+	// DeduplicatedTags tracks the number of duplicated tags dropped when
+	// merging the tags reported by the sources of an entity. A duplicate
+	// is counted once, when a change of the sources introduces it.
+	DeduplicatedTags = telemetry.NewCounterWithOpts(subsystem, "deduplicated_tags",
+		[]string{}, "Number of duplicated tags dropped when merging the tags of an entity.",
+		telemetry.Options{NoDoubleUnderscoreSep: true})
+
 	// queries tracks the number of queries made against the tagger.
 	queries = telemetry.NewCounterWithOpts(subsystem, "queries",
 		[]string{"cardinality", "status"}, "Queries made against the tagger.",
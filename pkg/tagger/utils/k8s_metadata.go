@@ -37,20 +37,58 @@ func InitMetadataAsTags(metadataAsTags map[string]string) (map[string]string, ma
 }
 
 // AddMetadataAsTags converts name and value into tags based on the metadata as tags configuration and patterns
-func AddMetadataAsTags(name, value string, metadataAsTags map[string]string, glob map[string]glob.Glob, tags *TagList) {
-	for pattern, tmpl := range metadataAsTags {
-		n := strings.ToLower(name)
-		if g, ok := glob[pattern]; ok {
-			if !g.Match(n) {
-				continue
-			}
-		} else if pattern != n {
+// An exact match is always applied. When several patterns match the name, only
+// the most specific one is applied in addition:
+// - a longer pattern (e.g. `app.*`) takes precedence over a shorter one (e.g. `*`)
+// - patterns of the same length are ordered alphabetically
+func AddMetadataAsTags(name, value string, metadataAsTags map[string]string, globs map[string]glob.Glob, tags *TagList) {
+	n := strings.ToLower(name)
+	if tmpl, ok := metadataAsTags[n]; ok {
+		if _, isGlob := globs[n]; !isGlob {
+			addMetadataAsTag(tmpl, name, value, tags)
+		}
+	}
+
+	matched := ""
+	for pattern, g := range globs {
+		if !g.Match(n) {
 			continue
 		}
-		tags.AddAuto(resolveTag(tmpl, name), value)
+		if matched == "" || isMoreSpecificPattern(pattern, matched) {
+			matched = pattern
+		}
+	}
+	if matched != "" {
+		addMetadataAsTag(metadataAsTags[matched], name, value, tags)
 	}
 }
 
+// addMetadataAsTag adds the tag described by the template. When the template
+// embeds the value with %%value%%, a tag without value is added instead, e.g.
+// `team_%%value%%` adds `team_platform` for the value `platform`.
+func addMetadataAsTag(tmpl, name, value string, tags *TagList) {
+	if strings.Contains(tmpl, valueTemplateVariable) {
+		if value == "" {
+			return
+		}
+		tags.AddAutoBare(resolveTag(strings.ReplaceAll(tmpl, valueTemplateVariable, value), name))
+		return
+	}
+	tags.AddAuto(resolveTag(tmpl, name), value)
+}
+
+// isMoreSpecificPattern returns whether pattern a takes precedence over pattern b
+func isMoreSpecificPattern(a, b string) bool {
+	if len(a) != len(b) {
+		return len(a) > len(b)
+	}
+	return a < b
+}
+
+// valueTemplateVariable is replaced by the value of the label, annotation or
+// env var, making the tag name itself carry the value
+const valueTemplateVariable = "%%value%%"
+
 var templateVariables = map[string]struct{}{
 	"label":      {},
 	"annotation": {},
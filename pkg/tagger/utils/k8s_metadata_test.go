@@ -97,6 +97,90 @@ func TestMetadataAsTags(t *testing.T) {
 			metadataAsTags: map[string]string{"*": "%%env%%"},
 			want:           []string{"foo:bar"},
 		},
+		{
+			name:           "prefix wildcard",
+			k:              "app.team",
+			v:              "bar",
+			metadataAsTags: map[string]string{"app.*": "app_%%label%%"},
+			want:           []string{"app_app.team:bar"},
+		},
+		{
+			name:           "prefix wildcard not matching",
+			k:              "team",
+			v:              "bar",
+			metadataAsTags: map[string]string{"app.*": "app_%%label%%"},
+			want:           []string{},
+		},
+		{
+			name:           "prefix wildcard takes precedence over match all",
+			k:              "app.team",
+			v:              "bar",
+			metadataAsTags: map[string]string{"*": "all_%%label%%", "app.*": "app_%%label%%"},
+			want:           []string{"app_app.team:bar"},
+		},
+		{
+			name:           "match all used when prefix wildcard does not match",
+			k:              "team",
+			v:              "bar",
+			metadataAsTags: map[string]string{"*": "all_%%label%%", "app.*": "app_%%label%%"},
+			want:           []string{"all_team:bar"},
+		},
+		{
+			name:           "longest prefix wildcard takes precedence",
+			k:              "app.team.name",
+			v:              "bar",
+			metadataAsTags: map[string]string{"app.*": "app_%%label%%", "app.team.*": "team_%%label%%"},
+			want:           []string{"team_app.team.name:bar"},
+		},
+		{
+			name:           "same length patterns are ordered alphabetically",
+			k:              "abc",
+			v:              "bar",
+			metadataAsTags: map[string]string{"ab*": "first", "a*c": "second"},
+			want:           []string{"second:bar"},
+		},
+		{
+			name:           "exact match applied with match all",
+			k:              "app",
+			v:              "bar",
+			metadataAsTags: map[string]string{"*": "prefix_%%label%%", "app": "application"},
+			want:           []string{"prefix_app:bar", "application:bar"},
+		},
+		{
+			name:           "exact match applied with the most specific wildcard",
+			k:              "app.team",
+			v:              "bar",
+			metadataAsTags: map[string]string{"*": "all_%%label%%", "app.*": "app_%%label%%", "app.team": "team"},
+			want:           []string{"app_app.team:bar", "team:bar"},
+		},
+		{
+			name:           "value tpl var",
+			k:              "owner.team",
+			v:              "platform",
+			metadataAsTags: map[string]string{"owner.team": "team_%%value%%"},
+			want:           []string{"team_platform"},
+		},
+		{
+			name:           "value tpl var with label tpl var",
+			k:              "owner.team",
+			v:              "platform",
+			metadataAsTags: map[string]string{"owner.*": "%%annotation%%_%%value%%"},
+			want:           []string{"owner.team_platform"},
+		},
+		{
+			name:           "value tpl var with empty value",
+			k:              "owner.team",
+			v:              "",
+			metadataAsTags: map[string]string{"owner.team": "team_%%value%%"},
+			want:           []string{},
+		},
+		{
+			name:           "case insensitive prefix wildcard",
+			k:              "App.Team",
+			v:              "bar",
+			metadataAsTags: map[string]string{"APP.*": "app_%%label%%"},
+			want:           []string{"app_App.Team:bar"},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -84,6 +84,21 @@ func (l *TagList) AddAuto(name, value string) {
 	l.AddLow(name, value)
 }
 
+// AddAutoBare adds a tag without value, such as `team_platform`. Like AddAuto,
+// the tag has a high cardinality if it starts with '+' character and a low
+// cardinality otherwise. It will skip empty tags.
+func (l *TagList) AddAutoBare(tag string) {
+	target := l.lowCardTags
+	if strings.HasPrefix(tag, "+") {
+		target = l.highCardTags
+		tag = tag[1:]
+	}
+	if tag == "" {
+		return
+	}
+	target[tag] = true
+}
+
 // Compute returns four string arrays in the format "tag:value"
 // - low cardinality
 // - orchestrator cardinality
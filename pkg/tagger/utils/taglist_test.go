@@ -92,6 +92,18 @@ func TestAddHighOrLow(t *testing.T) {
 	require.False(t, list.highCardTags["empty"])
 }
 
+func TestAddAutoBare(t *testing.T) {
+	list := NewTagList()
+	list.AddAutoBare("team_platform")
+	list.AddAutoBare("+owner_jdoe")
+	list.AddAutoBare("+")
+	list.AddAutoBare("")
+	require.Len(t, list.lowCardTags, 1)
+	require.Len(t, list.highCardTags, 1)
+	require.True(t, list.lowCardTags["team_platform"])
+	require.True(t, list.highCardTags["owner_jdoe"])
+}
+
 func TestAddStandard(t *testing.T) {
 	list := NewTagList()
 	list.splitList = map[string]string{
@@ -52,6 +52,7 @@ type DCAClientInterface interface {
 	GetNodeLabels(nodeName string) (map[string]string, error)
 	GetNodeAnnotations(nodeName string) (map[string]string, error)
 	GetNamespaceLabels(nsName string) (map[string]string, error)
+	GetNamespaceAnnotations(nsName string) (map[string]string, error)
 	GetPodsMetadataForNode(nodeName string) (apiv1.NamespacesPodsStringsSet, error)
 	GetKubernetesMetadataNames(nodeName, ns, podName string) ([]string, error)
 	GetCFAppsMetadataForNode(nodename string) (map[string][]string, error)
@@ -388,6 +389,13 @@ func (c *DCAClient) GetNamespaceLabels(nsName string) (map[string]string, error)
 	return result, err
 }
 
+// GetNamespaceAnnotations returns the namespace annotations setting the standard tags of its pods from the Cluster Agent.
+func (c *DCAClient) GetNamespaceAnnotations(nsName string) (map[string]string, error) {
+	var result map[string]string
+	err := c.doJSONQuery(context.TODO(), "api/v1/annotations/namespace/"+nsName, "GET", nil, &result, false)
+	return result, err
+}
+
 // GetNodeAnnotations returns the node annotations from the Cluster Agent.
 func (c *DCAClient) GetNodeAnnotations(nodeName string) (map[string]string, error) {
 	var result map[string]string
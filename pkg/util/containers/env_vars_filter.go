@@ -24,6 +24,12 @@ var (
 		"NOMAD_GROUP_NAME",
 		"NOMAD_NAMESPACE",
 		"NOMAD_DC",
+		"K_SERVICE",
+		"K_REVISION",
+		"K_CONFIGURATION",
+		"ACI_CONTAINER_GROUP",
+		"ACI_CONTAINER_NAME",
+		"WEBSITE_SITE_NAME",
 		"MESOS_TASK_ID",
 		"ECS_CONTAINER_METADATA_URI",
 		"ECS_CONTAINER_METADATA_URI_V4",
@@ -16,6 +16,8 @@ type Task struct {
 	Limits                map[string]float64 `json:"Limits"`
 	DesiredStatus         string             `json:"DesiredStatus"`
 	AvailabilityZone      string             `json:"AvailabilityZone"`
+	StopCode              string             `json:"StopCode,omitempty"`              // present only once the task is stopping
+	StoppedReason         string             `json:"StoppedReason,omitempty"`         // present only once the task is stopping
 	ContainerInstanceTags map[string]string  `json:"ContainerInstanceTags,omitempty"` // undocumented
 	TaskTags              map[string]string  `json:"TaskTags,omitempty"`              // undocumented
 }
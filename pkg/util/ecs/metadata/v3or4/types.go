@@ -15,7 +15,9 @@ type Task struct {
 	Version               string             `json:"Revision"`
 	Limits                map[string]float64 `json:"Limits,omitempty"`
 	DesiredStatus         string             `json:"DesiredStatus"`
-	LaunchType            string             `json:"LaunchType,omitempty"` // present only in v4
+	LaunchType            string             `json:"LaunchType,omitempty"`    // present only in v4
+	StopCode              string             `json:"StopCode,omitempty"`      // present only once the task is stopping
+	StoppedReason         string             `json:"StoppedReason,omitempty"` // present only once the task is stopping
 	ContainerInstanceTags map[string]string  `json:"ContainerInstanceTags,omitempty"`
 	TaskTags              map[string]string  `json:"TaskTags,omitempty"`
 }
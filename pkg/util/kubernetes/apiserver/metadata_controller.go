@@ -321,6 +321,23 @@ func GetPodMetadataNames(nodeName, ns, podName string) ([]string, error) {
 
 // GetNamespaceLabels retrieves the labels of the queried namespace from the cache of the shared informer.
 func GetNamespaceLabels(nsName string) (map[string]string, error) {
+	ns, err := getNamespace(nsName)
+	if err != nil {
+		return nil, err
+	}
+	return ns.Labels, nil
+}
+
+// GetNamespaceAnnotations retrieves the annotations of the queried namespace from the cache of the shared informer.
+func GetNamespaceAnnotations(nsName string) (map[string]string, error) {
+	ns, err := getNamespace(nsName)
+	if err != nil {
+		return nil, err
+	}
+	return ns.Annotations, nil
+}
+
+func getNamespace(nsName string) (*corev1.Namespace, error) {
 	if !config.Datadog.GetBool("kubernetes_collect_metadata_tags") {
 		return nil, log.Errorf("Metadata collection is disabled on the Cluster Agent")
 	}
@@ -337,5 +354,5 @@ func GetNamespaceLabels(nsName string) (map[string]string, error) {
 	if ns == nil {
 		return nil, fmt.Errorf("cannot get namespace %s from the informer's cache", nsName)
 	}
-	return ns.Labels, nil
+	return ns, nil
 }
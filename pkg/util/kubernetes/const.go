@@ -38,6 +38,22 @@ const (
 	// RcRevisionTagName is the key of the RC revision tag
 	RcRevisionTagName = "dd_remote_config_rev"
 
+	// PodSecurityPolicyAnnotKey is the key of the annotation set by the PSP admission controller
+	PodSecurityPolicyAnnotKey = "kubernetes.io/psp"
+
+	// PodSecurityPolicyTagName is the key of the pod security policy tag
+	PodSecurityPolicyTagName = "kube_pod_security_policy"
+
+	// HelmReleaseNameAnnotKey is the key of the annotation set by Helm with the release name
+	HelmReleaseNameAnnotKey = "meta.helm.sh/release-name"
+	// HelmReleaseNamespaceAnnotKey is the key of the annotation set by Helm with the release namespace
+	HelmReleaseNamespaceAnnotKey = "meta.helm.sh/release-namespace"
+
+	// HelmReleaseTagName is the key of the Helm release name tag
+	HelmReleaseTagName = "helm_release"
+	// HelmReleaseNamespaceTagName is the key of the Helm release namespace tag
+	HelmReleaseNamespaceTagName = "helm_release_namespace"
+
 	// EnvTagEnvVar is the environment variable of the env standard tag
 	EnvTagEnvVar = "DD_ENV"
 	// ServiceTagEnvVar is the environment variable of the service standard tag
@@ -45,9 +61,31 @@ const (
 	// VersionTagEnvVar is the environment variable of the version standard tag
 	VersionTagEnvVar = "DD_VERSION"
 
+	// ClusterEnvAnnotKey is the namespace annotation key of the env standard tag of its pods
+	ClusterEnvAnnotKey = "ad.datadoghq.com/cluster.env"
+	// ClusterServiceAnnotKey is the namespace annotation key of the service standard tag of its pods
+	ClusterServiceAnnotKey = "ad.datadoghq.com/cluster.service"
+	// ClusterVersionAnnotKey is the namespace annotation key of the version standard tag of its pods
+	ClusterVersionAnnotKey = "ad.datadoghq.com/cluster.version"
+
 	// KubeNodeRoleTagName is the role label tag name
 	KubeNodeRoleTagName = "kube_node_role"
 
+	// TopologyZoneLabelKey is the node label key of the zone the node runs in
+	TopologyZoneLabelKey = "topology.kubernetes.io/zone"
+	// LegacyTopologyZoneLabelKey is the deprecated node label key of the zone the node runs in
+	LegacyTopologyZoneLabelKey = "failure-domain.beta.kubernetes.io/zone"
+	// TopologyZoneTagName is the tag name of the zone a pod is spread across
+	TopologyZoneTagName = "kube_topology_zone"
+
+	// VirtualNodeTagName is the tag name of the virtual node provider a pod is scheduled on
+	VirtualNodeTagName = "kube_virtual_node"
+
+	// NodeReadyTagName is the tag name of the Ready condition of the node a pod is scheduled on
+	NodeReadyTagName = "kube_node_ready"
+	// NodeDiskPressureTagName is the tag name of the DiskPressure condition of the node a pod is scheduled on
+	NodeDiskPressureTagName = "kube_node_disk_pressure"
+
 	// PodKind represents the Pod object kind
 	PodKind = "Pod"
 	// DeploymentKind represents the Deployment object kind
@@ -91,6 +129,8 @@ const (
 	NamespaceTagName = "kube_namespace"
 	// PersistentVolumeClaimTagName represents the PersistentVolumeClaim tag name
 	PersistentVolumeClaimTagName = "persistentvolumeclaim"
+	// SecretTagName represents the Secret tag name
+	SecretTagName = "kube_secret"
 
 	// ResourceNameTagName represents any resource tag name
 	ResourceNameTagName = "kube_resource_name"
@@ -106,6 +146,9 @@ const (
 	CriContainerNamespaceLabel = "io.kubernetes.pod.namespace"
 )
 
+// NamespaceStandardTagsAnnotKeys are the namespace annotation keys setting the standard tags of its pods
+var NamespaceStandardTagsAnnotKeys = []string{ClusterEnvAnnotKey, ClusterServiceAnnotKey, ClusterVersionAnnotKey}
+
 // KindToTagName returns the tag name for a given kubernetes object name
 var KindToTagName = map[string]string{
 	PodKind:                   PodTagName,
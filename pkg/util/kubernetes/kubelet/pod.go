@@ -9,6 +9,7 @@ package kubelet
 
 import (
 	"encoding/json"
+	"time"
 
 	"github.com/DataDog/datadog-agent/pkg/util/log"
 )
@@ -61,3 +62,90 @@ func (p *Pod) GetPersistentVolumeClaimNames() []string {
 	}
 	return pvcs
 }
+
+// GetConditions returns the status of the pod conditions, indexed by condition type
+func (p *Pod) GetConditions() map[string]bool {
+	if len(p.Status.Conditions) == 0 {
+		return nil
+	}
+
+	conditions := make(map[string]bool, len(p.Status.Conditions))
+	for _, condition := range p.Status.Conditions {
+		conditions[condition.Type] = condition.Status == "True"
+	}
+	return conditions
+}
+
+// GetScheduledTime returns the time at which the pod was scheduled on its
+// node, or the zero time if the pod is not scheduled yet
+func (p *Pod) GetScheduledTime() time.Time {
+	for _, condition := range p.Status.Conditions {
+		if condition.Type == "PodScheduled" && condition.Status == "True" {
+			return condition.LastTransitionTime
+		}
+	}
+	return time.Time{}
+}
+
+// GetTopologySpreadKeys returns the topology keys referenced by the topology
+// spread constraints of the pod
+func (p *Pod) GetTopologySpreadKeys() []string {
+	var keys []string
+	for _, constraint := range p.Spec.TopologySpreadConstraints {
+		keys = append(keys, constraint.TopologyKey)
+	}
+	return keys
+}
+
+// GetTolerationKeys returns the unique keys of the tolerations of the pod.
+// Tolerations without a key, which match every taint, are ignored.
+func (p *Pod) GetTolerationKeys() []string {
+	var keys []string
+	seen := make(map[string]struct{})
+	for _, toleration := range p.Spec.Tolerations {
+		if _, found := seen[toleration.Key]; toleration.Key == "" || found {
+			continue
+		}
+		seen[toleration.Key] = struct{}{}
+		keys = append(keys, toleration.Key)
+	}
+	return keys
+}
+
+// GetSecretNames gets the names of the secrets referenced by the pod, either
+// through volumes or container environment variables. Only the names are
+// returned, the secrets themselves are never read.
+func (p *Pod) GetSecretNames() []string {
+	var names []string
+	seen := make(map[string]struct{})
+	add := func(name string) {
+		if _, found := seen[name]; name == "" || found {
+			return
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+
+	for _, volume := range p.Spec.Volumes {
+		if volume.Secret != nil {
+			add(volume.Secret.SecretName)
+		}
+	}
+
+	for _, containers := range [][]ContainerSpec{p.Spec.InitContainers, p.Spec.Containers} {
+		for _, container := range containers {
+			for _, envFrom := range container.EnvFrom {
+				if envFrom.SecretRef != nil {
+					add(envFrom.SecretRef.Name)
+				}
+			}
+			for _, env := range container.Env {
+				if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+					add(env.ValueFrom.SecretKeyRef.Name)
+				}
+			}
+		}
+	}
+
+	return names
+}
@@ -8,8 +8,10 @@
 package kubelet
 
 import (
+	"encoding/json"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -177,3 +179,174 @@ func TestPodGetPersistentVolumeClaimNames(t *testing.T) {
 		})
 	}
 }
+
+func TestPodGetSecretNames(t *testing.T) {
+	for nb, tc := range []struct {
+		pod     *Pod
+		secrets []string
+	}{
+		{
+			pod:     &Pod{},
+			secrets: nil,
+		},
+		{
+			pod: &Pod{
+				Spec: Spec{
+					InitContainers: []ContainerSpec{
+						{
+							Name: "init",
+							EnvFrom: []EnvFromSource{
+								{SecretRef: &SecretEnvSource{Name: "init-secret"}},
+							},
+						},
+					},
+					Containers: []ContainerSpec{
+						{
+							Name: "app",
+							Env: []EnvVar{
+								{Name: "PLAIN", Value: "value"},
+								{Name: "PASSWORD", ValueFrom: &EnvVarSource{SecretKeyRef: &SecretKeySelector{Name: "db-secret"}}},
+							},
+							EnvFrom: []EnvFromSource{
+								{SecretRef: &SecretEnvSource{Name: "db-secret"}},
+							},
+						},
+					},
+					Volumes: []VolumeSpec{
+						{
+							Name:   "certs",
+							Secret: &SecretVolumeSpec{SecretName: "tls-secret"},
+						},
+						{
+							Name: "data",
+							PersistentVolumeClaim: &PersistentVolumeClaimSpec{
+								ClaimName: "data-0",
+							},
+						},
+					},
+				},
+			},
+			secrets: []string{"tls-secret", "init-secret", "db-secret"},
+		},
+	} {
+		t.Run(fmt.Sprintf("case %d", nb), func(t *testing.T) {
+			assert.EqualValues(t, tc.secrets, tc.pod.GetSecretNames())
+		})
+	}
+}
+
+func TestContainerProbeType(t *testing.T) {
+	for _, tc := range []struct {
+		probe        string
+		expectedType string
+	}{
+		{
+			probe:        `{"exec":{"command":["cat","/tmp/healthy"]}}`,
+			expectedType: ProbeTypeExec,
+		},
+		{
+			probe:        `{"httpGet":{"path":"/healthz","port":8080}}`,
+			expectedType: ProbeTypeHTTP,
+		},
+		{
+			probe:        `{"tcpSocket":{"port":8080}}`,
+			expectedType: ProbeTypeTCP,
+		},
+		{
+			probe:        `{"grpc":{"port":2379}}`,
+			expectedType: ProbeTypeGRPC,
+		},
+		{
+			probe:        `{"initialDelaySeconds":5}`,
+			expectedType: "",
+		},
+	} {
+		t.Run(tc.expectedType, func(t *testing.T) {
+			var probe ContainerProbe
+			assert.NoError(t, json.Unmarshal([]byte(tc.probe), &probe))
+			assert.Equal(t, tc.expectedType, probe.Type())
+		})
+	}
+
+	var probe *ContainerProbe
+	assert.Equal(t, "", probe.Type())
+}
+
+func TestPodGetScheduledTime(t *testing.T) {
+	scheduledAt := time.Date(2023, time.October, 2, 12, 30, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name       string
+		conditions []Conditions
+		expected   time.Time
+	}{
+		{
+			name: "scheduled",
+			conditions: []Conditions{
+				{Type: "Initialized", Status: "True", LastTransitionTime: scheduledAt.Add(time.Second)},
+				{Type: "PodScheduled", Status: "True", LastTransitionTime: scheduledAt},
+			},
+			expected: scheduledAt,
+		},
+		{
+			name: "unschedulable",
+			conditions: []Conditions{
+				{Type: "PodScheduled", Status: "False", LastTransitionTime: scheduledAt},
+			},
+			expected: time.Time{},
+		},
+		{
+			name:     "no conditions",
+			expected: time.Time{},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &Pod{Status: Status{Conditions: tc.conditions}}
+			assert.Equal(t, tc.expected, pod.GetScheduledTime())
+		})
+	}
+}
+
+func TestPodGetConditions(t *testing.T) {
+	pod := &Pod{
+		Status: Status{
+			Conditions: []Conditions{
+				{Type: "PodScheduled", Status: "True"},
+				{Type: "Initialized", Status: "True"},
+				{Type: "ContainersReady", Status: "False"},
+				{Type: "Ready", Status: "Unknown"},
+			},
+		},
+	}
+
+	assert.Equal(t, map[string]bool{
+		"PodScheduled":    true,
+		"Initialized":     true,
+		"ContainersReady": false,
+		"Ready":           false,
+	}, pod.GetConditions())
+
+	assert.Nil(t, (&Pod{}).GetConditions())
+}
+
+func TestPodGetTolerationKeys(t *testing.T) {
+	pod := &Pod{
+		Spec: Spec{
+			Tolerations: []TolerationSpec{
+				{Key: "node.kubernetes.io/not-ready"},
+				{Key: "node.kubernetes.io/unreachable"},
+				{Key: "node.kubernetes.io/not-ready"},
+				{Key: ""},
+				{Key: "dedicated"},
+			},
+		},
+	}
+
+	assert.Equal(t, []string{
+		"node.kubernetes.io/not-ready",
+		"node.kubernetes.io/unreachable",
+		"dedicated",
+	}, pod.GetTolerationKeys())
+
+	assert.Nil(t, (&Pod{}).GetTolerationKeys())
+}
@@ -120,6 +120,20 @@ func (w *PodWatcher) computeChanges(podList []*Pod) ([]*Pod, error) {
 			}
 		}
 
+		// Ephemeral containers are not part of GetAllContainers as they
+		// are only used for debugging, but they are usually added to
+		// already running pods, so new ones must trigger an update
+		for _, container := range pod.Status.EphemeralContainers {
+			if container.IsPending() {
+				continue
+			}
+
+			if _, found := w.lastSeen[container.ID]; !found {
+				updatedContainer = true
+			}
+			w.lastSeen[container.ID] = now
+		}
+
 		newLabelsOrAnnotations := false
 		newPhase := false
 		newTagsDigest := digestPodMeta(pod.Metadata)
@@ -116,6 +116,39 @@ func (suite *PodwatcherTestSuite) TestPodWatcherComputeChangesInConditions() {
 	require.True(suite.T(), IsPodReady(changes[0]))
 }
 
+func (suite *PodwatcherTestSuite) TestPodWatcherWithEphemeralContainers() {
+	sourcePods, err := loadPodsFixture("./testdata/podlist_1.8-2.json")
+	require.Nil(suite.T(), err)
+
+	watcher := newWatcher()
+
+	changes, err := watcher.computeChanges(sourcePods)
+	require.Nil(suite.T(), err)
+	require.Len(suite.T(), changes, len(sourcePods))
+
+	// A pending ephemeral container should not trigger
+	sourcePods[0].Status.EphemeralContainers = []ContainerStatus{
+		{
+			Name: "debugger",
+		},
+	}
+	changes, err = watcher.computeChanges(sourcePods)
+	require.Nil(suite.T(), err)
+	require.Len(suite.T(), changes, 0)
+
+	// A running ephemeral container in an existing pod should trigger
+	sourcePods[0].Status.EphemeralContainers[0].ID = "containerd://debugger"
+	changes, err = watcher.computeChanges(sourcePods)
+	require.Nil(suite.T(), err)
+	require.Len(suite.T(), changes, 1)
+	require.Equal(suite.T(), sourcePods[0].Metadata.UID, changes[0].Metadata.UID)
+
+	// Sending the same pod again with no change
+	changes, err = watcher.computeChanges(sourcePods)
+	require.Nil(suite.T(), err)
+	require.Len(suite.T(), changes, 0)
+}
+
 func (suite *PodwatcherTestSuite) TestPodWatcherWithInitContainers() {
 	sourcePods, err := loadPodsFixture("./testdata/podlist_init_container_running.json")
 	require.Nil(suite.T(), err)
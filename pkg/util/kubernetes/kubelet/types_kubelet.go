@@ -44,13 +44,28 @@ type PodOwner struct {
 
 // Spec contains fields for unmarshalling a Pod.Spec
 type Spec struct {
-	HostNetwork       bool                    `json:"hostNetwork,omitempty"`
-	NodeName          string                  `json:"nodeName,omitempty"`
-	InitContainers    []ContainerSpec         `json:"initContainers,omitempty"`
-	Containers        []ContainerSpec         `json:"containers,omitempty"`
-	Volumes           []VolumeSpec            `json:"volumes,omitempty"`
-	PriorityClassName string                  `json:"priorityClassName,omitempty"`
-	SecurityContext   *PodSecurityContextSpec `json:"securityContext,omitempty"`
+	HostNetwork         bool                    `json:"hostNetwork,omitempty"`
+	NodeName            string                  `json:"nodeName,omitempty"`
+	InitContainers      []ContainerSpec         `json:"initContainers,omitempty"`
+	Containers          []ContainerSpec         `json:"containers,omitempty"`
+	EphemeralContainers []ContainerSpec         `json:"ephemeralContainers,omitempty"`
+	Volumes             []VolumeSpec            `json:"volumes,omitempty"`
+	PriorityClassName   string                  `json:"priorityClassName,omitempty"`
+	RuntimeClassName    *string                 `json:"runtimeClassName,omitempty"`
+	SecurityContext     *PodSecurityContextSpec `json:"securityContext,omitempty"`
+
+	TopologySpreadConstraints []TopologySpreadConstraintSpec `json:"topologySpreadConstraints,omitempty"`
+	Tolerations               []TolerationSpec               `json:"tolerations,omitempty"`
+}
+
+// TolerationSpec contains fields for unmarshalling a Pod.Spec.Tolerations
+type TolerationSpec struct {
+	Key string `json:"key,omitempty"`
+}
+
+// TopologySpreadConstraintSpec contains fields for unmarshalling a Pod.Spec.TopologySpreadConstraints
+type TopologySpreadConstraintSpec struct {
+	TopologyKey string `json:"topologyKey"`
 }
 
 // PodSecurityContextSpec contains fields for unmarshalling a Pod.Spec.SecurityContext
@@ -64,9 +79,12 @@ type PodSecurityContextSpec struct {
 type ContainerSpec struct {
 	Name            string                        `json:"name"`
 	Image           string                        `json:"image,omitempty"`
+	ImagePullPolicy string                        `json:"imagePullPolicy,omitempty"`
 	Ports           []ContainerPortSpec           `json:"ports,omitempty"`
 	ReadinessProbe  *ContainerProbe               `json:"readinessProbe,omitempty"`
+	LivenessProbe   *ContainerProbe               `json:"livenessProbe,omitempty"`
 	Env             []EnvVar                      `json:"env,omitempty"`
+	EnvFrom         []EnvFromSource               `json:"envFrom,omitempty"`
 	SecurityContext *ContainerSecurityContextSpec `json:"securityContext,omitempty"`
 	Resources       *ContainerResourcesSpec       `json:"resources,omitempty"`
 }
@@ -86,8 +104,44 @@ type ContainerPortSpec struct {
 }
 
 // ContainerProbe contains fields for unmarshalling a Pod.Spec.Containers.ReadinessProbe
+// and Pod.Spec.Containers.LivenessProbe
 type ContainerProbe struct {
-	InitialDelaySeconds int `json:"initialDelaySeconds"`
+	InitialDelaySeconds int               `json:"initialDelaySeconds"`
+	Exec                *ProbeHandlerSpec `json:"exec,omitempty"`
+	HTTPGet             *ProbeHandlerSpec `json:"httpGet,omitempty"`
+	TCPSocket           *ProbeHandlerSpec `json:"tcpSocket,omitempty"`
+	GRPC                *ProbeHandlerSpec `json:"grpc,omitempty"`
+}
+
+// ProbeHandlerSpec is used for unmarshalling the handler of a ContainerProbe.
+// Only the presence of the handler is needed, its fields are not unmarshalled.
+type ProbeHandlerSpec struct{}
+
+// Probe handler types
+const (
+	ProbeTypeExec = "exec"
+	ProbeTypeHTTP = "http"
+	ProbeTypeTCP  = "tcp"
+	ProbeTypeGRPC = "grpc"
+)
+
+// Type returns the type of the handler of the probe, or an empty string if
+// no supported handler is set
+func (p *ContainerProbe) Type() string {
+	switch {
+	case p == nil:
+		return ""
+	case p.Exec != nil:
+		return ProbeTypeExec
+	case p.HTTPGet != nil:
+		return ProbeTypeHTTP
+	case p.TCPSocket != nil:
+		return ProbeTypeTCP
+	case p.GRPC != nil:
+		return ProbeTypeGRPC
+	default:
+		return ""
+	}
 }
 
 // ContainerSecurityContextSpec contains fields for unmarshalling a Pod.Spec.Containers.SecurityContext
@@ -125,6 +179,28 @@ type EnvVar struct {
 	Name string `json:"name"`
 	// Value of the environment variable.
 	Value string `json:"value,omitempty"`
+	// Source for the environment variable's value.
+	ValueFrom *EnvVarSource `json:"valueFrom,omitempty"`
+}
+
+// EnvVarSource contains fields for unmarshalling a Pod.Spec.Containers.Env.ValueFrom
+type EnvVarSource struct {
+	SecretKeyRef *SecretKeySelector `json:"secretKeyRef,omitempty"`
+}
+
+// SecretKeySelector contains fields for unmarshalling a Pod.Spec.Containers.Env.ValueFrom.SecretKeyRef
+type SecretKeySelector struct {
+	Name string `json:"name"`
+}
+
+// EnvFromSource contains fields for unmarshalling a Pod.Spec.Containers.EnvFrom
+type EnvFromSource struct {
+	SecretRef *SecretEnvSource `json:"secretRef,omitempty"`
+}
+
+// SecretEnvSource contains fields for unmarshalling a Pod.Spec.Containers.EnvFrom.SecretRef
+type SecretEnvSource struct {
+	Name string `json:"name"`
 }
 
 // VolumeSpec contains fields for unmarshalling a Pod.Spec.Volumes
@@ -132,6 +208,12 @@ type VolumeSpec struct {
 	Name string `json:"name"`
 	// Only try to retrieve persistent volume claim to tag statefulsets
 	PersistentVolumeClaim *PersistentVolumeClaimSpec `json:"persistentVolumeClaim,omitempty"`
+	Secret                *SecretVolumeSpec          `json:"secret,omitempty"`
+}
+
+// SecretVolumeSpec contains fields for unmarshalling a Pod.Spec.Volumes.Secret
+type SecretVolumeSpec struct {
+	SecretName string `json:"secretName"`
 }
 
 // PersistentVolumeClaimSpec contains fields for unmarshalling a Pod.Spec.Volumes.PersistentVolumeClaim
@@ -144,11 +226,15 @@ type Status struct {
 	Phase          string            `json:"phase,omitempty"`
 	HostIP         string            `json:"hostIP,omitempty"`
 	PodIP          string            `json:"podIP,omitempty"`
+	NominatedNode  string            `json:"nominatedNodeName,omitempty"`
+	StartTime      *time.Time        `json:"startTime,omitempty"`
 	Containers     []ContainerStatus `json:"containerStatuses,omitempty"`
 	InitContainers []ContainerStatus `json:"initContainerStatuses,omitempty"`
-	AllContainers  []ContainerStatus
-	Conditions     []Conditions `json:"conditions,omitempty"`
-	QOSClass       string       `json:"qosClass,omitempty"`
+	// EphemeralContainers are not part of AllContainers
+	EphemeralContainers []ContainerStatus `json:"ephemeralContainerStatuses,omitempty"`
+	AllContainers       []ContainerStatus
+	Conditions          []Conditions `json:"conditions,omitempty"`
+	QOSClass            string       `json:"qosClass,omitempty"`
 }
 
 // GetAllContainers returns the list of init and regular containers
@@ -159,8 +245,9 @@ func (s *Status) GetAllContainers() []ContainerStatus {
 
 // Conditions contains fields for unmarshalling a Pod.Status.Conditions
 type Conditions struct {
-	Type   string `json:"type,omitempty"`
-	Status string `json:"status,omitempty"`
+	Type               string    `json:"type,omitempty"`
+	Status             string    `json:"status,omitempty"`
+	LastTransitionTime time.Time `json:"lastTransitionTime,omitempty"`
 }
 
 // ContainerStatus contains fields for unmarshalling a Pod.Status.Containers
@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"time"
 
+	"google.golang.org/protobuf/proto"
+
 	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/core"
 	"github.com/DataDog/datadog-agent/pkg/workloadmeta"
 )
@@ -392,8 +394,69 @@ func toProtoLaunchType(launchType workloadmeta.ECSLaunchType) (pb.ECSLaunchType,
 	return pb.ECSLaunchType_EC2, fmt.Errorf("unknown launch type: %s", launchType)
 }
 
+// MarshalWorkloadmetaEntity serializes the given entity into a protobuf
+// WorkloadmetaEntity, keyed by the string representation of its entity ID
+func MarshalWorkloadmetaEntity(entity workloadmeta.Entity) ([]byte, error) {
+	if entity == nil {
+		return nil, fmt.Errorf("cannot marshal nil entity")
+	}
+
+	entityID := entity.GetID()
+	protoEntity := &pb.WorkloadmetaEntity{
+		Id: entityID.String(false),
+	}
+
+	var err error
+	switch entityID.Kind {
+	case workloadmeta.KindContainer:
+		protoEntity.Container, err = protoContainerFromWorkloadmetaContainer(entity.(*workloadmeta.Container))
+	case workloadmeta.KindKubernetesPod:
+		protoEntity.KubernetesPod, err = protoKubernetesPodFromWorkloadmetaKubernetesPod(entity.(*workloadmeta.KubernetesPod))
+	case workloadmeta.KindECSTask:
+		protoEntity.EcsTask, err = protoECSTaskFromWorkloadmetaECSTask(entity.(*workloadmeta.ECSTask))
+	default:
+		return nil, fmt.Errorf("unknown kind: %s", entityID.Kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(protoEntity)
+}
+
 // Conversions from protobuf to Workloadmeta types
 
+// UnmarshalWorkloadmetaEntity deserializes a protobuf WorkloadmetaEntity, as
+// serialized by MarshalWorkloadmetaEntity, into a workloadmeta entity
+func UnmarshalWorkloadmetaEntity(data []byte) (workloadmeta.Entity, error) {
+	var protoEntity pb.WorkloadmetaEntity
+	if err := proto.Unmarshal(data, &protoEntity); err != nil {
+		return nil, err
+	}
+
+	var entity workloadmeta.Entity
+	var err error
+	switch {
+	case protoEntity.Container != nil:
+		entity, err = toWorkloadmetaContainer(protoEntity.Container)
+	case protoEntity.KubernetesPod != nil:
+		entity, err = toWorkloadmetaKubernetesPod(protoEntity.KubernetesPod)
+	case protoEntity.EcsTask != nil:
+		entity, err = toWorkloadmetaECSTask(protoEntity.EcsTask)
+	default:
+		return nil, fmt.Errorf("unknown entity")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if entityID := entity.GetID(); entityID.String(false) != protoEntity.Id {
+		return nil, fmt.Errorf("entity ID mismatch: expected %q, got %q", protoEntity.Id, entityID.String(false))
+	}
+
+	return entity, nil
+}
+
 // WorkloadmetaFilterFromProtoFilter converts the given protobuf filter into a workloadmeta.Filter
 func WorkloadmetaFilterFromProtoFilter(protoFilter *pb.WorkloadmetaFilter) (*workloadmeta.Filter, error) {
 	if protoFilter == nil {
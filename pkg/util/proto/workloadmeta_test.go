@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/protobuf/proto"
 
 	pb "github.com/DataDog/datadog-agent/pkg/proto/pbgo/core"
@@ -398,3 +399,121 @@ func TestWorkloadmetaFilterFromProtoFilter(t *testing.T) {
 	assert.Equal(t, workloadmeta.SourceRuntime, resultFilter.Source())
 	assert.Equal(t, workloadmeta.EventTypeSet, resultFilter.EventType())
 }
+
+func TestMarshalWorkloadmetaEntity(t *testing.T) {
+	createdAt := time.Unix(1669071600, 0)
+
+	tests := []struct {
+		name   string
+		entity workloadmeta.Entity
+	}{
+		{
+			name: "container",
+			entity: &workloadmeta.Container{
+				EntityID: workloadmeta.EntityID{
+					Kind: workloadmeta.KindContainer,
+					ID:   "123",
+				},
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      "abc",
+					Namespace: "default",
+				},
+				Image: workloadmeta.ContainerImage{
+					RawName: "datadog/agent:7",
+					Name:    "datadog/agent",
+					Tag:     "7",
+				},
+				Runtime: workloadmeta.ContainerRuntimeContainerd,
+				State: workloadmeta.ContainerState{
+					Running:   true,
+					Status:    workloadmeta.ContainerStatusRunning,
+					Health:    workloadmeta.ContainerHealthHealthy,
+					CreatedAt: createdAt,
+					StartedAt: createdAt,
+				},
+			},
+		},
+		{
+			name: "Kubernetes pod",
+			entity: &workloadmeta.KubernetesPod{
+				EntityID: workloadmeta.EntityID{
+					Kind: workloadmeta.KindKubernetesPod,
+					ID:   "123",
+				},
+				EntityMeta: workloadmeta.EntityMeta{
+					Name:      "test_pod",
+					Namespace: "default",
+				},
+				Owners: []workloadmeta.KubernetesPodOwner{
+					{
+						Kind: kubernetes.DeploymentKind,
+						Name: "test_deployment",
+						ID:   "d1",
+					},
+				},
+				Ready: true,
+				Phase: "Running",
+				IP:    "127.0.0.1",
+			},
+		},
+		{
+			name: "ECS task",
+			entity: &workloadmeta.ECSTask{
+				EntityID: workloadmeta.EntityID{
+					Kind: workloadmeta.KindECSTask,
+					ID:   "123",
+				},
+				EntityMeta: workloadmeta.EntityMeta{
+					Name: "abc",
+				},
+				ClusterName: "test_cluster",
+				Family:      "some_family",
+				Version:     "1",
+				LaunchType:  workloadmeta.ECSLaunchTypeFargate,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := MarshalWorkloadmetaEntity(test.entity)
+			require.NoError(t, err)
+
+			var protoEntity pb.WorkloadmetaEntity
+			require.NoError(t, proto.Unmarshal(data, &protoEntity))
+			entityID := test.entity.GetID()
+			assert.Equal(t, entityID.String(false), protoEntity.Id)
+
+			entity, err := UnmarshalWorkloadmetaEntity(data)
+			require.NoError(t, err)
+			assert.Equal(t, test.entity, entity)
+		})
+	}
+}
+
+func TestUnmarshalWorkloadmetaEntity_errors(t *testing.T) {
+	_, err := UnmarshalWorkloadmetaEntity([]byte("not protobuf"))
+	assert.Error(t, err)
+
+	data, err := proto.Marshal(&pb.WorkloadmetaEntity{Id: "Kind: container ID: 123\n"})
+	require.NoError(t, err)
+	_, err = UnmarshalWorkloadmetaEntity(data)
+	assert.EqualError(t, err, "unknown entity")
+
+	data, err = MarshalWorkloadmetaEntity(&workloadmeta.Container{
+		EntityID: workloadmeta.EntityID{Kind: workloadmeta.KindContainer, ID: "123"},
+		Runtime:  workloadmeta.ContainerRuntimeDocker,
+		State: workloadmeta.ContainerState{
+			Status: workloadmeta.ContainerStatusRunning,
+			Health: workloadmeta.ContainerHealthHealthy,
+		},
+	})
+	require.NoError(t, err)
+	var protoEntity pb.WorkloadmetaEntity
+	require.NoError(t, proto.Unmarshal(data, &protoEntity))
+	protoEntity.Id = "Kind: container ID: 456\n"
+	data, err = proto.Marshal(&protoEntity)
+	require.NoError(t, err)
+	_, err = UnmarshalWorkloadmetaEntity(data)
+	assert.ErrorContains(t, err, "entity ID mismatch")
+}
@@ -6,6 +6,7 @@
 package workloadmeta
 
 import (
+	"fmt"
 	"reflect"
 	"sort"
 
@@ -20,6 +21,16 @@ type cachedEntity struct {
 	cached        Entity
 	sources       map[Source]Entity
 	sortedSources []string
+
+	// version is the version of the last entity set, whatever its source
+	version uint64
+}
+
+// versionedEntity is implemented by the entities supporting optimistic
+// concurrency, that is the ones embedding EntityMeta.
+type versionedEntity interface {
+	GetVersion() uint64
+	SetVersion(uint64)
 }
 
 func newCachedEntity() *cachedEntity {
@@ -45,6 +56,11 @@ func (e *cachedEntity) set(source Source, entity Entity) (found, changed bool) {
 		return true, false
 	}
 
+	e.version = 0
+	if versioned, ok := entity.(versionedEntity); ok {
+		e.version = versioned.GetVersion()
+	}
+
 	e.sources[source] = entity
 	e.computeCache()
 
@@ -88,6 +104,12 @@ func (e *cachedEntity) computeCache() {
 		}
 	}
 
+	// the merged entity has the version of the last entity set, not the one
+	// of the first source
+	if versioned, ok := merged.(versionedEntity); ok {
+		versioned.SetVersion(e.version)
+	}
+
 	e.cached = merged
 }
 
@@ -95,6 +117,7 @@ func (e *cachedEntity) copy() *cachedEntity {
 	newEntity := newCachedEntity()
 
 	newEntity.cached = e.cached.DeepCopy()
+	newEntity.version = e.version
 
 	copy(newEntity.sortedSources, e.sortedSources)
 
@@ -104,3 +127,19 @@ func (e *cachedEntity) copy() *cachedEntity {
 
 	return newEntity
 }
+
+// checkEntityVersion returns an error if the entity has a version that
+// doesn't follow the current version. Entities without a version are always
+// accepted.
+func checkEntityVersion(currentVersion uint64, entity Entity) error {
+	versioned, ok := entity.(versionedEntity)
+	if !ok || versioned.GetVersion() == 0 {
+		return nil
+	}
+
+	if versioned.GetVersion() != currentVersion+1 {
+		return fmt.Errorf("version conflict: got version %d, expected version %d", versioned.GetVersion(), currentVersion+1)
+	}
+
+	return nil
+}
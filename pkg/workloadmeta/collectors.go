@@ -5,7 +5,16 @@
 
 package workloadmeta
 
-import "context"
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// defaultCollectorHeartbeatTimeout is the time after which a collector that
+// didn't beat is considered unhealthy. It leaves time for a few pulls, which
+// can take up to maxCollectorPullTime.
+const defaultCollectorHeartbeatTimeout = 5 * time.Minute
 
 // Collector is responsible for collecting metadata about workloads.
 type Collector interface {
@@ -18,6 +27,41 @@ type Collector interface {
 	// don't have streaming functionality, and called periodically by the
 	// store.
 	Pull(context.Context) error
+
+	// Healthy returns false when the collector stopped collecting, for
+	// instance because it doesn't receive events anymore. Collectors can
+	// implement it by embedding a Heartbeat.
+	Healthy() bool
+}
+
+// Heartbeat implements the Healthy method of the Collector interface,
+// collectors embedding it must call Beat each time they show that they are
+// still collecting (successful pull, event or keepalive received, ...).
+type Heartbeat struct {
+	// Timeout is the time after which a collector that didn't beat is
+	// unhealthy, defaults to 5 minutes.
+	Timeout time.Duration
+
+	lastBeat atomic.Int64 // unix nanoseconds
+}
+
+// Beat records that the collector is alive
+func (h *Heartbeat) Beat() {
+	h.lastBeat.Store(time.Now().UnixNano())
+}
+
+// Healthy returns true if the collector did beat in the last Timeout
+func (h *Heartbeat) Healthy() bool {
+	lastBeat := h.lastBeat.Load()
+	if lastBeat == 0 {
+		return false
+	}
+
+	timeout := h.Timeout
+	if timeout == 0 {
+		timeout = defaultCollectorHeartbeatTimeout
+	}
+	return time.Since(time.Unix(0, lastBeat)) < timeout
 }
 
 // CollectorCatalog is a collection of collectors
@@ -26,6 +26,8 @@ const (
 )
 
 type collector struct {
+	workloadmeta.Heartbeat
+
 	store    workloadmeta.Store
 	nodeName string
 }
@@ -99,6 +101,7 @@ func (c *collector) Pull(ctx context.Context) error {
 		Entity: containerEntity,
 	})
 
+	c.Beat()
 	c.store.Notify(events)
 	return nil
 }
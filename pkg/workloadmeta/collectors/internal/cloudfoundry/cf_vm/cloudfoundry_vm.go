@@ -24,6 +24,8 @@ const (
 )
 
 type collector struct {
+	workloadmeta.Heartbeat
+
 	store workloadmeta.Store
 	seen  map[workloadmeta.EntityID]struct{}
 
@@ -177,6 +179,7 @@ func (c *collector) Pull(ctx context.Context) error {
 
 	c.seen = seen
 
+	c.Beat()
 	c.store.Notify(events)
 
 	return nil
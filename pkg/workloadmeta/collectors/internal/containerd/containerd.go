@@ -80,6 +80,8 @@ type exitInfo struct {
 }
 
 type collector struct {
+	workloadmeta.Heartbeat
+
 	store                  workloadmeta.Store
 	containerdClient       cutil.ContainerdItf
 	filterPausedContainers *containers.Filter
@@ -144,6 +146,7 @@ func (c *collector) Start(ctx context.Context, store workloadmeta.Store) error {
 		return err
 	}
 
+	c.Beat()
 	go func() {
 		defer func() {
 			if errClose := c.containerdClient.Close(); errClose != nil {
@@ -169,8 +172,10 @@ func (c *collector) stream(ctx context.Context) {
 	for {
 		select {
 		case <-healthHandle.C:
+			c.Beat()
 
 		case ev := <-c.eventsChan:
+			c.Beat()
 			if err := c.handleEvent(ctx, ev); err != nil {
 				log.Warnf(err.Error())
 			}
@@ -43,6 +43,8 @@ const (
 type resolveHook func(ctx context.Context, co types.ContainerJSON) (string, error)
 
 type collector struct {
+	workloadmeta.Heartbeat
+
 	store workloadmeta.Store
 
 	dockerUtil        *docker.DockerUtil
@@ -104,6 +106,7 @@ func (c *collector) Start(ctx context.Context, store workloadmeta.Store) error {
 		return err
 	}
 
+	c.Beat()
 	go c.stream(ctx)
 
 	return nil
@@ -120,14 +123,17 @@ func (c *collector) stream(ctx context.Context) {
 	for {
 		select {
 		case <-health.C:
+			c.Beat()
 
 		case ev := <-c.containerEventsCh:
+			c.Beat()
 			err := c.handleContainerEvent(ctx, ev)
 			if err != nil {
 				log.Warnf(err.Error())
 			}
 
 		case ev := <-c.imageEventsCh:
+			c.Beat()
 			err := c.handleImageEvent(ctx, ev, nil)
 			if err != nil {
 				log.Warnf(err.Error())
@@ -28,6 +28,8 @@ const (
 )
 
 type collector struct {
+	workloadmeta.Heartbeat
+
 	store               workloadmeta.Store
 	metaV1              v1.Client
 	metaV3or4           func(metaURI, metaVersion string) v3or4.Client
@@ -89,6 +91,8 @@ func (c *collector) Pull(ctx context.Context) error {
 		return err
 	}
 
+	c.Beat()
+
 	// we always parse all the tasks coming from the API, as they are not
 	// immutable: the list of containers in the task changes as containers
 	// don't get added until they actually start running, and killed
@@ -128,6 +132,7 @@ func (c *collector) parseTasks(ctx context.Context, tasks []v1.Task) []workloadm
 			Family:      task.Family,
 			Version:     task.Version,
 			LaunchType:  workloadmeta.ECSLaunchTypeEC2,
+			KnownStatus: task.KnownStatus,
 			Containers:  taskContainers,
 		}
 
@@ -27,6 +27,8 @@ const (
 )
 
 type collector struct {
+	workloadmeta.Heartbeat
+
 	store  workloadmeta.Store
 	metaV2 v2.Client
 	seen   map[workloadmeta.EntityID]struct{}
@@ -62,6 +64,7 @@ func (c *collector) Pull(ctx context.Context) error {
 		return err
 	}
 
+	c.Beat()
 	c.store.Notify(c.parseTask(task))
 
 	return nil
@@ -71,11 +74,9 @@ func (c *collector) parseTask(task *v2.Task) []workloadmeta.CollectorEvent {
 	events := []workloadmeta.CollectorEvent{}
 	seen := make(map[workloadmeta.EntityID]struct{})
 
-	// We only want to collect tasks without a STOPPED status.
-	if task.KnownStatus == "STOPPED" {
-		return events
-	}
-
+	// STOPPED tasks are still collected: the metadata endpoint only
+	// describes the task of the agent, and its stop code is only
+	// meaningful once it is stopped.
 	arnParts := strings.Split(task.TaskARN, "/")
 	taskID := arnParts[len(arnParts)-1]
 	entityID := workloadmeta.EntityID{
@@ -85,7 +86,7 @@ func (c *collector) parseTask(task *v2.Task) []workloadmeta.CollectorEvent {
 
 	seen[entityID] = struct{}{}
 
-	taskContainers, containerEvents := c.parseTaskContainers(task, seen)
+	taskContainers, containerEvents, startedAt := c.parseTaskContainers(task, seen)
 	entity := &workloadmeta.ECSTask{
 		EntityID: entityID,
 		EntityMeta: workloadmeta.EntityMeta{
@@ -96,8 +97,15 @@ func (c *collector) parseTask(task *v2.Task) []workloadmeta.CollectorEvent {
 		Family:      task.Family,
 		Version:     task.Version,
 		LaunchType:  workloadmeta.ECSLaunchTypeFargate,
+		StartedAt:   startedAt,
+		KnownStatus: task.KnownStatus,
 		Containers:  taskContainers,
 
+		// the stop code and reason are only set once the task has
+		// been requested to stop
+		StopCode:      task.StopCode,
+		StoppedReason: task.StoppedReason,
+
 		// the AvailabilityZone metadata is only available for
 		// Fargate tasks using platform version 1.4 or later
 		AvailabilityZone: task.AvailabilityZone,
@@ -138,12 +146,16 @@ func (c *collector) parseTask(task *v2.Task) []workloadmeta.CollectorEvent {
 	return events
 }
 
+// parseTaskContainers returns the containers of the task, their events, and
+// the start time of the task. The task metadata doesn't include it, so the
+// start time of the first container started is used instead.
 func (c *collector) parseTaskContainers(
 	task *v2.Task,
 	seen map[workloadmeta.EntityID]struct{},
-) ([]workloadmeta.OrchestratorContainer, []workloadmeta.CollectorEvent) {
+) ([]workloadmeta.OrchestratorContainer, []workloadmeta.CollectorEvent, *time.Time) {
 	taskContainers := make([]workloadmeta.OrchestratorContainer, 0, len(task.Containers))
 	events := make([]workloadmeta.CollectorEvent, 0, len(task.Containers))
+	var taskStartedAt *time.Time
 
 	for _, container := range task.Containers {
 		containerID := container.DockerID
@@ -177,6 +189,8 @@ func (c *collector) parseTaskContainers(
 			startedAt, err = time.Parse(time.RFC3339, container.StartedAt)
 			if err != nil {
 				log.Debugf("cannot parse StartedAt %q for container %q: %s", container.StartedAt, container.DockerID, err)
+			} else if taskStartedAt == nil || startedAt.Before(*taskStartedAt) {
+				taskStartedAt = &startedAt
 			}
 		}
 
@@ -210,7 +224,7 @@ func (c *collector) parseTaskContainers(
 		})
 	}
 
-	return taskContainers, events
+	return taskContainers, events, taskStartedAt
 }
 
 // parseClusterName returns the short name of a cluster. it detects if the name
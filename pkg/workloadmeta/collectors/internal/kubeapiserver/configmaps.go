@@ -0,0 +1,84 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build kubeapiserver
+
+package kubeapiserver
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+	"github.com/DataDog/datadog-agent/pkg/workloadmeta"
+)
+
+func newConfigMapStore(ctx context.Context, wlm workloadmeta.Store, client kubernetes.Interface) (*cache.Reflector, *reflectorStore) {
+	configMapListerWatcher := func(namespace string) cache.ListerWatcher {
+		return &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return client.CoreV1().ConfigMaps(namespace).List(ctx, options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.CoreV1().ConfigMaps(namespace).Watch(ctx, options)
+			},
+		}
+	}
+
+	configMapStore := newConfigMapReflectorStore(wlm)
+	configMapReflector := cache.NewNamedReflector(
+		componentName,
+		newReconnectingListerWatcher(ctx, "configmaps", filterNamespaces(configMapListerWatcher)),
+		&corev1.ConfigMap{},
+		configMapStore,
+		noResync,
+	)
+	log.Debug("configmap reflector enabled")
+	return configMapReflector, configMapStore
+}
+
+func newConfigMapReflectorStore(wlmetaStore workloadmeta.Store) *reflectorStore {
+	return &reflectorStore{
+		wlmetaStore: wlmetaStore,
+		seen:        make(map[string]workloadmeta.EntityID),
+		parser:      newConfigMapParser(),
+	}
+}
+
+type configMapParser struct{}
+
+func newConfigMapParser() objectParser {
+	return configMapParser{}
+}
+
+func (p configMapParser) Parse(obj interface{}) workloadmeta.Entity {
+	configMap := obj.(*corev1.ConfigMap)
+
+	// Only the labels are kept: the data of a ConfigMap can be large and
+	// is never needed by the consumers of workloadmeta.
+	return &workloadmeta.KubernetesConfigMap{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindKubernetesConfigMap,
+			ID:   configMapEntityID(configMap.Namespace, configMap.Name),
+		},
+		EntityMeta: workloadmeta.EntityMeta{
+			Name:      configMap.Name,
+			Namespace: configMap.Namespace,
+			Labels:    configMap.Labels,
+		},
+	}
+}
+
+// configMapEntityID uses the namespace/name as id so that ConfigMaps can be
+// retrieved from the references found in pod specs.
+func configMapEntityID(namespace, name string) string {
+	return namespace + "/" + name
+}
@@ -0,0 +1,87 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build kubeapiserver && test
+
+package kubeapiserver
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/DataDog/datadog-agent/pkg/workloadmeta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigMapParser_Parse(t *testing.T) {
+	parser := newConfigMapParser()
+
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-configmap",
+			Namespace: "test-namespace",
+			Labels: map[string]string{
+				"team": "containers",
+			},
+		},
+		Data: map[string]string{
+			"key": "value",
+		},
+	}
+
+	expected := &workloadmeta.KubernetesConfigMap{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindKubernetesConfigMap,
+			ID:   "test-namespace/test-configmap",
+		},
+		EntityMeta: workloadmeta.EntityMeta{
+			Name:      "test-configmap",
+			Namespace: "test-namespace",
+			Labels: map[string]string{
+				"team": "containers",
+			},
+		},
+	}
+
+	assert.Equal(t, expected, parser.Parse(configMap))
+}
+
+func Test_ConfigMapsFakeKubernetesClient(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{
+		Name:      "test-configmap",
+		Namespace: "test-namespace",
+		Labels:    map[string]string{"test-label": "test-value"},
+		UID:       types.UID("test-configmap-uid"),
+	}
+
+	createResource := func(cl *fake.Clientset) error {
+		_, err := cl.CoreV1().ConfigMaps(objectMeta.Namespace).Create(context.TODO(), &corev1.ConfigMap{ObjectMeta: objectMeta}, metav1.CreateOptions{})
+		return err
+	}
+	expected := workloadmeta.EventBundle{
+		Events: []workloadmeta.Event{
+			{
+				Type: workloadmeta.EventTypeSet,
+				Entity: &workloadmeta.KubernetesConfigMap{
+					EntityID: workloadmeta.EntityID{
+						ID:   "test-namespace/test-configmap",
+						Kind: workloadmeta.KindKubernetesConfigMap,
+					},
+					EntityMeta: workloadmeta.EntityMeta{
+						Name:      objectMeta.Name,
+						Namespace: objectMeta.Namespace,
+						Labels:    objectMeta.Labels,
+					},
+				},
+			},
+		},
+	}
+	testCollectEvent(t, createResource, newConfigMapStore, expected)
+}
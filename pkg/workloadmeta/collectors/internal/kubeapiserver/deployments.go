@@ -40,19 +40,21 @@ func (f *deploymentFilter) filteredOut(entity workloadmeta.Entity) bool {
 }
 
 func newDeploymentStore(ctx context.Context, wlm workloadmeta.Store, client kubernetes.Interface) (*cache.Reflector, *reflectorStore) {
-	deploymentListerWatcher := &cache.ListWatch{
-		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
-			return client.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, options)
-		},
-		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-			return client.AppsV1().Deployments(metav1.NamespaceAll).Watch(ctx, options)
-		},
+	deploymentListerWatcher := func(namespace string) cache.ListerWatcher {
+		return &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return client.AppsV1().Deployments(namespace).List(ctx, options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.AppsV1().Deployments(namespace).Watch(ctx, options)
+			},
+		}
 	}
 
 	deploymentStore := newDeploymentReflectorStore(wlm)
 	deploymentReflector := cache.NewNamedReflector(
 		componentName,
-		deploymentListerWatcher,
+		newReconnectingListerWatcher(ctx, "deployments", filterNamespaces(deploymentListerWatcher)),
 		&appsv1.Deployment{},
 		deploymentStore,
 		noResync,
@@ -0,0 +1,87 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build kubeapiserver
+
+package kubeapiserver
+
+import (
+	"context"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+	"github.com/DataDog/datadog-agent/pkg/workloadmeta"
+)
+
+func newJobStore(ctx context.Context, wlm workloadmeta.Store, client kubernetes.Interface) (*cache.Reflector, *reflectorStore) {
+	jobListerWatcher := func(namespace string) cache.ListerWatcher {
+		return &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return client.BatchV1().Jobs(namespace).List(ctx, options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.BatchV1().Jobs(namespace).Watch(ctx, options)
+			},
+		}
+	}
+
+	jobStore := newJobReflectorStore(wlm)
+	jobReflector := cache.NewNamedReflector(
+		componentName,
+		newReconnectingListerWatcher(ctx, "jobs", filterNamespaces(jobListerWatcher)),
+		&batchv1.Job{},
+		jobStore,
+		noResync,
+	)
+	log.Debug("job reflector enabled")
+	return jobReflector, jobStore
+}
+
+func newJobReflectorStore(wlmetaStore workloadmeta.Store) *reflectorStore {
+	return &reflectorStore{
+		wlmetaStore: wlmetaStore,
+		seen:        make(map[string]workloadmeta.EntityID),
+		parser:      newJobParser(),
+	}
+}
+
+type jobParser struct{}
+
+func newJobParser() objectParser {
+	return jobParser{}
+}
+
+func (p jobParser) Parse(obj interface{}) workloadmeta.Entity {
+	job := obj.(*batchv1.Job)
+
+	owners := make([]workloadmeta.KubernetesPodOwner, 0, len(job.OwnerReferences))
+	for _, o := range job.OwnerReferences {
+		owners = append(owners, workloadmeta.KubernetesPodOwner{
+			Kind: o.Kind,
+			Name: o.Name,
+			ID:   string(o.UID),
+		})
+	}
+
+	// The spec and status are not kept: only the owners are needed to link
+	// pods to the CronJob that spawned them.
+	return &workloadmeta.KubernetesJob{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindKubernetesJob,
+			ID:   string(job.UID),
+		},
+		EntityMeta: workloadmeta.EntityMeta{
+			Name:      job.Name,
+			Namespace: job.Namespace,
+		},
+		Owners: owners,
+	}
+}
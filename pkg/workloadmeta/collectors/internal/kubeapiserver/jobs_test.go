@@ -0,0 +1,92 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build kubeapiserver && test
+
+package kubeapiserver
+
+import (
+	"context"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/DataDog/datadog-agent/pkg/workloadmeta"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobParser_Parse(t *testing.T) {
+	parser := newJobParser()
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "backup-28245420",
+			Namespace: "test-namespace",
+			UID:       types.UID("job-uid"),
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Kind: "CronJob",
+					Name: "backup",
+					UID:  types.UID("cronjob-uid"),
+				},
+			},
+		},
+	}
+
+	expected := &workloadmeta.KubernetesJob{
+		EntityID: workloadmeta.EntityID{
+			Kind: workloadmeta.KindKubernetesJob,
+			ID:   "job-uid",
+		},
+		EntityMeta: workloadmeta.EntityMeta{
+			Name:      "backup-28245420",
+			Namespace: "test-namespace",
+		},
+		Owners: []workloadmeta.KubernetesPodOwner{
+			{
+				Kind: "CronJob",
+				Name: "backup",
+				ID:   "cronjob-uid",
+			},
+		},
+	}
+
+	assert.Equal(t, expected, parser.Parse(job))
+}
+
+func Test_JobsFakeKubernetesClient(t *testing.T) {
+	objectMeta := metav1.ObjectMeta{
+		Name:      "test-job",
+		Namespace: "test-namespace",
+		UID:       types.UID("test-job-uid"),
+	}
+
+	createResource := func(cl *fake.Clientset) error {
+		_, err := cl.BatchV1().Jobs(objectMeta.Namespace).Create(context.TODO(), &batchv1.Job{ObjectMeta: objectMeta}, metav1.CreateOptions{})
+		return err
+	}
+	expected := workloadmeta.EventBundle{
+		Events: []workloadmeta.Event{
+			{
+				Type: workloadmeta.EventTypeSet,
+				Entity: &workloadmeta.KubernetesJob{
+					EntityID: workloadmeta.EntityID{
+						ID:   string(objectMeta.UID),
+						Kind: workloadmeta.KindKubernetesJob,
+					},
+					EntityMeta: workloadmeta.EntityMeta{
+						Name:      objectMeta.Name,
+						Namespace: objectMeta.Namespace,
+					},
+					Owners: []workloadmeta.KubernetesPodOwner{},
+				},
+			},
+		},
+	}
+	testCollectEvent(t, createResource, newJobStore, expected)
+}
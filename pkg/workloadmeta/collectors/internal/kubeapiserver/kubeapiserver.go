@@ -27,9 +27,17 @@ const (
 	collectorID   = "kubeapiserver"
 	componentName = "workloadmeta-kubeapiserver"
 	noResync      = time.Duration(0)
+
+	// heartbeatTimeout is the time after which the collector is unhealthy
+	// if none of its reflectors delivered objects. Nodes report their status
+	// at least every 5 minutes, so the node reflector always delivers updates
+	// within that time.
+	heartbeatTimeout = 15 * time.Minute
 )
 
-type collector struct{}
+type collector struct {
+	workloadmeta.Heartbeat
+}
 
 // storeGenerator returns a new store specific to a given resource
 type storeGenerator func(context.Context, workloadmeta.Store, kubernetes.Interface) (*cache.Reflector, *reflectorStore)
@@ -39,18 +47,29 @@ func storeGenerators(cfg config.Config) []storeGenerator {
 
 	if cfg.GetBool("cluster_agent.collect_kubernetes_tags") {
 		generators = append(generators, newPodStore)
+
+		// Jobs are only collected to link pods to their CronJob
+		if cfg.GetBool("cluster_agent.collect_kubernetes_job_owners") {
+			generators = append(generators, newJobStore)
+		}
 	}
 
 	if cfg.GetBool("language_detection.enabled") {
 		generators = append(generators, newDeploymentStore)
 	}
 
+	if len(cfg.GetStringMapString("cluster_agent.kubernetes_configmap_labels_as_tags")) > 0 {
+		generators = append(generators, newConfigMapStore)
+	}
+
 	return generators
 }
 
 func init() {
 	workloadmeta.RegisterClusterCollector(collectorID, func() workloadmeta.Collector {
-		return &collector{}
+		return &collector{
+			Heartbeat: workloadmeta.Heartbeat{Timeout: heartbeatTimeout},
+		}
 	})
 }
 
@@ -65,6 +84,7 @@ func (c *collector) Start(ctx context.Context, wlmetaStore workloadmeta.Store) e
 
 	for _, storeBuilder := range storeGenerators(config.Datadog) {
 		reflector, store := storeBuilder(ctx, wlmetaStore, client)
+		store.beat = c.Beat
 		objectStores = append(objectStores, store)
 		go reflector.Run(ctx.Done())
 	}
@@ -8,11 +8,19 @@
 package kubeapiserver
 
 import (
+	"context"
 	"strings"
 	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
 
 	"github.com/DataDog/datadog-agent/pkg/config"
+	"github.com/DataDog/datadog-agent/pkg/workloadmeta"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestStoreGenerators(t *testing.T) {
@@ -38,6 +46,24 @@ func TestStoreGenerators(t *testing.T) {
 			},
 			expectedStoresGenerator: []storeGenerator{newNodeStore, newPodStore},
 		},
+		{
+			name: "Kubernetes tags and job owners enabled",
+			cfg: map[string]bool{
+				"cluster_agent.collect_kubernetes_tags":       true,
+				"cluster_agent.collect_kubernetes_job_owners": true,
+				"language_detection.enabled":                  false,
+			},
+			expectedStoresGenerator: []storeGenerator{newNodeStore, newPodStore, newJobStore},
+		},
+		{
+			name: "Job owners enabled without kubernetes tags",
+			cfg: map[string]bool{
+				"cluster_agent.collect_kubernetes_tags":       false,
+				"cluster_agent.collect_kubernetes_job_owners": true,
+				"language_detection.enabled":                  false,
+			},
+			expectedStoresGenerator: []storeGenerator{newNodeStore},
+		},
 		{
 			name: "Language detection enabled",
 			cfg: map[string]bool{
@@ -79,3 +105,39 @@ func collectResultStoreGenerator(funcs []storeGenerator) []*reflectorStore {
 	}
 	return stores
 }
+
+func TestCollectorLiveness(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	wlm := workloadmeta.NewMockStore()
+
+	c := &collector{
+		Heartbeat: workloadmeta.Heartbeat{Timeout: 500 * time.Millisecond},
+	}
+	reflector, store := newNodeStore(context.TODO(), wlm, client)
+	store.beat = c.Beat
+
+	assert.False(t, c.Healthy(), "the collector is unhealthy before the reflector delivers objects")
+
+	stopReflector := make(chan struct{})
+	go reflector.Run(stopReflector)
+
+	// the initial list makes the collector beat
+	assert.Eventually(t, c.Healthy, 5*time.Second, 10*time.Millisecond)
+
+	// objects delivered by the watch keep the collector healthy
+	for i := 0; i < 3; i++ {
+		time.Sleep(300 * time.Millisecond)
+		_, err := client.CoreV1().Nodes().Create(context.TODO(), &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "node-" + string(rune('a'+i)),
+			},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+		assert.Eventually(t, c.Healthy, time.Second, 10*time.Millisecond)
+	}
+
+	// the collector becomes unhealthy once the reflector stops delivering
+	// objects, which makes the workloadmeta collectors health check fail
+	close(stopReflector)
+	assert.Eventually(t, func() bool { return !c.Healthy() }, 5*time.Second, 10*time.Millisecond)
+}
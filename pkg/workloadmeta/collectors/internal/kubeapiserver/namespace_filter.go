@@ -0,0 +1,426 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build kubeapiserver
+
+package kubeapiserver
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/DataDog/datadog-agent/pkg/config"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+// namespaceFilter filters resources based on the namespace include and
+// exclude patterns
+type namespaceFilter struct {
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// newNamespaceFilter returns nil if no pattern is configured. Invalid patterns
+// are logged and ignored.
+func newNamespaceFilter(cfg config.Config) *namespaceFilter {
+	include, err := parseFilters(cfg.GetStringSlice("kubernetes_namespace_include_patterns"))
+	if err != nil {
+		log.Errorf("unable to parse all kubernetes_namespace_include_patterns: %v", err)
+	}
+
+	exclude, err := parseFilters(cfg.GetStringSlice("kubernetes_namespace_exclude_patterns"))
+	if err != nil {
+		log.Errorf("unable to parse all kubernetes_namespace_exclude_patterns: %v", err)
+	}
+
+	if len(include) == 0 && len(exclude) == 0 {
+		return nil
+	}
+
+	return &namespaceFilter{
+		include: include,
+		exclude: exclude,
+	}
+}
+
+// isExcluded returns true if the resources of the namespace must not be
+// collected. Exclude patterns take precedence over include patterns.
+func (f *namespaceFilter) isExcluded(namespace string) bool {
+	for _, exclude := range f.exclude {
+		if exclude.MatchString(namespace) {
+			return true
+		}
+	}
+
+	if len(f.include) == 0 {
+		return false
+	}
+
+	for _, include := range f.include {
+		if include.MatchString(namespace) {
+			return false
+		}
+	}
+	return true
+}
+
+// includedNamespaces returns the namespaces to collect when all the include
+// patterns match a single namespace name (`^<name>$`). It returns false if
+// there is no include pattern or if one of them is a regex matching several
+// names.
+func (f *namespaceFilter) includedNamespaces() ([]string, bool) {
+	if len(f.include) == 0 {
+		return nil, false
+	}
+
+	seen := make(map[string]struct{}, len(f.include))
+	namespaces := make([]string, 0, len(f.include))
+	for _, include := range f.include {
+		namespace, ok := literalNamespace(include)
+		if !ok {
+			return nil, false
+		}
+
+		if _, found := seen[namespace]; found || f.isExcluded(namespace) {
+			continue
+		}
+		seen[namespace] = struct{}{}
+		namespaces = append(namespaces, namespace)
+	}
+
+	sort.Strings(namespaces)
+	return namespaces, true
+}
+
+// excludeFieldSelector returns a field selector excluding the namespaces
+// matched by the exclude patterns of a single namespace name (`^<name>$`), so
+// that the API server doesn't send their resources.
+func (f *namespaceFilter) excludeFieldSelector() string {
+	var selectors []string
+	for _, exclude := range f.exclude {
+		if namespace, ok := literalNamespace(exclude); ok {
+			selectors = append(selectors, "metadata.namespace!="+namespace)
+		}
+	}
+	return strings.Join(selectors, ",")
+}
+
+// literalNamespace returns the namespace name matched by a pattern of the form
+// `^<name>$`, where name doesn't contain any regex metacharacter
+func literalNamespace(pattern *regexp.Regexp) (string, bool) {
+	expr := pattern.String()
+	if len(expr) < 3 || !strings.HasPrefix(expr, "^") || !strings.HasSuffix(expr, "$") {
+		return "", false
+	}
+
+	name := expr[1 : len(expr)-1]
+	if regexp.QuoteMeta(name) != name {
+		return "", false
+	}
+	return name, true
+}
+
+// isObjectExcluded returns true if the object belongs to an excluded namespace
+func (f *namespaceFilter) isObjectExcluded(obj runtime.Object) bool {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	return f.isExcluded(accessor.GetNamespace())
+}
+
+// filterNamespaces applies the configured namespace filter to a namespaced
+// resource, newListerWatcher returning the ListerWatcher of the resource in a
+// given namespace.
+//
+// When the include patterns are namespace names, the resource is only listed
+// and watched in these namespaces. Otherwise, it is listed and watched in all
+// the namespaces, excluding the namespace names of the exclude patterns with
+// a field selector, and the resources are then filtered with the patterns
+// once received.
+func filterNamespaces(newListerWatcher func(namespace string) cache.ListerWatcher) cache.ListerWatcher {
+	filter := newNamespaceFilter(config.Datadog)
+	if filter == nil {
+		return newListerWatcher(metav1.NamespaceAll)
+	}
+
+	if namespaces, ok := filter.includedNamespaces(); ok && len(namespaces) > 0 {
+		return newMultiNamespaceListerWatcher(namespaces, newListerWatcher)
+	}
+
+	return &namespaceFilteredListerWatcher{
+		ListerWatcher: newListerWatcher(metav1.NamespaceAll),
+		filter:        filter,
+		fieldSelector: filter.excludeFieldSelector(),
+	}
+}
+
+// namespaceFilteredListerWatcher drops the resources of the excluded namespaces
+// from the lists and watch events of a namespaced resource
+type namespaceFilteredListerWatcher struct {
+	cache.ListerWatcher
+
+	filter *namespaceFilter
+
+	// fieldSelector is added to the field selector of the lists and watches
+	fieldSelector string
+}
+
+// List lists the resources that don't belong to an excluded namespace
+func (lw *namespaceFilteredListerWatcher) List(options metav1.ListOptions) (runtime.Object, error) {
+	list, err := lw.ListerWatcher.List(lw.withFieldSelector(options))
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]runtime.Object, 0, len(items))
+	for _, item := range items {
+		if !lw.filter.isObjectExcluded(item) {
+			filtered = append(filtered, item)
+		}
+	}
+
+	if err := meta.SetList(list, filtered); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Watch watches the resources that don't belong to an excluded namespace
+func (lw *namespaceFilteredListerWatcher) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	w, err := lw.ListerWatcher.Watch(lw.withFieldSelector(options))
+	if err != nil {
+		return nil, err
+	}
+
+	return watch.Filter(w, func(event watch.Event) (watch.Event, bool) {
+		switch event.Type {
+		case watch.Added, watch.Modified, watch.Deleted:
+			return event, !lw.filter.isObjectExcluded(event.Object)
+		default:
+			// bookmarks and errors are not bound to a namespace
+			return event, true
+		}
+	}), nil
+}
+
+func (lw *namespaceFilteredListerWatcher) withFieldSelector(options metav1.ListOptions) metav1.ListOptions {
+	if lw.fieldSelector == "" {
+		return options
+	}
+
+	if options.FieldSelector == "" {
+		options.FieldSelector = lw.fieldSelector
+	} else {
+		options.FieldSelector += "," + lw.fieldSelector
+	}
+	return options
+}
+
+// multiNamespaceListerWatcher lists and watches a namespaced resource in a
+// fixed set of namespaces, with one list and one watch per namespace.
+//
+// The resource versions of the namespaces are tracked separately, as the
+// single resource version known by the reflector is the one of whichever
+// namespace last received an event. The resource version requested by the
+// reflector is therefore only used to know whether it needs a consistent
+// list.
+type multiNamespaceListerWatcher struct {
+	namespaces     []string
+	listerWatchers map[string]cache.ListerWatcher
+
+	mu               sync.Mutex
+	resourceVersions map[string]string // namespace => last resource version
+}
+
+func newMultiNamespaceListerWatcher(namespaces []string, newListerWatcher func(namespace string) cache.ListerWatcher) *multiNamespaceListerWatcher {
+	listerWatchers := make(map[string]cache.ListerWatcher, len(namespaces))
+	for _, namespace := range namespaces {
+		listerWatchers[namespace] = newListerWatcher(namespace)
+	}
+
+	return &multiNamespaceListerWatcher{
+		namespaces:       namespaces,
+		listerWatchers:   listerWatchers,
+		resourceVersions: make(map[string]string, len(namespaces)),
+	}
+}
+
+// List lists the resources of all the namespaces into a single list
+func (lw *multiNamespaceListerWatcher) List(options metav1.ListOptions) (runtime.Object, error) {
+	var list runtime.Object
+	var items []runtime.Object
+	resourceVersions := make(map[string]string, len(lw.namespaces))
+
+	for _, namespace := range lw.namespaces {
+		nsOptions := options
+		// pages can't span several namespaces, so each namespace is
+		// listed at once
+		nsOptions.Limit = 0
+		nsOptions.Continue = ""
+		if options.ResourceVersion != "" && options.ResourceVersion != "0" {
+			nsOptions.ResourceVersion = lw.resourceVersion(namespace)
+			nsOptions.ResourceVersionMatch = ""
+			if nsOptions.ResourceVersion != "" {
+				nsOptions.ResourceVersionMatch = metav1.ResourceVersionMatchNotOlderThan
+			}
+		}
+
+		nsList, err := lw.listerWatchers[namespace].List(nsOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		nsItems, err := meta.ExtractList(nsList)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, nsItems...)
+
+		listMeta, err := meta.ListAccessor(nsList)
+		if err != nil {
+			return nil, err
+		}
+		resourceVersions[namespace] = listMeta.GetResourceVersion()
+
+		if list == nil {
+			list = nsList
+		}
+	}
+
+	if err := meta.SetList(list, items); err != nil {
+		return nil, err
+	}
+
+	lw.mu.Lock()
+	lw.resourceVersions = resourceVersions
+	lw.mu.Unlock()
+
+	return list, nil
+}
+
+// Watch watches the resources of all the namespaces, each from its last
+// resource version
+func (lw *multiNamespaceListerWatcher) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	watchers := make([]watch.Interface, 0, len(lw.namespaces))
+	for _, namespace := range lw.namespaces {
+		nsOptions := options
+		nsOptions.ResourceVersion = lw.resourceVersion(namespace)
+
+		w, err := lw.listerWatchers[namespace].Watch(nsOptions)
+		if err != nil {
+			for _, started := range watchers {
+				started.Stop()
+			}
+			return nil, err
+		}
+		watchers = append(watchers, w)
+	}
+
+	return newMultiNamespaceWatch(lw, watchers), nil
+}
+
+func (lw *multiNamespaceListerWatcher) resourceVersion(namespace string) string {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	return lw.resourceVersions[namespace]
+}
+
+func (lw *multiNamespaceListerWatcher) setResourceVersion(namespace, resourceVersion string) {
+	if resourceVersion == "" {
+		return
+	}
+
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+	lw.resourceVersions[namespace] = resourceVersion
+}
+
+// multiNamespaceWatch merges the watches of several namespaces. It stops as
+// soon as one of them stops, for the reflector to start a new one.
+type multiNamespaceWatch struct {
+	watchers []watch.Interface
+	result   chan watch.Event
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func newMultiNamespaceWatch(lw *multiNamespaceListerWatcher, watchers []watch.Interface) *multiNamespaceWatch {
+	w := &multiNamespaceWatch{
+		watchers: watchers,
+		result:   make(chan watch.Event),
+		done:     make(chan struct{}),
+	}
+
+	var wg sync.WaitGroup
+	for i, nsWatcher := range watchers {
+		wg.Add(1)
+		go func(namespace string, nsWatcher watch.Interface) {
+			defer wg.Done()
+			defer w.Stop()
+
+			for {
+				select {
+				case event, ok := <-nsWatcher.ResultChan():
+					if !ok {
+						return
+					}
+
+					select {
+					case w.result <- event:
+					case <-w.done:
+						return
+					}
+
+					// the resource version is only recorded once the
+					// event is delivered, so that it is received again
+					// by the next watch otherwise
+					if event.Type != watch.Error {
+						if accessor, err := meta.Accessor(event.Object); err == nil {
+							lw.setResourceVersion(namespace, accessor.GetResourceVersion())
+						}
+					}
+				case <-w.done:
+					return
+				}
+			}
+		}(lw.namespaces[i], nsWatcher)
+	}
+
+	go func() {
+		wg.Wait()
+		close(w.result)
+	}()
+
+	return w
+}
+
+// Stop stops the watches of all the namespaces
+func (w *multiNamespaceWatch) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.done)
+		for _, nsWatcher := range w.watchers {
+			nsWatcher.Stop()
+		}
+	})
+}
+
+// ResultChan returns the events of all the namespaces
+func (w *multiNamespaceWatch) ResultChan() <-chan watch.Event {
+	return w.result
+}
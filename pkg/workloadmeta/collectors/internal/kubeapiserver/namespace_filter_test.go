@@ -0,0 +1,321 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build kubeapiserver && test
+
+package kubeapiserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/DataDog/datadog-agent/pkg/config"
+)
+
+func newTestPod(namespace, name string) *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name}}
+}
+
+func newTestPodListerWatcher(client *fake.Clientset) func(namespace string) cache.ListerWatcher {
+	return func(namespace string) cache.ListerWatcher {
+		return &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return client.CoreV1().Pods(namespace).List(context.TODO(), options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.CoreV1().Pods(namespace).Watch(context.TODO(), options)
+			},
+		}
+	}
+}
+
+// stubListerWatcher records the options of its calls and returns an empty
+// pod list with the given resource version
+type stubListerWatcher struct {
+	resourceVersion string
+	listOptions     []metav1.ListOptions
+	watchOptions    []metav1.ListOptions
+}
+
+func (lw *stubListerWatcher) List(options metav1.ListOptions) (runtime.Object, error) {
+	lw.listOptions = append(lw.listOptions, options)
+	return &corev1.PodList{ListMeta: metav1.ListMeta{ResourceVersion: lw.resourceVersion}}, nil
+}
+
+func (lw *stubListerWatcher) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	lw.watchOptions = append(lw.watchOptions, options)
+	return watch.NewFake(), nil
+}
+
+func TestNamespaceFilter(t *testing.T) {
+	tests := []struct {
+		name            string
+		include         []string
+		exclude         []string
+		expectNil       bool
+		expectExcluded  []string
+		expectCollected []string
+	}{
+		{
+			name:      "no patterns",
+			expectNil: true,
+		},
+		{
+			name:      "invalid patterns only",
+			include:   []string{"["},
+			expectNil: true,
+		},
+		{
+			name:            "include",
+			include:         []string{"^team-"},
+			expectExcluded:  []string{"default", "kube-system"},
+			expectCollected: []string{"team-a", "team-b"},
+		},
+		{
+			name:            "exclude",
+			exclude:         []string{"^kube-"},
+			expectExcluded:  []string{"kube-system", "kube-public"},
+			expectCollected: []string{"default", "team-a"},
+		},
+		{
+			name:            "exclude takes precedence",
+			include:         []string{"^team-"},
+			exclude:         []string{"^team-b$"},
+			expectExcluded:  []string{"default", "team-b"},
+			expectCollected: []string{"team-a"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Mock(t)
+			cfg.Set("kubernetes_namespace_include_patterns", tt.include)
+			cfg.Set("kubernetes_namespace_exclude_patterns", tt.exclude)
+
+			filter := newNamespaceFilter(cfg)
+			if tt.expectNil {
+				assert.Nil(t, filter)
+				return
+			}
+			require.NotNil(t, filter)
+
+			for _, namespace := range tt.expectExcluded {
+				assert.True(t, filter.isExcluded(namespace), namespace)
+			}
+			for _, namespace := range tt.expectCollected {
+				assert.False(t, filter.isExcluded(namespace), namespace)
+			}
+		})
+	}
+}
+
+func TestNamespaceFilteredListerWatcher(t *testing.T) {
+	cfg := config.Mock(t)
+	cfg.Set("kubernetes_namespace_exclude_patterns", []string{"^kube-system$"})
+
+	client := fake.NewSimpleClientset(
+		newTestPod("default", "collected"),
+		newTestPod("kube-system", "excluded"),
+	)
+
+	cfg.Set("kubernetes_namespace_exclude_patterns", []string{"^kube-"})
+
+	lw := filterNamespaces(newTestPodListerWatcher(client))
+
+	list, err := lw.List(metav1.ListOptions{})
+	require.NoError(t, err)
+	pods := list.(*corev1.PodList).Items
+	require.Len(t, pods, 1)
+	assert.Equal(t, "collected", pods[0].Name)
+
+	w, err := lw.Watch(metav1.ListOptions{})
+	require.NoError(t, err)
+	defer w.Stop()
+
+	_, err = client.CoreV1().Pods("kube-system").Create(context.TODO(), newTestPod("kube-system", "excluded-new"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = client.CoreV1().Pods("default").Create(context.TODO(), newTestPod("default", "collected-new"), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	select {
+	case event := <-w.ResultChan():
+		assert.Equal(t, watch.Added, event.Type)
+		assert.Equal(t, "collected-new", event.Object.(*corev1.Pod).Name)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timeout waiting for the watch event")
+	}
+}
+
+func TestLiteralNamespacePatterns(t *testing.T) {
+	tests := []struct {
+		name                   string
+		include                []string
+		exclude                []string
+		expectNamespaces       []string
+		expectLiteralIncludes  bool
+		expectExcludeSelectors string
+	}{
+		{
+			name:                  "literal include patterns",
+			include:               []string{"^team-b$", "^team-a$", "^team-a$"},
+			expectNamespaces:      []string{"team-a", "team-b"},
+			expectLiteralIncludes: true,
+		},
+		{
+			name:                  "excluded literal include pattern",
+			include:               []string{"^team-a$", "^team-b$"},
+			exclude:               []string{"^team-b$"},
+			expectNamespaces:      []string{"team-a"},
+			expectLiteralIncludes: true,
+			// the namespace is not listed at all, but excluding it
+			// with a field selector doesn't hurt
+			expectExcludeSelectors: "metadata.namespace!=team-b",
+		},
+		{
+			name:    "regex include pattern",
+			include: []string{"^team-a$", "^team-"},
+		},
+		{
+			name:    "unanchored include pattern",
+			include: []string{"team-a"},
+		},
+		{
+			name:                   "literal and regex exclude patterns",
+			exclude:                []string{"^kube-system$", "^kube-", "^default$"},
+			expectExcludeSelectors: "metadata.namespace!=kube-system,metadata.namespace!=default",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Mock(t)
+			cfg.Set("kubernetes_namespace_include_patterns", tt.include)
+			cfg.Set("kubernetes_namespace_exclude_patterns", tt.exclude)
+
+			filter := newNamespaceFilter(cfg)
+			require.NotNil(t, filter)
+
+			namespaces, ok := filter.includedNamespaces()
+			assert.Equal(t, tt.expectLiteralIncludes, ok)
+			assert.Equal(t, tt.expectNamespaces, namespaces)
+			assert.Equal(t, tt.expectExcludeSelectors, filter.excludeFieldSelector())
+		})
+	}
+}
+
+func TestNamespaceFilteredListerWatcherFieldSelector(t *testing.T) {
+	cfg := config.Mock(t)
+	cfg.Set("kubernetes_namespace_exclude_patterns", []string{"^kube-system$", "^kube-"})
+
+	stub := &stubListerWatcher{}
+	lw := filterNamespaces(func(namespace string) cache.ListerWatcher {
+		assert.Equal(t, metav1.NamespaceAll, namespace)
+		return stub
+	})
+
+	_, err := lw.List(metav1.ListOptions{})
+	require.NoError(t, err)
+	w, err := lw.Watch(metav1.ListOptions{FieldSelector: "spec.nodeName=node"})
+	require.NoError(t, err)
+	w.Stop()
+
+	require.Len(t, stub.listOptions, 1)
+	assert.Equal(t, "metadata.namespace!=kube-system", stub.listOptions[0].FieldSelector)
+	require.Len(t, stub.watchOptions, 1)
+	assert.Equal(t, "spec.nodeName=node,metadata.namespace!=kube-system", stub.watchOptions[0].FieldSelector)
+}
+
+func TestMultiNamespaceListerWatcher(t *testing.T) {
+	cfg := config.Mock(t)
+	cfg.Set("kubernetes_namespace_include_patterns", []string{"^team-a$", "^team-b$"})
+
+	client := fake.NewSimpleClientset(
+		newTestPod("team-a", "collected-a"),
+		newTestPod("team-b", "collected-b"),
+		newTestPod("other", "not-collected"),
+	)
+
+	lw := filterNamespaces(newTestPodListerWatcher(client))
+	require.IsType(t, &multiNamespaceListerWatcher{}, lw)
+
+	list, err := lw.List(metav1.ListOptions{ResourceVersion: "0"})
+	require.NoError(t, err)
+	var names []string
+	for _, pod := range list.(*corev1.PodList).Items {
+		names = append(names, pod.Name)
+	}
+	assert.ElementsMatch(t, []string{"collected-a", "collected-b"}, names)
+
+	w, err := lw.Watch(metav1.ListOptions{})
+	require.NoError(t, err)
+
+	// the resources are only requested in the included namespaces
+	var namespaces []string
+	for _, action := range client.Actions() {
+		namespaces = append(namespaces, action.GetNamespace())
+	}
+	assert.ElementsMatch(t, []string{"team-a", "team-b", "team-a", "team-b"}, namespaces)
+
+	_, err = client.CoreV1().Pods("other").Create(context.TODO(), newTestPod("other", "not-collected-new"), metav1.CreateOptions{})
+	require.NoError(t, err)
+	_, err = client.CoreV1().Pods("team-b").Create(context.TODO(), newTestPod("team-b", "collected-new"), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	select {
+	case event := <-w.ResultChan():
+		assert.Equal(t, watch.Added, event.Type)
+		assert.Equal(t, "collected-new", event.Object.(*corev1.Pod).Name)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timeout waiting for the watch event")
+	}
+
+	w.Stop()
+	assert.Eventually(t, func() bool {
+		_, ok := <-w.ResultChan()
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestMultiNamespaceListerWatcherResourceVersions(t *testing.T) {
+	stubs := map[string]*stubListerWatcher{
+		"team-a": {resourceVersion: "10"},
+		"team-b": {resourceVersion: "20"},
+	}
+	lw := newMultiNamespaceListerWatcher([]string{"team-a", "team-b"}, func(namespace string) cache.ListerWatcher {
+		return stubs[namespace]
+	})
+
+	list, err := lw.List(metav1.ListOptions{ResourceVersion: "0", Limit: 500})
+	require.NoError(t, err)
+	require.NotNil(t, list)
+	for _, stub := range stubs {
+		require.Len(t, stub.listOptions, 1)
+		assert.Equal(t, metav1.ListOptions{ResourceVersion: "0"}, stub.listOptions[0], "lists are not paginated")
+	}
+
+	// each namespace is watched from its own resource version
+	w, err := lw.Watch(metav1.ListOptions{ResourceVersion: "20"})
+	require.NoError(t, err)
+	assert.Equal(t, "10", stubs["team-a"].watchOptions[0].ResourceVersion)
+	assert.Equal(t, "20", stubs["team-b"].watchOptions[0].ResourceVersion)
+	w.Stop()
+
+	// a relist from the reflector's resource version lists each namespace
+	// from its own resource version
+	_, err = lw.List(metav1.ListOptions{ResourceVersion: "20"})
+	require.NoError(t, err)
+	assert.Equal(t, metav1.ListOptions{ResourceVersion: "10", ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan}, stubs["team-a"].listOptions[1])
+	assert.Equal(t, metav1.ListOptions{ResourceVersion: "20", ResourceVersionMatch: metav1.ResourceVersionMatchNotOlderThan}, stubs["team-b"].listOptions[1])
+}
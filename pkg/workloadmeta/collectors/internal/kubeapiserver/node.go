@@ -34,7 +34,7 @@ func newNodeStore(ctx context.Context, wlm workloadmeta.Store, client kubernetes
 	nodeStore := newNodeReflectorStore(wlm)
 	nodeReflector := cache.NewNamedReflector(
 		componentName,
-		nodeListerWatcher,
+		newReconnectingListerWatcher(ctx, "nodes", nodeListerWatcher),
 		&corev1.Node{},
 		nodeStore,
 		noResync,
@@ -62,6 +62,14 @@ func newNodeParser() objectParser {
 func (p nodeParser) Parse(obj interface{}) workloadmeta.Entity {
 	node := obj.(*corev1.Node)
 
+	var conditions map[string]bool
+	if len(node.Status.Conditions) > 0 {
+		conditions = make(map[string]bool, len(node.Status.Conditions))
+		for _, condition := range node.Status.Conditions {
+			conditions[string(condition.Type)] = condition.Status == corev1.ConditionTrue
+		}
+	}
+
 	return &workloadmeta.KubernetesNode{
 		EntityID: workloadmeta.EntityID{
 			Kind: workloadmeta.KindKubernetesNode,
@@ -72,5 +80,6 @@ func (p nodeParser) Parse(obj interface{}) workloadmeta.Entity {
 			Annotations: node.Annotations,
 			Labels:      node.Labels,
 		},
+		Conditions: conditions,
 	}
 }
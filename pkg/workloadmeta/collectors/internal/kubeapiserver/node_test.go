@@ -32,12 +32,28 @@ func TestNodeParser_Parse(t *testing.T) {
 			Name:   "test-node",
 			Labels: map[string]string{"test-label": "test-value"},
 		},
+		Conditions: map[string]bool{
+			"Ready":        true,
+			"DiskPressure": false,
+		},
 	}
 	node := &corev1.Node{
 		ObjectMeta: v1.ObjectMeta{
 			Name:   expected.ID,
 			Labels: expected.Labels,
 		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{
+					Type:   corev1.NodeReady,
+					Status: corev1.ConditionTrue,
+				},
+				{
+					Type:   corev1.NodeDiskPressure,
+					Status: corev1.ConditionFalse,
+				},
+			},
+		},
 	}
 	entity := parser.Parse(node)
 	storedNode, ok := entity.(*workloadmeta.KubernetesNode)
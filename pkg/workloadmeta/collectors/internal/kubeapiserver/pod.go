@@ -10,6 +10,7 @@ package kubeapiserver
 import (
 	"context"
 	"regexp"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -24,19 +25,21 @@ import (
 )
 
 func newPodStore(ctx context.Context, wlm workloadmeta.Store, client kubernetes.Interface) (*cache.Reflector, *reflectorStore) {
-	podListerWatcher := &cache.ListWatch{
-		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
-			return client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, options)
-		},
-		WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
-			return client.CoreV1().Pods(metav1.NamespaceAll).Watch(ctx, options)
-		},
+	podListerWatcher := func(namespace string) cache.ListerWatcher {
+		return &cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				return client.CoreV1().Pods(namespace).List(ctx, options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				return client.CoreV1().Pods(namespace).Watch(ctx, options)
+			},
+		}
 	}
 
 	podStore := newPodReflectorStore(wlm)
 	podReflector := cache.NewNamedReflector(
 		componentName,
-		podListerWatcher,
+		newReconnectingListerWatcher(ctx, "pods", filterNamespaces(podListerWatcher)),
 		&corev1.Pod{},
 		podStore,
 		noResync,
@@ -73,6 +76,43 @@ func newPodParser(annotationsExclude []string) (objectParser, error) {
 	return podParser{annotationsFilter: filters}, nil
 }
 
+// secretNames returns the names of the secrets referenced by the pod, either
+// through volumes or container environment variables.
+func secretNames(pod *corev1.Pod) []string {
+	var names []string
+	seen := make(map[string]struct{})
+	add := func(name string) {
+		if _, found := seen[name]; name == "" || found {
+			return
+		}
+		seen[name] = struct{}{}
+		names = append(names, name)
+	}
+
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Secret != nil {
+			add(volume.Secret.SecretName)
+		}
+	}
+
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for _, container := range containers {
+			for _, envFrom := range container.EnvFrom {
+				if envFrom.SecretRef != nil {
+					add(envFrom.SecretRef.Name)
+				}
+			}
+			for _, env := range container.Env {
+				if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+					add(env.ValueFrom.SecretKeyRef.Name)
+				}
+			}
+		}
+	}
+
+	return names
+}
+
 func (p podParser) Parse(obj interface{}) workloadmeta.Entity {
 	pod := obj.(*corev1.Pod)
 	owners := make([]workloadmeta.KubernetesPodOwner, 0, len(pod.OwnerReferences))
@@ -85,15 +125,29 @@ func (p podParser) Parse(obj interface{}) workloadmeta.Entity {
 	}
 
 	var ready bool
+	var scheduledAt time.Time
+	var conditions map[string]bool
+	if len(pod.Status.Conditions) > 0 {
+		conditions = make(map[string]bool, len(pod.Status.Conditions))
+	}
 	for _, condition := range pod.Status.Conditions {
-		if condition.Type == corev1.PodReady {
+		conditions[string(condition.Type)] = condition.Status == corev1.ConditionTrue
+
+		switch condition.Type {
+		case corev1.PodReady:
+			ready = condition.Status == corev1.ConditionTrue
+		case corev1.PodScheduled:
 			if condition.Status == corev1.ConditionTrue {
-				ready = true
+				scheduledAt = condition.LastTransitionTime.Time
 			}
-			break
 		}
 	}
 
+	var startTime *time.Time
+	if pod.Status.StartTime != nil {
+		startTime = &pod.Status.StartTime.Time
+	}
+
 	var pvcNames []string
 	for _, volume := range pod.Spec.Volumes {
 		if volume.PersistentVolumeClaim != nil {
@@ -101,6 +155,37 @@ func (p podParser) Parse(obj interface{}) workloadmeta.Entity {
 		}
 	}
 
+	var topologySpreadKeys []string
+	for _, constraint := range pod.Spec.TopologySpreadConstraints {
+		topologySpreadKeys = append(topologySpreadKeys, constraint.TopologyKey)
+	}
+
+	var tolerationKeys []string
+	seenTolerationKeys := make(map[string]struct{})
+	for _, toleration := range pod.Spec.Tolerations {
+		if _, found := seenTolerationKeys[toleration.Key]; toleration.Key == "" || found {
+			continue
+		}
+		seenTolerationKeys[toleration.Key] = struct{}{}
+		tolerationKeys = append(tolerationKeys, toleration.Key)
+	}
+
+	var runtimeClass string
+	if pod.Spec.RuntimeClassName != nil {
+		runtimeClass = *pod.Spec.RuntimeClassName
+	}
+
+	var configMapNames []string
+	for _, containers := range [][]corev1.Container{pod.Spec.InitContainers, pod.Spec.Containers} {
+		for _, container := range containers {
+			for _, envFrom := range container.EnvFrom {
+				if envFrom.ConfigMapRef != nil && envFrom.ConfigMapRef.Name != "" {
+					configMapNames = append(configMapNames, envFrom.ConfigMapRef.Name)
+				}
+			}
+		}
+	}
+
 	return &workloadmeta.KubernetesPod{
 		EntityID: workloadmeta.EntityID{
 			Kind: workloadmeta.KindKubernetesPod,
@@ -115,10 +200,20 @@ func (p podParser) Parse(obj interface{}) workloadmeta.Entity {
 		Phase:                      string(pod.Status.Phase),
 		Owners:                     owners,
 		PersistentVolumeClaimNames: pvcNames,
+		ConfigMapNames:             configMapNames,
+		SecretNames:                secretNames(pod),
 		Ready:                      ready,
+		Conditions:                 conditions,
 		IP:                         pod.Status.PodIP,
+		NodeName:                   pod.Spec.NodeName,
+		NominatedNodeName:          pod.Status.NominatedNodeName,
+		TopologySpreadKeys:         topologySpreadKeys,
+		TolerationKeys:             tolerationKeys,
 		PriorityClass:              pod.Spec.PriorityClassName,
+		RuntimeClass:               runtimeClass,
 		QOSClass:                   string(pod.Status.QOSClass),
+		ScheduledAt:                scheduledAt,
+		StartTime:                  startTime,
 
 		// Containers could be generated by this collector, but
 		// currently it's not to save on memory, since this is supposed
@@ -10,18 +10,22 @@ package kubeapiserver
 import (
 	"context"
 	"testing"
+	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/fake"
 
+	"github.com/DataDog/datadog-agent/pkg/util/pointer"
 	"github.com/DataDog/datadog-agent/pkg/workloadmeta"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestPodParser_Parse(t *testing.T) {
 	filterAnnotations := []string{"ignoreAnnotation"}
+	scheduledAt := time.Date(2023, time.October, 2, 12, 30, 0, 0, time.UTC)
+	startTime := scheduledAt.Add(5 * time.Second)
 
 	parser, err := newPodParser(filterAnnotations)
 	assert.NoError(t, err)
@@ -47,6 +51,45 @@ func TestPodParser_Parse(t *testing.T) {
 		},
 		Spec: corev1.PodSpec{
 			PriorityClassName: "priorityClass",
+			RuntimeClassName:  pointer.Ptr("gvisor"),
+			NodeName:          "nodeName",
+			TopologySpreadConstraints: []corev1.TopologySpreadConstraint{
+				{
+					TopologyKey: "topology.kubernetes.io/zone",
+				},
+			},
+			Tolerations: []corev1.Toleration{
+				{
+					Key:      "dedicated",
+					Operator: corev1.TolerationOpEqual,
+					Value:    "gpu",
+					Effect:   corev1.TaintEffectNoSchedule,
+				},
+				{
+					Key:    "dedicated",
+					Effect: corev1.TaintEffectNoExecute,
+				},
+				{
+					Operator: corev1.TolerationOpExists,
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name: "container",
+					EnvFrom: []corev1.EnvFromSource{
+						{
+							ConfigMapRef: &corev1.ConfigMapEnvSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "configMapName"},
+							},
+						},
+						{
+							SecretRef: &corev1.SecretEnvSource{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "secretName"},
+							},
+						},
+					},
+				},
+			},
 			Volumes: []corev1.Volume{
 				{
 					Name: "pvcVol",
@@ -61,13 +104,20 @@ func TestPodParser_Parse(t *testing.T) {
 		Status: corev1.PodStatus{
 			Phase: corev1.PodRunning,
 			Conditions: []corev1.PodCondition{
+				{
+					Type:               corev1.PodScheduled,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(scheduledAt),
+				},
 				{
 					Type:   corev1.PodReady,
 					Status: corev1.ConditionTrue,
 				},
 			},
-			PodIP:    "127.0.0.1",
-			QOSClass: corev1.PodQOSGuaranteed,
+			PodIP:             "127.0.0.1",
+			NominatedNodeName: "nominatedNodeName",
+			StartTime:         pointer.Ptr(metav1.NewTime(startTime)),
+			QOSClass:          corev1.PodQOSGuaranteed,
 		},
 	}
 
@@ -97,10 +147,23 @@ func TestPodParser_Parse(t *testing.T) {
 			},
 		},
 		PersistentVolumeClaimNames: []string{"pvcName"},
+		ConfigMapNames:             []string{"configMapName"},
+		SecretNames:                []string{"secretName"},
 		Ready:                      true,
-		IP:                         "127.0.0.1",
-		PriorityClass:              "priorityClass",
-		QOSClass:                   "Guaranteed",
+		Conditions: map[string]bool{
+			"PodScheduled": true,
+			"Ready":        true,
+		},
+		IP:                 "127.0.0.1",
+		NodeName:           "nodeName",
+		NominatedNodeName:  "nominatedNodeName",
+		TopologySpreadKeys: []string{"topology.kubernetes.io/zone"},
+		TolerationKeys:     []string{"dedicated"},
+		PriorityClass:      "priorityClass",
+		RuntimeClass:       "gvisor",
+		QOSClass:           "Guaranteed",
+		ScheduledAt:        scheduledAt,
+		StartTime:          &startTime,
 	}
 
 	assert.Equal(t, expected, parsed)
@@ -0,0 +1,140 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build kubeapiserver
+
+package kubeapiserver
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/DataDog/datadog-agent/pkg/telemetry"
+	"github.com/DataDog/datadog-agent/pkg/util/log"
+)
+
+const telemetrySubsystem = "kubeapiserver"
+
+var (
+	reconnectInitialInterval = 1 * time.Second
+	reconnectMaxInterval     = 5 * time.Minute
+
+	reconnections = telemetry.NewCounterWithOpts(
+		telemetrySubsystem,
+		"reconnections",
+		[]string{"resource"},
+		"Number of times the kubeapiserver collector reconnected to the API server after losing the connection.",
+		telemetry.Options{NoDoubleUnderscoreSep: true},
+	)
+
+	disconnectDuration = telemetry.NewHistogramWithOpts(
+		telemetrySubsystem,
+		"disconnect_duration_seconds",
+		[]string{"resource"},
+		"Time during which the kubeapiserver collector couldn't reach the API server, in seconds.",
+		[]float64{1, 5, 10, 30, 60, 300, 900, 3600},
+		telemetry.Options{NoDoubleUnderscoreSep: true},
+	)
+)
+
+// reconnectingListerWatcher wraps the ListerWatcher of a reflector to keep
+// track of its connection to the API server. While the API server is
+// unreachable, failed calls are retried with an exponential backoff (on top
+// of the short backoff of the reflector), and reconnections are reported in
+// telemetry.
+type reconnectingListerWatcher struct {
+	cache.ListerWatcher
+
+	ctx      context.Context
+	resource string
+
+	mu                sync.Mutex
+	backoff           *backoff.ExponentialBackOff
+	disconnectedSince time.Time
+}
+
+func newReconnectingListerWatcher(ctx context.Context, resource string, lw cache.ListerWatcher) *reconnectingListerWatcher {
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.InitialInterval = reconnectInitialInterval
+	expBackoff.MaxInterval = reconnectMaxInterval
+	expBackoff.MaxElapsedTime = 0 // retry forever
+
+	return &reconnectingListerWatcher{
+		ListerWatcher: lw,
+		ctx:           ctx,
+		resource:      resource,
+		backoff:       expBackoff,
+	}
+}
+
+// List lists the resources, waiting for the backoff delay before returning
+// an error
+func (lw *reconnectingListerWatcher) List(options metav1.ListOptions) (runtime.Object, error) {
+	obj, err := lw.ListerWatcher.List(options)
+	if err != nil {
+		lw.onError(err)
+		return nil, err
+	}
+
+	lw.onSuccess()
+	return obj, nil
+}
+
+// Watch watches the resources, waiting for the backoff delay before
+// returning an error
+func (lw *reconnectingListerWatcher) Watch(options metav1.ListOptions) (watch.Interface, error) {
+	w, err := lw.ListerWatcher.Watch(options)
+	if err != nil {
+		lw.onError(err)
+		return nil, err
+	}
+
+	lw.onSuccess()
+	return w, nil
+}
+
+func (lw *reconnectingListerWatcher) onError(err error) {
+	lw.mu.Lock()
+	if lw.disconnectedSince.IsZero() {
+		lw.disconnectedSince = time.Now()
+		log.Warnf("kubeapiserver collector lost the connection to the API server for %s: %s", lw.resource, err)
+	}
+	delay := lw.backoff.NextBackOff()
+	lw.mu.Unlock()
+
+	log.Debugf("kubeapiserver collector will retry to reach the API server for %s in %s", lw.resource, delay)
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-lw.ctx.Done():
+	case <-timer.C:
+	}
+}
+
+func (lw *reconnectingListerWatcher) onSuccess() {
+	lw.mu.Lock()
+	defer lw.mu.Unlock()
+
+	lw.backoff.Reset()
+
+	if lw.disconnectedSince.IsZero() {
+		return
+	}
+
+	duration := time.Since(lw.disconnectedSince)
+	lw.disconnectedSince = time.Time{}
+
+	reconnections.Inc(lw.resource)
+	disconnectDuration.Observe(duration.Seconds(), lw.resource)
+	log.Infof("kubeapiserver collector reconnected to the API server for %s after %s", lw.resource, duration)
+}
@@ -0,0 +1,111 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+//go:build kubeapiserver && test
+
+package kubeapiserver
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+
+	"github.com/DataDog/datadog-agent/comp/core/telemetry"
+	"github.com/DataDog/datadog-agent/pkg/workloadmeta"
+)
+
+func setReconnectIntervals(t *testing.T, initial, maxInterval time.Duration) {
+	previousInitial, previousMax := reconnectInitialInterval, reconnectMaxInterval
+	reconnectInitialInterval, reconnectMaxInterval = initial, maxInterval
+	t.Cleanup(func() {
+		reconnectInitialInterval, reconnectMaxInterval = previousInitial, previousMax
+	})
+}
+
+func getTelemetry(t *testing.T) string {
+	req, err := http.NewRequest("GET", "/metrics", nil)
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	telemetry.GetCompatComponent().Handler().ServeHTTP(rec, req)
+	return rec.Body.String()
+}
+
+func TestReconnectingListerWatcher(t *testing.T) {
+	setReconnectIntervals(t, time.Millisecond, 10*time.Millisecond)
+
+	client := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}})
+
+	// the API server is unreachable for the first lists
+	var failures atomic.Int32
+	failures.Store(3)
+	client.PrependReactor("list", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if failures.Add(-1) >= 0 {
+			return true, nil, errors.New("connection refused")
+		}
+		return false, nil, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	wlm := workloadmeta.NewMockStore()
+	reflector, store := newNodeStore(ctx, wlm, client)
+	go reflector.Run(ctx.Done())
+
+	assert.Eventually(t, store.HasSynced, 30*time.Second, 10*time.Millisecond)
+
+	node, err := wlm.GetKubernetesNode("test-node")
+	require.NoError(t, err)
+	assert.Equal(t, "test-node", node.Name)
+
+	metrics := getTelemetry(t)
+	assert.Contains(t, metrics, `kubeapiserver_reconnections{resource="nodes"} 1`)
+	assert.Contains(t, metrics, `kubeapiserver_disconnect_duration_seconds_count{resource="nodes"} 1`)
+}
+
+func TestReconnectingListerWatcher_backoff(t *testing.T) {
+	setReconnectIntervals(t, 50*time.Millisecond, time.Second)
+
+	client := fake.NewSimpleClientset()
+	client.PrependReactor("list", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	lw := newReconnectingListerWatcher(ctx, "nodes", &cache.ListWatch{
+		ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+			return client.CoreV1().Nodes().List(ctx, options)
+		},
+	})
+
+	// failed lists wait for the backoff delay
+	start := time.Now()
+	_, err := lw.List(metav1.ListOptions{})
+	assert.Error(t, err)
+	assert.GreaterOrEqual(t, time.Since(start), 25*time.Millisecond) // randomized between 0.5 and 1.5 times the interval
+	assert.False(t, lw.disconnectedSince.IsZero())
+
+	// stopping the collector interrupts the backoff
+	setReconnectIntervals(t, time.Hour, time.Hour)
+	lw = newReconnectingListerWatcher(ctx, "nodes", lw.ListerWatcher)
+	cancel()
+	start = time.Now()
+	_, err = lw.List(metav1.ListOptions{})
+	assert.Error(t, err)
+	assert.Less(t, time.Since(start), time.Minute)
+}
@@ -12,6 +12,7 @@ import (
 	"sync"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -43,6 +44,10 @@ type reflectorStore struct {
 
 	// filter to keep only resources that the Cluster-Agent needs
 	filter reflectorStoreFilter
+
+	// beat is called each time the reflector delivers objects, to report
+	// that the collector is still receiving them
+	beat func()
 }
 
 // The filter is called in Replace/Add/Delete functions before the obj is parsed
@@ -59,6 +64,7 @@ func (r *reflectorStore) Add(obj interface{}) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 	r.hasSynced = true
+	r.doBeat()
 	if r.filter != nil && r.filter.filteredOut(entity) {
 		// Don't store the object in memory if it is filtered out
 		return nil
@@ -131,6 +137,7 @@ func (r *reflectorStore) Replace(list []interface{}, _ string) error {
 	r.wlmetaStore.Notify(events)
 	r.seen = seenNow
 	r.hasSynced = true
+	r.doBeat()
 
 	return nil
 }
@@ -143,6 +150,7 @@ func (r *reflectorStore) Delete(obj interface{}) error {
 
 	var kind workloadmeta.Kind
 	var uid types.UID
+	var id string
 	switch v := obj.(type) {
 	case *corev1.Pod:
 		kind = workloadmeta.KindKubernetesPod
@@ -153,13 +161,25 @@ func (r *reflectorStore) Delete(obj interface{}) error {
 	case *appsv1.Deployment:
 		kind = workloadmeta.KindKubernetesDeployment
 		uid = v.UID
+	case *corev1.ConfigMap:
+		kind = workloadmeta.KindKubernetesConfigMap
+		uid = v.UID
+		id = configMapEntityID(v.Namespace, v.Name)
+	case *batchv1.Job:
+		kind = workloadmeta.KindKubernetesJob
+		uid = v.UID
 	default:
 		return fmt.Errorf("failed to identify Kind of object: %#v", obj)
 	}
 
 	r.hasSynced = true
+	r.doBeat()
 	delete(r.seen, string(uid))
 
+	if id == "" {
+		id = string(uid)
+	}
+
 	if r.filter != nil && r.filter.filteredOut(obj.(workloadmeta.Entity)) {
 		return nil
 	}
@@ -171,7 +191,7 @@ func (r *reflectorStore) Delete(obj interface{}) error {
 			Entity: &workloadmeta.KubernetesPod{
 				EntityID: workloadmeta.EntityID{
 					Kind: kind,
-					ID:   string(uid),
+					ID:   id,
 				},
 			},
 		},
@@ -211,3 +231,9 @@ func (r *reflectorStore) GetByKey(key string) (item interface{}, exists bool, er
 func (r *reflectorStore) Resync() error {
 	panic("not implemented")
 }
+
+func (r *reflectorStore) doBeat() {
+	if r.beat != nil {
+		r.beat()
+	}
+}
@@ -32,6 +32,8 @@ const (
 )
 
 type collector struct {
+	workloadmeta.Heartbeat
+
 	watcher    *kubelet.PodWatcher
 	store      workloadmeta.Store
 	lastExpire time.Time
@@ -79,6 +81,7 @@ func (c *collector) Pull(ctx context.Context) error {
 		}
 	}
 
+	c.Beat()
 	c.store.Notify(events)
 
 	return err
@@ -97,9 +100,10 @@ func (c *collector) parsePods(pods []*kubelet.Pod) []workloadmeta.CollectorEvent
 		// Validate allocation size.
 		// Limits hardcoded here are huge enough to never be hit.
 		if len(pod.Spec.Containers) > 10000 ||
-			len(pod.Spec.InitContainers) > 10000 {
-			log.Errorf("pod %s has a crazy number of containers: %d or init containers: %d. Skipping it!",
-				podMeta.UID, len(pod.Spec.Containers), len(pod.Spec.InitContainers))
+			len(pod.Spec.InitContainers) > 10000 ||
+			len(pod.Spec.EphemeralContainers) > 10000 {
+			log.Errorf("pod %s has a crazy number of containers: %d or init containers: %d or ephemeral containers: %d. Skipping it!",
+				podMeta.UID, len(pod.Spec.Containers), len(pod.Spec.InitContainers), len(pod.Spec.EphemeralContainers))
 			continue
 		}
 
@@ -114,6 +118,9 @@ func (c *collector) parsePods(pods []*kubelet.Pod) []workloadmeta.CollectorEvent
 			pod.Status.InitContainers,
 			&podID,
 		)
+		for i := range podInitContainers {
+			podInitContainers[i].IsInit = true
+		}
 
 		podContainers, containerEvents := c.parsePodContainers(
 			pod,
@@ -122,6 +129,13 @@ func (c *collector) parsePods(pods []*kubelet.Pod) []workloadmeta.CollectorEvent
 			&podID,
 		)
 
+		podEphemeralContainers, ephemeralContainerEvents := c.parsePodContainers(
+			pod,
+			pod.Spec.EphemeralContainers,
+			pod.Status.EphemeralContainers,
+			&podID,
+		)
+
 		podOwners := pod.Owners()
 		owners := make([]workloadmeta.KubernetesPodOwner, 0, len(podOwners))
 		for _, o := range podOwners {
@@ -134,6 +148,11 @@ func (c *collector) parsePods(pods []*kubelet.Pod) []workloadmeta.CollectorEvent
 
 		PodSecurityContext := extractPodSecurityContext(&pod.Spec)
 
+		var runtimeClass string
+		if pod.Spec.RuntimeClassName != nil {
+			runtimeClass = *pod.Spec.RuntimeClassName
+		}
+
 		entity := &workloadmeta.KubernetesPod{
 			EntityID: podID,
 			EntityMeta: workloadmeta.EntityMeta{
@@ -144,18 +163,29 @@ func (c *collector) parsePods(pods []*kubelet.Pod) []workloadmeta.CollectorEvent
 			},
 			Owners:                     owners,
 			PersistentVolumeClaimNames: pod.GetPersistentVolumeClaimNames(),
+			SecretNames:                pod.GetSecretNames(),
 			InitContainers:             podInitContainers,
 			Containers:                 podContainers,
+			EphemeralContainers:        podEphemeralContainers,
 			Ready:                      kubelet.IsPodReady(pod),
+			Conditions:                 pod.GetConditions(),
 			Phase:                      pod.Status.Phase,
 			IP:                         pod.Status.PodIP,
+			NodeName:                   pod.Spec.NodeName,
+			NominatedNodeName:          pod.Status.NominatedNode,
+			TopologySpreadKeys:         pod.GetTopologySpreadKeys(),
+			TolerationKeys:             pod.GetTolerationKeys(),
 			PriorityClass:              pod.Spec.PriorityClassName,
+			RuntimeClass:               runtimeClass,
 			QOSClass:                   pod.Status.QOSClass,
+			ScheduledAt:                pod.GetScheduledTime(),
+			StartTime:                  pod.Status.StartTime,
 			SecurityContext:            PodSecurityContext,
 		}
 
 		events = append(events, initContainerEvents...)
 		events = append(events, containerEvents...)
+		events = append(events, ephemeralContainerEvents...)
 		events = append(events, workloadmeta.CollectorEvent{
 			Source: workloadmeta.SourceNodeOrchestrator,
 			Type:   workloadmeta.EventTypeSet,
@@ -212,6 +242,11 @@ func (c *collector) parsePodContainers(
 			Name: container.Name,
 		}
 
+		if st := container.LastState.Terminated; st != nil {
+			exitCode := st.ExitCode
+			podContainer.LastExitCode = &exitCode
+		}
+
 		containerSpec := findContainerSpec(container.Name, containerSpecs)
 		if containerSpec != nil {
 			env = extractEnvFromSpec(containerSpec.Env)
@@ -222,6 +257,16 @@ func (c *collector) parsePodContainers(
 			}
 
 			podContainer.Image.ID = imageID
+			podContainer.ImagePullPolicy = containerSpec.ImagePullPolicy
+			podContainer.LivenessProbeType = containerSpec.LivenessProbe.Type()
+			if resources := containerSpec.Resources; resources != nil {
+				podContainer.Resources = workloadmeta.OrchestratorContainerResources{
+					CPURequest:    resources.Requests["cpu"],
+					CPULimit:      resources.Limits["cpu"],
+					MemoryRequest: resources.Requests["memory"],
+					MemoryLimit:   resources.Limits["memory"],
+				}
+			}
 			containerSecurityContext = extractContainerSecurityContext(containerSpec)
 			ports = make([]workloadmeta.ContainerPort, 0, len(containerSpec.Ports))
 			for _, port := range containerSpec.Ports {
@@ -20,6 +20,7 @@ import (
 	"github.com/DataDog/datadog-agent/pkg/config"
 	"github.com/DataDog/datadog-agent/pkg/errors"
 	"github.com/DataDog/datadog-agent/pkg/util/clusteragent"
+	"github.com/DataDog/datadog-agent/pkg/util/kubernetes"
 	"github.com/DataDog/datadog-agent/pkg/util/kubernetes/apiserver"
 	"github.com/DataDog/datadog-agent/pkg/util/kubernetes/kubelet"
 	"github.com/DataDog/datadog-agent/pkg/util/log"
@@ -33,15 +34,18 @@ const (
 )
 
 type collector struct {
-	store                  workloadmeta.Store
-	seen                   map[workloadmeta.EntityID]struct{}
-	kubeUtil               kubelet.KubeUtilInterface
-	apiClient              *apiserver.APIClient
-	dcaClient              clusteragent.DCAClientInterface
-	dcaEnabled             bool
-	updateFreq             time.Duration
-	lastUpdate             time.Time
-	collectNamespaceLabels bool
+	workloadmeta.Heartbeat
+
+	store                       workloadmeta.Store
+	seen                        map[workloadmeta.EntityID]struct{}
+	kubeUtil                    kubelet.KubeUtilInterface
+	apiClient                   *apiserver.APIClient
+	dcaClient                   clusteragent.DCAClientInterface
+	dcaEnabled                  bool
+	updateFreq                  time.Duration
+	lastUpdate                  time.Time
+	collectNamespaceLabels      bool
+	collectNamespaceAnnotations bool
 }
 
 func init() {
@@ -102,6 +106,7 @@ func (c *collector) Start(_ context.Context, store workloadmeta.Store) error {
 
 	c.updateFreq = time.Duration(config.Datadog.GetInt("kubernetes_metadata_tag_update_freq")) * time.Second
 	c.collectNamespaceLabels = len(config.Datadog.GetStringMapString("kubernetes_namespace_labels_as_tags")) > 0
+	c.collectNamespaceAnnotations = config.Datadog.GetBool("kubernetes_namespace_standard_tags_from_annotations")
 
 	return err
 }
@@ -112,6 +117,7 @@ func (c *collector) Pull(ctx context.Context) error {
 	timeDelta := c.lastUpdate.Add(c.updateFreq).Unix() - time.Now().Unix()
 	if timeDelta > 0 {
 		log.Tracef("skipping, next effective Pull will be in %d seconds", timeDelta)
+		c.Beat()
 		return nil
 	}
 
@@ -150,6 +156,7 @@ func (c *collector) Pull(ctx context.Context) error {
 
 	c.seen = seen
 
+	c.Beat()
 	c.store.Notify(events)
 
 	c.lastUpdate = time.Now()
@@ -182,6 +189,9 @@ func (c *collector) parsePods(
 		}
 	}
 
+	// namespace annotations are shared by all pods of a namespace, only query them once
+	nsAnnotationsByName := make(map[string]map[string]string)
+
 	for _, pod := range pods {
 		if pod.Metadata.UID == "" {
 			continue
@@ -216,6 +226,15 @@ func (c *collector) parsePods(
 			log.Debugf("Could not fetch namespace labels for pod %s/%s: %v", pod.Metadata.Namespace, pod.Metadata.Name, err)
 		}
 
+		nsAnnotations, found := nsAnnotationsByName[pod.Metadata.Namespace]
+		if !found {
+			nsAnnotations, err = c.getNamespaceAnnotations(apiserver.GetNamespaceAnnotations, pod.Metadata.Namespace)
+			if err != nil {
+				log.Debugf("Could not fetch namespace annotations for pod %s/%s: %v", pod.Metadata.Namespace, pod.Metadata.Name, err)
+			}
+			nsAnnotationsByName[pod.Metadata.Namespace] = nsAnnotations
+		}
+
 		entityID := workloadmeta.EntityID{
 			Kind: workloadmeta.KindKubernetesPod,
 			ID:   pod.Metadata.UID,
@@ -231,8 +250,9 @@ func (c *collector) parsePods(
 				Annotations: pod.Metadata.Annotations,
 				Labels:      pod.Metadata.Labels,
 			},
-			KubeServices:    services,
-			NamespaceLabels: nsLabels,
+			KubeServices:         services,
+			NamespaceLabels:      nsLabels,
+			NamespaceAnnotations: nsAnnotations,
 		}
 
 		events = append(events, workloadmeta.CollectorEvent{
@@ -283,6 +303,35 @@ func (c *collector) getNamespaceLabels(getNamespaceLabelsFromAPIServerFunc func(
 	return getNamespaceLabelsFromAPIServerFunc(ns)
 }
 
+// getNamespaceAnnotations returns the namespace annotations setting the standard tags of pods,
+// fast return if their collection is disabled.
+func (c *collector) getNamespaceAnnotations(getNamespaceAnnotationsFromAPIServerFunc func(string) (map[string]string, error), ns string) (map[string]string, error) {
+	if !c.collectNamespaceAnnotations {
+		return nil, nil
+	}
+
+	if c.isDCAEnabled() {
+		getNamespaceAnnotationsFromAPIServerFunc = c.dcaClient.GetNamespaceAnnotations
+	}
+
+	annotations, err := getNamespaceAnnotationsFromAPIServerFunc(ns)
+	if err != nil {
+		return nil, err
+	}
+
+	// the API server returns all the annotations of the namespace
+	var standardTagsAnnotations map[string]string
+	for _, key := range kubernetes.NamespaceStandardTagsAnnotKeys {
+		if value, found := annotations[key]; found {
+			if standardTagsAnnotations == nil {
+				standardTagsAnnotations = make(map[string]string)
+			}
+			standardTagsAnnotations[key] = value
+		}
+	}
+	return standardTagsAnnotations, nil
+}
+
 func (c *collector) isDCAEnabled() bool {
 	if c.dcaEnabled && c.dcaClient != nil {
 		v := c.dcaClient.Version()
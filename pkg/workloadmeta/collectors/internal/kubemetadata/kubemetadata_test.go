@@ -43,6 +43,9 @@ type FakeDCAClient struct {
 	NamespaceLabels    map[string]string
 	NamespaceLabelsErr error
 
+	NamespaceAnnotations    map[string]string
+	NamespaceAnnotationsErr error
+
 	PodMetadataForNode    apiv1.NamespacesPodsStringsSet
 	PodMetadataForNodeErr error
 
@@ -86,6 +89,10 @@ func (f *FakeDCAClient) GetNamespaceLabels(nsName string) (map[string]string, er
 	return f.NamespaceLabels, f.NamespaceLabelsErr
 }
 
+func (f *FakeDCAClient) GetNamespaceAnnotations(nsName string) (map[string]string, error) {
+	return f.NamespaceAnnotations, f.NamespaceAnnotationsErr
+}
+
 func (f *FakeDCAClient) GetPodsMetadataForNode(nodeName string) (apiv1.NamespacesPodsStringsSet, error) {
 	return f.PodMetadataForNode, f.PodMetadataForNodeErr
 }
@@ -393,6 +400,85 @@ func TestKubeMetadataCollector_getNamespaceLabels(t *testing.T) {
 	}
 }
 
+func TestKubeMetadataCollector_getNamespaceAnnotations(t *testing.T) {
+	nsAnnotations := map[string]string{
+		"ad.datadoghq.com/cluster.env":                     "prod",
+		"ad.datadoghq.com/cluster.service":                 "billing",
+		"kubectl.kubernetes.io/last-applied-configuration": "{}",
+	}
+	standardTagsAnnotations := map[string]string{
+		"ad.datadoghq.com/cluster.env":     "prod",
+		"ad.datadoghq.com/cluster.service": "billing",
+	}
+
+	tests := []struct {
+		name                                     string
+		collectNamespaceAnnotations              bool
+		clusterAgentEnabled                      bool
+		dcaClient                                clusteragent.DCAClientInterface
+		getNamespaceAnnotationsFromAPIServerFunc func(string) (map[string]string, error)
+		want                                     map[string]string
+		wantErr                                  bool
+	}{
+		{
+			name: "collection disabled",
+			want: nil,
+		},
+		{
+			name:                        "cluster agent not enabled",
+			collectNamespaceAnnotations: true,
+			dcaClient:                   &FakeDCAClient{},
+			getNamespaceAnnotationsFromAPIServerFunc: func(string) (map[string]string, error) {
+				return nsAnnotations, nil
+			},
+			want: standardTagsAnnotations,
+		},
+		{
+			name:                        "cluster agent not enabled and no standard tags annotations",
+			collectNamespaceAnnotations: true,
+			dcaClient:                   &FakeDCAClient{},
+			getNamespaceAnnotationsFromAPIServerFunc: func(string) (map[string]string, error) {
+				return map[string]string{"foo": "bar"}, nil
+			},
+			want: nil,
+		},
+		{
+			name:                        "cluster agent enabled",
+			collectNamespaceAnnotations: true,
+			clusterAgentEnabled:         true,
+			dcaClient: &FakeDCAClient{
+				LocalVersion:         version.Version{Major: 1, Minor: 12},
+				NamespaceAnnotations: standardTagsAnnotations,
+			},
+			want: standardTagsAnnotations,
+		},
+		{
+			name:                        "cluster agent enabled and failed to get namespace annotations",
+			collectNamespaceAnnotations: true,
+			clusterAgentEnabled:         true,
+			dcaClient: &FakeDCAClient{
+				LocalVersion:            version.Version{Major: 1, Minor: 12},
+				NamespaceAnnotationsErr: errors.New("failed to get namespace annotations"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &collector{
+				dcaClient:                   tt.dcaClient,
+				dcaEnabled:                  tt.clusterAgentEnabled,
+				collectNamespaceAnnotations: tt.collectNamespaceAnnotations,
+			}
+
+			annotations, err := c.getNamespaceAnnotations(tt.getNamespaceAnnotationsFromAPIServerFunc, "foo")
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, annotations)
+		})
+	}
+}
+
 func TestKubeMetadataCollector_parsePods(t *testing.T) {
 	pods := []*kubelet.Pod{{
 		Metadata: kubelet.PodMetadata{
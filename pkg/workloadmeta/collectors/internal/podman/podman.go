@@ -32,6 +32,8 @@ type podmanClient interface {
 }
 
 type collector struct {
+	workloadmeta.Heartbeat
+
 	client podmanClient
 	store  workloadmeta.Store
 	seen   map[workloadmeta.EntityID]struct{}
@@ -87,6 +89,7 @@ func (c *collector) Pull(_ context.Context) error {
 
 	c.seen = seen
 
+	c.Beat()
 	c.store.Notify(events)
 
 	return nil
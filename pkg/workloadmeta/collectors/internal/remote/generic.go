@@ -31,6 +31,10 @@ import (
 const (
 	noTimeout         = 0 * time.Minute
 	streamRecvTimeout = 10 * time.Minute
+
+	// the remote server sends keepalives, the collector is unhealthy if
+	// nothing is received for longer than streamRecvTimeout
+	heartbeatTimeout = streamRecvTimeout + time.Minute
 )
 
 var errWorkloadmetaStreamNotStarted = errors.New("workloadmeta stream not started")
@@ -63,6 +67,8 @@ type StreamHandler interface {
 
 // GenericCollector is a generic remote workloadmeta collector with resync mechanisms.
 type GenericCollector struct {
+	workloadmeta.Heartbeat
+
 	CollectorID   string
 	StreamHandler StreamHandler
 
@@ -88,6 +94,7 @@ func (c *GenericCollector) Start(ctx context.Context, store workloadmeta.Store)
 	}
 
 	c.store = store
+	c.Heartbeat.Timeout = heartbeatTimeout
 
 	c.ctx, c.cancel = context.WithCancel(ctx)
 
@@ -122,6 +129,7 @@ func (c *GenericCollector) Start(ctx context.Context, store workloadmeta.Store)
 	c.client = c.StreamHandler.NewClient(conn)
 
 	log.Info("remote workloadmeta initialized successfully")
+	c.Beat()
 	go c.Run()
 
 	return nil
@@ -213,6 +221,8 @@ func (c *GenericCollector) Run() {
 			continue
 		}
 
+		c.Beat()
+
 		collectorEvents, err := c.StreamHandler.HandleResponse(response)
 		if err != nil {
 			log.Warnf("error processing event received from remote workloadmeta: %s", err)
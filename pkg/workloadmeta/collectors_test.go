@@ -0,0 +1,67 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package workloadmeta
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testCollector struct {
+	Heartbeat
+}
+
+func (c *testCollector) Start(context.Context, Store) error {
+	return nil
+}
+
+func (c *testCollector) Pull(context.Context) error {
+	return nil
+}
+
+func TestHeartbeat(t *testing.T) {
+	var h Heartbeat
+	assert.False(t, h.Healthy(), "a collector that never did beat is unhealthy")
+
+	h.Beat()
+	assert.True(t, h.Healthy())
+
+	h.lastBeat.Store(time.Now().Add(-time.Hour).UnixNano())
+	assert.False(t, h.Healthy())
+
+	h.Timeout = 2 * time.Hour
+	assert.True(t, h.Healthy())
+}
+
+func TestCheckCollectorsHealth(t *testing.T) {
+	s := newStore(CollectorCatalog{})
+	t.Cleanup(s.deregisterCollectorsHealth)
+
+	healthy := &testCollector{}
+	healthy.Beat()
+	stale := &testCollector{}
+	s.collectors = map[string]Collector{
+		"healthy": healthy,
+		"stale":   stale,
+	}
+
+	// liveness handles start with a full buffer, they are only read for
+	// healthy collectors
+	s.checkCollectorsHealth()
+	assert.Len(t, s.collectorsHealth["healthy"].C, 1)
+	assert.Len(t, s.collectorsHealth["stale"].C, 2)
+
+	stale.Beat()
+	s.checkCollectorsHealth()
+	assert.Len(t, s.collectorsHealth["healthy"].C, 0)
+	assert.Len(t, s.collectorsHealth["stale"].C, 1)
+
+	s.deregisterCollectorsHealth()
+	assert.Empty(t, s.collectorsHealth)
+}
@@ -13,6 +13,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/DataDog/datadog-agent/pkg/config"
 	"github.com/DataDog/datadog-agent/pkg/errors"
 	"github.com/DataDog/datadog-agent/pkg/status/health"
 	"github.com/DataDog/datadog-agent/pkg/util/log"
@@ -51,14 +52,26 @@ type store struct {
 	subscribersMut sync.RWMutex
 	subscribers    []subscriber
 
+	// subscribersClosed is set once the channels of all subscribers have
+	// been closed on shutdown, so that no more events are sent to them.
+	subscribersClosed bool
+
 	collectorMut sync.RWMutex
 	candidates   map[string]Collector
 	collectors   map[string]Collector
 
 	eventCh chan []CollectorEvent
 
+	// coalescingWindow is the time during which events received for the
+	// same entity and source are coalesced into the most recent one. A
+	// zero value, the default, disables coalescing.
+	coalescingWindow time.Duration
+
 	ongoingPullsMut sync.Mutex
 	ongoingPulls    map[string]time.Time // collector ID => time when last pull started
+
+	// collector ID => liveness handle, only used by the puller goroutine
+	collectorsHealth map[string]*health.Handle
 }
 
 var _ Store = &store{}
@@ -77,11 +90,13 @@ func newStore(catalog CollectorCatalog) *store {
 	}
 
 	return &store{
-		store:        make(map[Kind]map[string]*cachedEntity),
-		candidates:   candidates,
-		collectors:   make(map[string]Collector),
-		eventCh:      make(chan []CollectorEvent, eventChBufferSize),
-		ongoingPulls: make(map[string]time.Time),
+		store:            make(map[Kind]map[string]*cachedEntity),
+		candidates:       candidates,
+		collectors:       make(map[string]Collector),
+		eventCh:          make(chan []CollectorEvent, eventChBufferSize),
+		coalescingWindow: config.Datadog.GetDuration("workloadmeta.event_coalescing_window"),
+		ongoingPulls:     make(map[string]time.Time),
+		collectorsHealth: make(map[string]*health.Handle),
 	}
 }
 
@@ -89,14 +104,43 @@ func newStore(catalog CollectorCatalog) *store {
 func (s *store) Start(ctx context.Context) {
 	go func() {
 		health := health.RegisterLiveness("workloadmeta-store")
+
+		// events received during the coalescing window, flushed when
+		// coalesceTimer fires
+		var pendingEvents []CollectorEvent
+		var coalesceTimer *time.Timer
+		var coalesceC <-chan time.Time
+
 		for {
 			select {
 			case <-health.C:
 
 			case evs := <-s.eventCh:
-				s.handleEvents(evs)
+				if s.coalescingWindow <= 0 {
+					s.handleEvents(evs)
+					break
+				}
+
+				pendingEvents = append(pendingEvents, evs...)
+				if coalesceC == nil {
+					coalesceTimer = time.NewTimer(s.coalescingWindow)
+					coalesceC = coalesceTimer.C
+				}
+
+			case <-coalesceC:
+				s.handleEvents(coalesceEvents(pendingEvents))
+				pendingEvents = nil
+				coalesceC = nil
 
 			case <-ctx.Done():
+				if coalesceTimer != nil {
+					coalesceTimer.Stop()
+				}
+
+				if len(pendingEvents) > 0 {
+					s.handleEvents(coalesceEvents(pendingEvents))
+				}
+
 				err := health.Deregister()
 				if err != nil {
 					log.Warnf("error de-registering health check: %s", err)
@@ -121,6 +165,7 @@ func (s *store) Start(ctx context.Context) {
 
 			case <-pullTicker.C:
 				s.pull(ctx)
+				s.checkCollectorsHealth()
 
 			case <-ctx.Done():
 				pullTicker.Stop()
@@ -130,6 +175,8 @@ func (s *store) Start(ctx context.Context) {
 					log.Warnf("error de-registering health check: %s", err)
 				}
 
+				s.deregisterCollectorsHealth()
+
 				s.unsubscribeAll()
 
 				log.Infof("stopped workloadmeta store")
@@ -228,6 +275,7 @@ func (s *store) Unsubscribe(ch chan EventBundle) {
 		if sub.ch == ch {
 			s.subscribers = append(s.subscribers[:i], s.subscribers[i+1:]...)
 			telemetry.Subscribers.Dec()
+			telemetry.SubscriberQueueDepth.Delete(sub.name)
 			close(ch)
 			return
 		}
@@ -369,6 +417,26 @@ func (s *store) GetKubernetesDeployment(id string) (*KubernetesDeployment, error
 	return entity.(*KubernetesDeployment), nil
 }
 
+// GetKubernetesConfigMap implements Store#GetKubernetesConfigMap
+func (s *store) GetKubernetesConfigMap(id string) (*KubernetesConfigMap, error) {
+	entity, err := s.getEntityByKind(KindKubernetesConfigMap, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.(*KubernetesConfigMap), nil
+}
+
+// GetKubernetesJob implements Store#GetKubernetesJob
+func (s *store) GetKubernetesJob(id string) (*KubernetesJob, error) {
+	entity, err := s.getEntityByKind(KindKubernetesJob, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.(*KubernetesJob), nil
+}
+
 // GetECSTask implements Store#GetECSTask
 func (s *store) GetECSTask(id string) (*ECSTask, error) {
 	entity, err := s.getEntityByKind(KindECSTask, id)
@@ -576,6 +644,85 @@ func (s *store) pull(ctx context.Context) {
 	}
 }
 
+// checkCollectorsHealth reports the health of each collector to the agent
+// health check. The liveness handle of a collector is only read while the
+// collector is healthy, so that a stale collector makes the health check fail.
+func (s *store) checkCollectorsHealth() {
+	s.collectorMut.RLock()
+	defer s.collectorMut.RUnlock()
+
+	for id, c := range s.collectors {
+		handle, ok := s.collectorsHealth[id]
+		if !ok {
+			handle = health.RegisterLiveness("workloadmeta-collector-" + id)
+			s.collectorsHealth[id] = handle
+		}
+
+		if !c.Healthy() {
+			log.Debugf("workloadmeta collector %q is unhealthy", id)
+			continue
+		}
+
+		select {
+		case <-handle.C:
+		default:
+		}
+	}
+}
+
+func (s *store) deregisterCollectorsHealth() {
+	for id, handle := range s.collectorsHealth {
+		if err := handle.Deregister(); err != nil {
+			log.Warnf("error de-registering health check of collector %q: %s", id, err)
+		}
+		delete(s.collectorsHealth, id)
+	}
+}
+
+// coalesceEvents keeps only the most recent event for each entity and source
+// in evs. Remaining events are returned in the order in which their most
+// recent occurrence was received. Set events with a version are never dropped,
+// as each version has to be applied in turn to pass the version check of the
+// store.
+func coalesceEvents(evs []CollectorEvent) []CollectorEvent {
+	type eventKey struct {
+		id     EntityID
+		source Source
+	}
+
+	isVersionedSet := func(ev CollectorEvent) bool {
+		versioned, ok := ev.Entity.(versionedEntity)
+		return ev.Type == EventTypeSet && ok && versioned.GetVersion() != 0
+	}
+
+	latest := make(map[eventKey]int, len(evs))
+	unversioned := 0
+	for i, ev := range evs {
+		if isVersionedSet(ev) {
+			continue
+		}
+
+		latest[eventKey{id: ev.Entity.GetID(), source: ev.Source}] = i
+		unversioned++
+	}
+
+	if len(latest) == unversioned {
+		return evs
+	}
+
+	coalesced := make([]CollectorEvent, 0, len(evs)-unversioned+len(latest))
+	for i, ev := range evs {
+		if !isVersionedSet(ev) && latest[eventKey{id: ev.Entity.GetID(), source: ev.Source}] != i {
+			telemetry.CoalescedEvents.Inc(string(ev.Entity.GetID().Kind), string(ev.Source))
+			continue
+		}
+
+		coalesced = append(coalesced, ev)
+	}
+
+	return coalesced
+}
+
 func (s *store) handleEvents(evs []CollectorEvent) {
 	s.storeMut.Lock()
 	s.subscribersMut.RLock()
@@ -585,7 +732,20 @@ func (s *store) handleEvents(evs []CollectorEvent) {
 		filteredEvents[sub] = make([]Event, 0, len(evs))
 	}
 
-	for _, ev := range evs {
+	// number of events and time spent processing them, by collector. The
+	// processing time of an event is accounted for when the processing of
+	// the next one starts.
+	eventsProcessed := make(map[Source]int)
+	processingTime := make(map[Source]time.Duration)
+	eventStart := time.Now()
+
+	for i, ev := range evs {
+		if now := time.Now(); i > 0 {
+			processingTime[evs[i-1].Source] += now.Sub(eventStart)
+			eventStart = now
+		}
+		eventsProcessed[ev.Source]++
+
 		entityID := ev.Entity.GetID()
 
 		telemetry.EventsReceived.Inc(string(entityID.Kind), string(ev.Source))
@@ -600,6 +760,16 @@ func (s *store) handleEvents(evs []CollectorEvent) {
 
 		switch ev.Type {
 		case EventTypeSet:
+			var currentVersion uint64
+			if ok {
+				currentVersion = cachedEntity.version
+			}
+
+			if err := checkEntityVersion(currentVersion, ev.Entity); err != nil {
+				log.Warnf("cannot set %s entity %q from source %s: %s", entityID.Kind, entityID.ID, ev.Source, err)
+				continue
+			}
+
 			if !ok {
 				entitiesOfKind[entityID.ID] = newCachedEntity()
 				cachedEntity = entitiesOfKind[entityID.ID]
@@ -692,6 +862,10 @@ func (s *store) handleEvents(evs []CollectorEvent) {
 		}
 	}
 
+	if len(evs) > 0 {
+		processingTime[evs[len(evs)-1].Source] += time.Since(eventStart)
+	}
+
 	s.subscribersMut.RUnlock()
 
 	// unlock the store before notifying subscribers, as they might need to
@@ -699,6 +873,11 @@ func (s *store) handleEvents(evs []CollectorEvent) {
 	// process an event.
 	s.storeMut.Unlock()
 
+	for source, count := range eventsProcessed {
+		telemetry.CollectorEventsProcessed.Add(float64(count), string(source))
+		telemetry.CollectorLatency.Observe(float64(processingTime[source])/float64(time.Millisecond), string(source))
+	}
+
 	for sub, evs := range filteredEvents {
 		if len(evs) == 0 {
 			continue
@@ -747,10 +926,12 @@ func (s *store) unsubscribeAll() {
 	defer s.subscribersMut.Unlock()
 
 	for _, sub := range s.subscribers {
+		telemetry.SubscriberQueueDepth.Delete(sub.name)
 		close(sub.ch)
 	}
 
 	s.subscribers = nil
+	s.subscribersClosed = true
 
 	telemetry.Subscribers.Set(0)
 }
@@ -760,10 +941,22 @@ func (s *store) notifyChannel(name string, ch chan EventBundle, events []Event,
 		Ch:     make(chan struct{}),
 		Events: events,
 	}
+	start := time.Now()
+
 	s.subscribersMut.Lock()
+	if s.subscribersClosed {
+		// the store is shutting down and ch has been closed
+		s.subscribersMut.Unlock()
+		return
+	}
 	ch <- bundle
+	telemetry.SubscriberQueueDepth.Set(float64(len(ch)), name)
 	s.subscribersMut.Unlock()
 
+	defer func() {
+		telemetry.EventDispatchLatency.Observe(float64(time.Since(start))/float64(time.Millisecond), name)
+	}()
+
 	if wait {
 		timer := time.NewTimer(eventBundleChTimeout)
 
@@ -6,13 +6,16 @@
 package workloadmeta
 
 import (
+	"context"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/DataDog/datadog-agent/pkg/errors"
 	"github.com/DataDog/datadog-agent/pkg/languagedetection/languagemodels"
+	"github.com/DataDog/datadog-agent/pkg/workloadmeta/telemetry"
 )
 
 const (
@@ -62,6 +65,137 @@ func TestHandleEvents(t *testing.T) {
 	}
 }
 
+func TestHandleEventsVersionConflict(t *testing.T) {
+	s := newTestStore()
+
+	newPod := func(version uint64, phase string) *KubernetesPod {
+		return &KubernetesPod{
+			EntityID: EntityID{
+				Kind: KindKubernetesPod,
+				ID:   "pod-uid",
+			},
+			EntityMeta: EntityMeta{
+				Name:    "pod",
+				Version: version,
+			},
+			Phase: phase,
+		}
+	}
+
+	setPod := func(source Source, pod *KubernetesPod) {
+		s.handleEvents([]CollectorEvent{
+			{
+				Type:   EventTypeSet,
+				Source: source,
+				Entity: pod,
+			},
+		})
+	}
+
+	// an entity can't be created with a version other than 1
+	setPod(fooSource, newPod(2, "Pending"))
+	_, err := s.GetKubernetesPod("pod-uid")
+	assert.True(t, errors.IsNotFound(err))
+
+	setPod(fooSource, newPod(1, "Pending"))
+	pod, err := s.GetKubernetesPod("pod-uid")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(1), pod.Version)
+
+	// both sources read version 1 and update the pod concurrently: the
+	// second update is rejected
+	setPod(fooSource, newPod(2, "Running"))
+	setPod(barSource, newPod(2, "Failed"))
+
+	pod, err = s.GetKubernetesPod("pod-uid")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), pod.Version)
+	assert.Equal(t, "Running", pod.Phase)
+
+	// the rejected source retries from the current version
+	setPod(barSource, newPod(3, "Failed"))
+
+	pod, err = s.GetKubernetesPod("pod-uid")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(3), pod.Version)
+
+	// a zero version forces the update
+	setPod(fooSource, newPod(0, "Succeeded"))
+
+	pod, err = s.GetKubernetesPod("pod-uid")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), pod.Version)
+	assert.Equal(t, "Failed", pod.Phase, "the bar source has precedence when merging")
+	assert.Equal(t, "Succeeded", s.store[KindKubernetesPod]["pod-uid"].get(fooSource).(*KubernetesPod).Phase)
+}
+
+func TestHandleEventsOutOfOrderVersions(t *testing.T) {
+	s := newTestStore()
+
+	newPod := func(version uint64, phase string) *KubernetesPod {
+		return &KubernetesPod{
+			EntityID: EntityID{
+				Kind: KindKubernetesPod,
+				ID:   "pod-uid",
+			},
+			EntityMeta: EntityMeta{
+				Name:    "pod",
+				Version: version,
+			},
+			Phase: phase,
+		}
+	}
+
+	// the updates of a collector are delivered out of order in a single
+	// batch: version 3 arrives before version 2 and is rejected
+	s.handleEvents([]CollectorEvent{
+		{Type: EventTypeSet, Source: fooSource, Entity: newPod(1, "Pending")},
+		{Type: EventTypeSet, Source: fooSource, Entity: newPod(3, "Succeeded")},
+		{Type: EventTypeSet, Source: fooSource, Entity: newPod(2, "Running")},
+	})
+
+	pod, err := s.GetKubernetesPod("pod-uid")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), pod.Version)
+	assert.Equal(t, "Running", pod.Phase)
+
+	// a stale update delivered late doesn't overwrite the current entity
+	s.handleEvents([]CollectorEvent{
+		{Type: EventTypeSet, Source: fooSource, Entity: newPod(1, "Pending")},
+	})
+
+	pod, err = s.GetKubernetesPod("pod-uid")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(2), pod.Version)
+	assert.Equal(t, "Running", pod.Phase)
+}
+
+func TestHandleEventsCollectorTelemetry(t *testing.T) {
+	s := newTestStore()
+
+	newContainer := func(id string) *Container {
+		return &Container{
+			EntityID: EntityID{
+				Kind: KindContainer,
+				ID:   id,
+			},
+		}
+	}
+
+	fooProcessed := telemetry.CollectorEventsProcessed.WithValues(fooSource).Get()
+	barProcessed := telemetry.CollectorEventsProcessed.WithValues(barSource).Get()
+
+	s.handleEvents([]CollectorEvent{
+		{Type: EventTypeSet, Source: fooSource, Entity: newContainer("foo1")},
+		{Type: EventTypeSet, Source: barSource, Entity: newContainer("bar1")},
+		{Type: EventTypeSet, Source: fooSource, Entity: newContainer("foo2")},
+		{Type: EventTypeUnset, Source: fooSource, Entity: newContainer("foo1")},
+	})
+
+	assert.Equal(t, fooProcessed+3, telemetry.CollectorEventsProcessed.WithValues(fooSource).Get())
+	assert.Equal(t, barProcessed+1, telemetry.CollectorEventsProcessed.WithValues(barSource).Get())
+}
+
 func TestSubscribe(t *testing.T) {
 	fooContainer := &Container{
 		EntityID: EntityID{
@@ -1270,6 +1404,183 @@ func TestNoDataRace(t *testing.T) {
 	})
 }
 
+func TestCoalesceEvents(t *testing.T) {
+	fooContainer := &Container{
+		EntityID: EntityID{
+			Kind: KindContainer,
+			ID:   "foo",
+		},
+	}
+
+	updatedFooContainer := &Container{
+		EntityID: fooContainer.EntityID,
+		EntityMeta: EntityMeta{
+			Name: "foo-updated",
+		},
+	}
+
+	barContainer := &Container{
+		EntityID: EntityID{
+			Kind: KindContainer,
+			ID:   "bar",
+		},
+	}
+
+	newVersionedContainer := func(version uint64) *Container {
+		return &Container{
+			EntityID: fooContainer.EntityID,
+			EntityMeta: EntityMeta{
+				Version: version,
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		events   []CollectorEvent
+		expected []CollectorEvent
+	}{
+		{
+			name: "no events for the same entity",
+			events: []CollectorEvent{
+				{Type: EventTypeSet, Source: fooSource, Entity: fooContainer},
+				{Type: EventTypeSet, Source: fooSource, Entity: barContainer},
+			},
+			expected: []CollectorEvent{
+				{Type: EventTypeSet, Source: fooSource, Entity: fooContainer},
+				{Type: EventTypeSet, Source: fooSource, Entity: barContainer},
+			},
+		},
+		{
+			name: "most recent event for the same entity is kept",
+			events: []CollectorEvent{
+				{Type: EventTypeSet, Source: fooSource, Entity: fooContainer},
+				{Type: EventTypeSet, Source: fooSource, Entity: barContainer},
+				{Type: EventTypeSet, Source: fooSource, Entity: updatedFooContainer},
+			},
+			expected: []CollectorEvent{
+				{Type: EventTypeSet, Source: fooSource, Entity: barContainer},
+				{Type: EventTypeSet, Source: fooSource, Entity: updatedFooContainer},
+			},
+		},
+		{
+			name: "unset after set is kept",
+			events: []CollectorEvent{
+				{Type: EventTypeSet, Source: fooSource, Entity: fooContainer},
+				{Type: EventTypeUnset, Source: fooSource, Entity: fooContainer},
+			},
+			expected: []CollectorEvent{
+				{Type: EventTypeUnset, Source: fooSource, Entity: fooContainer},
+			},
+		},
+		{
+			name: "versioned set events are kept",
+			events: []CollectorEvent{
+				{Type: EventTypeSet, Source: fooSource, Entity: newVersionedContainer(1)},
+				{Type: EventTypeSet, Source: fooSource, Entity: newVersionedContainer(2)},
+			},
+			expected: []CollectorEvent{
+				{Type: EventTypeSet, Source: fooSource, Entity: newVersionedContainer(1)},
+				{Type: EventTypeSet, Source: fooSource, Entity: newVersionedContainer(2)},
+			},
+		},
+		{
+			name: "unversioned events are coalesced around versioned set events",
+			events: []CollectorEvent{
+				{Type: EventTypeSet, Source: fooSource, Entity: fooContainer},
+				{Type: EventTypeSet, Source: fooSource, Entity: newVersionedContainer(1)},
+				{Type: EventTypeSet, Source: fooSource, Entity: updatedFooContainer},
+			},
+			expected: []CollectorEvent{
+				{Type: EventTypeSet, Source: fooSource, Entity: newVersionedContainer(1)},
+				{Type: EventTypeSet, Source: fooSource, Entity: updatedFooContainer},
+			},
+		},
+		{
+			name: "events from different sources are not coalesced",
+			events: []CollectorEvent{
+				{Type: EventTypeSet, Source: fooSource, Entity: fooContainer},
+				{Type: EventTypeSet, Source: barSource, Entity: updatedFooContainer},
+			},
+			expected: []CollectorEvent{
+				{Type: EventTypeSet, Source: fooSource, Entity: fooContainer},
+				{Type: EventTypeSet, Source: barSource, Entity: updatedFooContainer},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, coalesceEvents(tt.events))
+		})
+	}
+}
+
+func TestCoalescingVersionedEvents(t *testing.T) {
+	s := newTestStore()
+	s.coalescingWindow = 50 * time.Millisecond
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.Start(ctx)
+
+	newPod := func(version uint64) *KubernetesPod {
+		return &KubernetesPod{
+			EntityID: EntityID{
+				Kind: KindKubernetesPod,
+				ID:   "pod-uid",
+			},
+			EntityMeta: EntityMeta{
+				Name:    "pod",
+				Version: version,
+			},
+		}
+	}
+
+	// both versions are received within the same window, and both have to
+	// be applied for the second one to pass the version check
+	s.Notify([]CollectorEvent{{Type: EventTypeSet, Source: fooSource, Entity: newPod(1)}})
+	s.Notify([]CollectorEvent{{Type: EventTypeSet, Source: fooSource, Entity: newPod(2)}})
+
+	assert.Eventually(t, func() bool {
+		pod, err := s.GetKubernetesPod("pod-uid")
+		return err == nil && pod.Version == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestCoalescingFlushOnShutdown(t *testing.T) {
+	s := newTestStore()
+	s.coalescingWindow = time.Hour
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.Start(ctx)
+
+	container := &Container{
+		EntityID: EntityID{
+			Kind: KindContainer,
+			ID:   "deadbeef",
+		},
+	}
+
+	s.Notify([]CollectorEvent{{Type: EventTypeSet, Source: fooSource, Entity: container}})
+
+	// wait for the event to be pending in the store loop before stopping
+	// the store
+	assert.Eventually(t, func() bool {
+		return len(s.eventCh) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+
+	assert.Eventually(t, func() bool {
+		_, err := s.GetContainer(container.ID)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
 func newTestStore() *store {
 	return &store{
 		store:   make(map[Kind]map[string]*cachedEntity),
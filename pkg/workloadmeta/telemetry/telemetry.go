@@ -8,7 +8,14 @@ package telemetry
 
 import "github.com/DataDog/datadog-agent/pkg/telemetry"
 
-const subsystem = "workloadmeta"
+const (
+	subsystem = "workloadmeta"
+
+	// collectorSubsystem is the subsystem of the per-collector metrics. The
+	// double underscore is replaced with a dot, for the metrics to be named
+	// workloadmeta.collector.<name>.
+	collectorSubsystem = subsystem + "__collector"
+)
 
 var (
 	// StatusSuccess is the value for the "status" tag that represents a successful operation
@@ -47,6 +54,17 @@ var (
 		commonOpts,
 	)
 
+	// CoalescedEvents tracks the number of events dropped by the
+	// workloadmeta store because a more recent event for the same entity and
+	// source was received within the coalescing window.
+	CoalescedEvents = telemetry.NewCounterWithOpts(
+		subsystem,
+		"coalesced_events",
+		[]string{"kind", "source"},
+		"Number of events coalesced into a more recent event by the workloadmeta store.",
+		commonOpts,
+	)
+
 	// PullErrors tracks the number of errors that the workloadmeta received
 	// when pulling from the collectors.
 	PullErrors = telemetry.NewCounterWithOpts(
@@ -79,6 +97,47 @@ var (
 		commonOpts,
 	)
 
+	// EventDispatchLatency measures the time it takes to dispatch a bundle of
+	// events to a subscriber, from the moment it's sent until the subscriber
+	// acknowledges it.
+	EventDispatchLatency = telemetry.NewHistogramWithOpts(
+		subsystem,
+		"event_dispatch_latency_ms",
+		[]string{"subscriber_name"},
+		"The time it takes to dispatch a bundle of events to a subscriber (in milliseconds)",
+		[]float64{1, 5, 10, 25, 50, 100, 250, 500, 1000},
+		commonOpts,
+	)
+
+	// SubscriberQueueDepth tracks the number of bundles of events waiting to
+	// be received by each subscriber.
+	SubscriberQueueDepth = telemetry.NewGaugeWithOpts(
+		subsystem,
+		"subscriber_queue_depth",
+		[]string{"subscriber_name"},
+		"Number of bundles of events waiting to be received by a subscriber.",
+		commonOpts,
+	)
+
+	// CollectorEventsProcessed tracks the number of events processed by the
+	// workloadmeta store, by the collector that sent them.
+	CollectorEventsProcessed = telemetry.NewCounter(
+		collectorSubsystem,
+		"events_processed",
+		[]string{"collector_name"},
+		"Number of events processed by the workloadmeta store, by collector.",
+	)
+
+	// CollectorLatency measures the time it takes the workloadmeta store to
+	// process the events of a batch sent by a collector.
+	CollectorLatency = telemetry.NewHistogram(
+		collectorSubsystem,
+		"latency_ms",
+		[]string{"collector_name"},
+		"The time it takes the workloadmeta store to process a batch of events, by collector (in milliseconds)",
+		[]float64{1, 5, 10, 25, 50, 100, 250, 500, 1000},
+	)
+
 	// RemoteClientErrors tracks the number of errors on the remote workloadmeta
 	// client while receiving events.
 	RemoteClientErrors = telemetry.NewCounterWithOpts(
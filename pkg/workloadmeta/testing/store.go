@@ -165,6 +165,26 @@ func (s *Store) GetKubernetesDeployment(id string) (*workloadmeta.KubernetesDepl
 	return entity.(*workloadmeta.KubernetesDeployment), nil
 }
 
+// GetKubernetesConfigMap implements Store#GetKubernetesConfigMap
+func (s *Store) GetKubernetesConfigMap(id string) (*workloadmeta.KubernetesConfigMap, error) {
+	entity, err := s.getEntityByKind(workloadmeta.KindKubernetesConfigMap, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.(*workloadmeta.KubernetesConfigMap), nil
+}
+
+// GetKubernetesJob implements Store#GetKubernetesJob
+func (s *Store) GetKubernetesJob(id string) (*workloadmeta.KubernetesJob, error) {
+	entity, err := s.getEntityByKind(workloadmeta.KindKubernetesJob, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.(*workloadmeta.KubernetesJob), nil
+}
+
 // GetECSTask returns metadata about an ECS task.
 func (s *Store) GetECSTask(id string) (*workloadmeta.ECSTask, error) {
 	entity, err := s.getEntityByKind(workloadmeta.KindECSTask, id)
@@ -84,6 +84,15 @@ type Store interface {
 	// the entity with kind KindKubernetesDeployment and the given ID.
 	GetKubernetesDeployment(id string) (*KubernetesDeployment, error)
 
+	// GetKubernetesConfigMap returns metadata about a Kubernetes ConfigMap.
+	// It fetches the entity with kind KindKubernetesConfigMap and the given
+	// ID, in the "<namespace>/<name>" format.
+	GetKubernetesConfigMap(id string) (*KubernetesConfigMap, error)
+
+	// GetKubernetesJob returns metadata about a Kubernetes Job. It fetches
+	// the entity with kind KindKubernetesJob and the given ID.
+	GetKubernetesJob(id string) (*KubernetesJob, error)
+
 	// GetECSTask returns metadata about an ECS task.  It fetches the entity with
 	// kind KindECSTask and the given ID.
 	GetECSTask(id string) (*ECSTask, error)
@@ -138,6 +147,8 @@ const (
 	KindKubernetesPod          Kind = "kubernetes_pod"
 	KindKubernetesNode         Kind = "kubernetes_node"
 	KindKubernetesDeployment   Kind = "kubernetes_deployment"
+	KindKubernetesConfigMap    Kind = "kubernetes_configmap"
+	KindKubernetesJob          Kind = "kubernetes_job"
 	KindECSTask                Kind = "ecs_task"
 	KindContainerImageMetadata Kind = "container_image_metadata"
 	KindProcess                Kind = "process"
@@ -298,6 +309,27 @@ type EntityMeta struct {
 	Namespace   string
 	Annotations map[string]string
 	Labels      map[string]string
+
+	// Version is used for optimistic concurrency: when not zero, the store
+	// only accepts to set the entity if Version is the current version of
+	// the entity plus one. A zero Version always sets the entity.
+	//
+	// The store never assigns versions itself. A collector that needs to
+	// detect concurrent or out-of-order updates reads the entity from the
+	// store and sets Version to its current version plus one before sending
+	// the update. Collectors that don't set it, which is the case of all the
+	// built-in collectors, always set the entity.
+	Version uint64
+}
+
+// GetVersion returns the version of the entity.
+func (e EntityMeta) GetVersion() uint64 {
+	return e.Version
+}
+
+// SetVersion sets the version of the entity.
+func (e *EntityMeta) SetVersion(version uint64) {
+	e.Version = version
 }
 
 // String returns a string representation of EntityMeta.
@@ -417,9 +449,23 @@ func (c ContainerPort) String(verbose bool) string {
 // OrchestratorContainer is a reference to a Container with
 // orchestrator-specific data attached to it.
 type OrchestratorContainer struct {
-	ID    string
-	Name  string
-	Image ContainerImage
+	ID                string
+	Name              string
+	Image             ContainerImage
+	ImagePullPolicy   string
+	LivenessProbeType string
+	Resources         OrchestratorContainerResources
+	IsInit            bool
+	LastExitCode      *int32
+}
+
+// OrchestratorContainerResources holds the resource requests and limits of a
+// container, as quantities set in the orchestrator spec.
+type OrchestratorContainerResources struct {
+	CPURequest    string
+	CPULimit      string
+	MemoryRequest string
+	MemoryLimit   string
 }
 
 // String returns a string representation of OrchestratorContainer.
@@ -575,13 +621,25 @@ type KubernetesPod struct {
 	PersistentVolumeClaimNames []string
 	InitContainers             []OrchestratorContainer
 	Containers                 []OrchestratorContainer
+	EphemeralContainers        []OrchestratorContainer
 	Ready                      bool
+	Conditions                 map[string]bool
 	Phase                      string
 	IP                         string
+	NodeName                   string
+	NominatedNodeName          string
+	TopologySpreadKeys         []string
+	TolerationKeys             []string
 	PriorityClass              string
+	RuntimeClass               string
 	QOSClass                   string
 	KubeServices               []string
 	NamespaceLabels            map[string]string
+	NamespaceAnnotations       map[string]string
+	ConfigMapNames             []string
+	SecretNames                []string
+	ScheduledAt                time.Time
+	StartTime                  *time.Time
 	FinishedAt                 time.Time
 	SecurityContext            *PodSecurityContext
 }
@@ -637,17 +695,41 @@ func (p KubernetesPod) String(verbose bool) string {
 		}
 	}
 
+	if len(p.EphemeralContainers) > 0 {
+		_, _ = fmt.Fprintln(&sb, "----------- Ephemeral Containers -----------")
+		for _, c := range p.EphemeralContainers {
+			_, _ = fmt.Fprint(&sb, c.String(verbose))
+		}
+	}
+
 	_, _ = fmt.Fprintln(&sb, "----------- Pod Info -----------")
 	_, _ = fmt.Fprintln(&sb, "Ready:", p.Ready)
 	_, _ = fmt.Fprintln(&sb, "Phase:", p.Phase)
 	_, _ = fmt.Fprintln(&sb, "IP:", p.IP)
+	_, _ = fmt.Fprintln(&sb, "Node Name:", p.NodeName)
+	if p.NominatedNodeName != "" {
+		_, _ = fmt.Fprintln(&sb, "Nominated Node Name:", p.NominatedNodeName)
+	}
 
 	if verbose {
 		_, _ = fmt.Fprintln(&sb, "Priority Class:", p.PriorityClass)
+		_, _ = fmt.Fprintln(&sb, "Runtime Class:", p.RuntimeClass)
 		_, _ = fmt.Fprintln(&sb, "QOS Class:", p.QOSClass)
+		_, _ = fmt.Fprintln(&sb, "Conditions:", p.Conditions)
 		_, _ = fmt.Fprintln(&sb, "PVCs:", sliceToString(p.PersistentVolumeClaimNames))
 		_, _ = fmt.Fprintln(&sb, "Kube Services:", sliceToString(p.KubeServices))
 		_, _ = fmt.Fprintln(&sb, "Namespace Labels:", mapToString(p.NamespaceLabels))
+		_, _ = fmt.Fprintln(&sb, "Namespace Annotations:", mapToString(p.NamespaceAnnotations))
+		_, _ = fmt.Fprintln(&sb, "ConfigMaps:", sliceToString(p.ConfigMapNames))
+		_, _ = fmt.Fprintln(&sb, "Secrets:", sliceToString(p.SecretNames))
+		_, _ = fmt.Fprintln(&sb, "Topology Spread Keys:", sliceToString(p.TopologySpreadKeys))
+		_, _ = fmt.Fprintln(&sb, "Toleration Keys:", sliceToString(p.TolerationKeys))
+		if !p.ScheduledAt.IsZero() {
+			_, _ = fmt.Fprintln(&sb, "Scheduled At:", p.ScheduledAt)
+		}
+		if p.StartTime != nil {
+			_, _ = fmt.Fprintln(&sb, "Start Time:", *p.StartTime)
+		}
 		if !p.FinishedAt.IsZero() {
 			_, _ = fmt.Fprintln(&sb, "Finished At:", p.FinishedAt)
 		}
@@ -663,7 +745,8 @@ func (p KubernetesPod) String(verbose bool) string {
 	return sb.String()
 }
 
-// GetAllContainers returns init containers and containers.
+// GetAllContainers returns init containers and containers. Ephemeral
+// containers, used for debugging, are not included.
 func (p KubernetesPod) GetAllContainers() []OrchestratorContainer {
 	return append(p.InitContainers, p.Containers...)
 }
@@ -693,6 +776,7 @@ func (o KubernetesPodOwner) String(verbose bool) string {
 type KubernetesNode struct {
 	EntityID
 	EntityMeta
+	Conditions map[string]bool
 }
 
 // GetID implements Entity#GetID.
@@ -725,6 +809,11 @@ func (n KubernetesNode) String(verbose bool) string {
 	_, _ = fmt.Fprintln(&sb, "----------- Entity Meta -----------")
 	_, _ = fmt.Fprint(&sb, n.EntityMeta.String(verbose))
 
+	if verbose {
+		_, _ = fmt.Fprintln(&sb, "----------- Node Info -----------")
+		_, _ = fmt.Fprintln(&sb, "Conditions:", n.Conditions)
+	}
+
 	return sb.String()
 }
 
@@ -791,6 +880,99 @@ func (d KubernetesDeployment) String(verbose bool) string {
 
 var _ Entity = &KubernetesDeployment{}
 
+// KubernetesConfigMap is an Entity representing a Kubernetes ConfigMap. Only
+// its metadata is stored, never its data.
+type KubernetesConfigMap struct {
+	EntityID
+	EntityMeta
+}
+
+// GetID implements Entity#GetID.
+func (c *KubernetesConfigMap) GetID() EntityID {
+	return c.EntityID
+}
+
+// Merge implements Entity#Merge.
+func (c *KubernetesConfigMap) Merge(e Entity) error {
+	cc, ok := e.(*KubernetesConfigMap)
+	if !ok {
+		return fmt.Errorf("cannot merge KubernetesConfigMap with different kind %T", e)
+	}
+
+	return merge(c, cc)
+}
+
+// DeepCopy implements Entity#DeepCopy.
+func (c KubernetesConfigMap) DeepCopy() Entity {
+	cc := deepcopy.Copy(c).(KubernetesConfigMap)
+	return &cc
+}
+
+// String implements Entity#String
+func (c KubernetesConfigMap) String(verbose bool) string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintln(&sb, "----------- Entity ID -----------")
+	_, _ = fmt.Fprintln(&sb, c.EntityID.String(verbose))
+
+	_, _ = fmt.Fprintln(&sb, "----------- Entity Meta -----------")
+	_, _ = fmt.Fprint(&sb, c.EntityMeta.String(verbose))
+
+	return sb.String()
+}
+
+var _ Entity = &KubernetesConfigMap{}
+
+// KubernetesJob is an Entity representing a Kubernetes Job. Only its metadata
+// and owners are stored, so that pods can be linked to the CronJob that
+// spawned them.
+type KubernetesJob struct {
+	EntityID
+	EntityMeta
+	Owners []KubernetesPodOwner
+}
+
+// GetID implements Entity#GetID.
+func (j *KubernetesJob) GetID() EntityID {
+	return j.EntityID
+}
+
+// Merge implements Entity#Merge.
+func (j *KubernetesJob) Merge(e Entity) error {
+	jj, ok := e.(*KubernetesJob)
+	if !ok {
+		return fmt.Errorf("cannot merge KubernetesJob with different kind %T", e)
+	}
+
+	return merge(j, jj)
+}
+
+// DeepCopy implements Entity#DeepCopy.
+func (j KubernetesJob) DeepCopy() Entity {
+	jj := deepcopy.Copy(j).(KubernetesJob)
+	return &jj
+}
+
+// String implements Entity#String
+func (j KubernetesJob) String(verbose bool) string {
+	var sb strings.Builder
+	_, _ = fmt.Fprintln(&sb, "----------- Entity ID -----------")
+	_, _ = fmt.Fprintln(&sb, j.EntityID.String(verbose))
+
+	_, _ = fmt.Fprintln(&sb, "----------- Entity Meta -----------")
+	_, _ = fmt.Fprint(&sb, j.EntityMeta.String(verbose))
+
+	if len(j.Owners) > 0 {
+		_, _ = fmt.Fprintln(&sb, "----------- Owners -----------")
+		for _, o := range j.Owners {
+			_, _ = fmt.Fprint(&sb, o.String(verbose))
+		}
+	}
+
+	return sb.String()
+}
+
+var _ Entity = &KubernetesJob{}
+
 // ECSTask is an Entity representing an ECS Task.
 type ECSTask struct {
 	EntityID
@@ -803,6 +985,10 @@ type ECSTask struct {
 	Family                string
 	Version               string
 	LaunchType            ECSLaunchType
+	StartedAt             *time.Time
+	KnownStatus           string
+	StopCode              string
+	StoppedReason         string
 	Containers            []OrchestratorContainer
 }
 
@@ -851,6 +1037,14 @@ func (t ECSTask) String(verbose bool) string {
 		_, _ = fmt.Fprintln(&sb, "Family:", t.Family)
 		_, _ = fmt.Fprintln(&sb, "Version:", t.Version)
 		_, _ = fmt.Fprintln(&sb, "Launch Type:", t.LaunchType)
+		_, _ = fmt.Fprintln(&sb, "Known Status:", t.KnownStatus)
+		if t.StartedAt != nil {
+			_, _ = fmt.Fprintln(&sb, "Started At:", *t.StartedAt)
+		}
+		if t.StopCode != "" {
+			_, _ = fmt.Fprintln(&sb, "Stop Code:", t.StopCode)
+			_, _ = fmt.Fprintln(&sb, "Stopped Reason:", t.StoppedReason)
+		}
 	}
 
 	return sb.String()
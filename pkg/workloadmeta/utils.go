@@ -16,6 +16,8 @@ var kubeKindToWorkloadmetaKindMap = map[string]Kind{
 	"Pod":        KindKubernetesPod,
 	"Deployment": KindKubernetesDeployment,
 	"Node":       KindKubernetesNode,
+	"ConfigMap":  KindKubernetesConfigMap,
+	"Job":        KindKubernetesJob,
 }
 
 // KubernetesKindToWorkloadMetaKind maps a Kubernetes Kind to a workloadmeta Kind.
@@ -12,6 +12,14 @@ import (
 	"github.com/DataDog/datadog-agent/test/fakeintake/api"
 )
 
+// Statuses of a check run, as sent by the agent
+const (
+	CheckRunStatusOK       = 0
+	CheckRunStatusWarning  = 1
+	CheckRunStatusCritical = 2
+	CheckRunStatusUnknown  = 3
+)
+
 type CheckRun struct {
 	collectedTime time.Time
 	Check         string   `json:"check"`
@@ -62,3 +70,21 @@ func NewCheckRunAggregator() CheckRunAggregator {
 		Aggregator: newAggregator(ParseCheckRunPayload),
 	}
 }
+
+// GetCheckRunsByName return the check runs of the check checkName
+func (agg *CheckRunAggregator) GetCheckRunsByName(checkName string) []*CheckRun {
+	return append([]*CheckRun{}, agg.GetPayloadsByName(checkName)...)
+}
+
+// GetCheckRunsByStatus return the check runs with the given status, ordered by check name
+func (agg *CheckRunAggregator) GetCheckRunsByStatus(status int) []*CheckRun {
+	checkRuns := []*CheckRun{}
+	for _, name := range agg.GetNames() {
+		for _, checkRun := range agg.GetPayloadsByName(name) {
+			if checkRun.Status == status {
+				checkRuns = append(checkRuns, checkRun)
+			}
+		}
+	}
+	return checkRuns
+}
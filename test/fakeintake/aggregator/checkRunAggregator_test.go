@@ -12,6 +12,7 @@ import (
 
 	"github.com/DataDog/datadog-agent/test/fakeintake/api"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 //go:embed fixtures/checkrun_bytes
@@ -36,3 +37,45 @@ func TestCheckRun(t *testing.T) {
 		assert.Equal(t, expectedTags, gotTags)
 	})
 }
+
+func TestCheckRunAggregator(t *testing.T) {
+	agg := NewCheckRunAggregator()
+	err := agg.UnmarshallPayloads([]api.Payload{
+		{Data: []byte(`[
+			{"check": "ntp.in_sync", "host_name": "host-1", "status": 0},
+			{"check": "datadog.agent.up", "host_name": "host-1", "status": 1},
+			{"check": "ntp.in_sync", "host_name": "host-2", "status": 2}
+		]`)},
+		{Data: []byte(`[
+			{"check": "datadog.agent.up", "host_name": "host-2", "status": 3},
+			{"check": "ntp.in_sync", "host_name": "host-3", "status": 0}
+		]`)},
+	})
+	require.NoError(t, err)
+
+	checkRunIDs := func(checkRuns []*CheckRun) []string {
+		names := []string{}
+		for _, checkRun := range checkRuns {
+			names = append(names, checkRun.name()+"@"+checkRun.HostName)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"ntp.in_sync@host-1", "ntp.in_sync@host-2", "ntp.in_sync@host-3"}, checkRunIDs(agg.GetCheckRunsByName("ntp.in_sync")))
+	assert.Empty(t, agg.GetCheckRunsByName("unknown.check"))
+
+	for _, tc := range []struct {
+		name     string
+		status   int
+		expected []string
+	}{
+		{"ok", CheckRunStatusOK, []string{"ntp.in_sync@host-1", "ntp.in_sync@host-3"}},
+		{"warning", CheckRunStatusWarning, []string{"datadog.agent.up@host-1"}},
+		{"critical", CheckRunStatusCritical, []string{"ntp.in_sync@host-2"}},
+		{"unknown", CheckRunStatusUnknown, []string{"datadog.agent.up@host-2"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, checkRunIDs(agg.GetCheckRunsByStatus(tc.status)))
+		})
+	}
+}
@@ -54,7 +54,11 @@ func decodeCollectorConnection(b []byte) (cnx *agentmodel.CollectorConnections,
 
 // ParseConnections return the Connections from payload
 func ParseConnections(payload api.Payload) (conns []*Connections, err error) {
-	connections, err := decodeCollectorConnection(payload.Data)
+	enflated, err := enflate(payload.Data, payload.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	connections, err := decodeCollectorConnection(enflated)
 	if err != nil {
 		return nil, err
 	}
@@ -75,3 +79,25 @@ func NewConnectionsAggregator() ConnectionsAggregator {
 		Aggregator: newAggregator(ParseConnections),
 	}
 }
+
+// GetNetworkConnections return the connections of all payloads, ordered by hostname and network ID
+func (agg *ConnectionsAggregator) GetNetworkConnections() []*agentmodel.Connection {
+	connections := []*agentmodel.Connection{}
+	for _, name := range agg.GetNames() {
+		for _, payload := range agg.GetPayloadsByName(name) {
+			connections = append(connections, payload.Connections...)
+		}
+	}
+	return connections
+}
+
+// GetConnectionsBySource return the connections whose local address is srcIP
+func (agg *ConnectionsAggregator) GetConnectionsBySource(srcIP string) []*agentmodel.Connection {
+	connections := []*agentmodel.Connection{}
+	for _, connection := range agg.GetNetworkConnections() {
+		if connection.Laddr != nil && connection.Laddr.Ip == srcIP {
+			connections = append(connections, connection)
+		}
+	}
+	return connections
+}
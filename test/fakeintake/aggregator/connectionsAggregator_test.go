@@ -6,14 +6,18 @@
 package aggregator
 
 import (
+	"bytes"
+	"compress/gzip"
 	_ "embed"
 	//	"sort"
 	"testing"
 
+	agentmodel "github.com/DataDog/agent-payload/v5/process"
 	krpretty "github.com/kr/pretty"
 
 	"github.com/DataDog/datadog-agent/test/fakeintake/api"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 //go:embed fixtures/connections_bytes
@@ -26,6 +30,13 @@ func TestConnections(t *testing.T) {
 		assert.Empty(t, checks)
 	})
 
+	t.Run("parseConnectionsPayload should return error on unexpected message type", func(t *testing.T) {
+		data := encodeCollectorProc(t, &agentmodel.CollectorProc{HostName: "my-host"})
+		cc, err := ParseConnections(api.Payload{Data: data, Encoding: encodingProtobuf})
+		assert.ErrorContains(t, err, "not protobuf process.CollectorConnections type")
+		assert.Empty(t, cc)
+	})
+
 	t.Run("parseConnectionsPayload should return valid checks on valid ", func(t *testing.T) {
 		cc, err := ParseConnections(api.Payload{Data: connectionsData, Encoding: encodingProtobuf})
 		assert.NoError(t, err)
@@ -44,4 +55,55 @@ func TestConnections(t *testing.T) {
 		assert.Equal(t, uint64(143), c.LastPacketsReceived)
 		assert.Equal(t, uint32(0xf0000000), c.NetNS)
 	})
+	t.Run("parseConnectionsPayload should decompress gzip encoded payloads", func(t *testing.T) {
+		var compressed bytes.Buffer
+		w := gzip.NewWriter(&compressed)
+		_, err := w.Write(connectionsData)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		cc, err := ParseConnections(api.Payload{Data: compressed.Bytes(), Encoding: encodingGzip})
+		require.NoError(t, err)
+		assert.Equal(t, 1, len(cc))
+		assert.Equal(t, 17, len(cc[0].Connections))
+	})
+}
+
+func TestConnectionsAggregator(t *testing.T) {
+	encodeConnections := func(hostname string, connections ...*agentmodel.Connection) api.Payload {
+		data, err := agentmodel.EncodeMessage(agentmodel.Message{
+			Header: agentmodel.MessageHeader{
+				Version:  agentmodel.MessageV3,
+				Encoding: agentmodel.MessageEncodingZstdPB,
+				Type:     agentmodel.TypeCollectorConnections,
+			},
+			Body: &agentmodel.CollectorConnections{HostName: hostname, Connections: connections},
+		})
+		require.NoError(t, err)
+		return api.Payload{Data: data, Encoding: encodingProtobuf}
+	}
+	newConnection := func(pid int32, srcIP string) *agentmodel.Connection {
+		return &agentmodel.Connection{Pid: pid, Laddr: &agentmodel.Addr{Ip: srcIP, Port: 4242}}
+	}
+
+	agg := NewConnectionsAggregator()
+	err := agg.UnmarshallPayloads([]api.Payload{
+		encodeConnections("host-2", newConnection(3, "10.0.0.2")),
+		encodeConnections("host-1", newConnection(1, "10.0.0.1"), newConnection(2, "127.0.0.1")),
+		encodeConnections("host-1", newConnection(4, "10.0.0.1"), &agentmodel.Connection{Pid: 5}),
+	})
+	require.NoError(t, err)
+
+	pids := func(connections []*agentmodel.Connection) []int32 {
+		ret := []int32{}
+		for _, c := range connections {
+			ret = append(ret, c.Pid)
+		}
+		return ret
+	}
+
+	assert.Equal(t, []int32{1, 2, 4, 5, 3}, pids(agg.GetNetworkConnections()))
+	assert.Equal(t, []int32{1, 4}, pids(agg.GetConnectionsBySource("10.0.0.1")))
+	assert.Equal(t, []int32{3}, pids(agg.GetConnectionsBySource("10.0.0.2")))
+	assert.Empty(t, agg.GetConnectionsBySource("10.0.0.3"))
 }
@@ -0,0 +1,86 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package aggregator
+
+import (
+	"fmt"
+	"time"
+
+	agentmodel "github.com/DataDog/agent-payload/v5/process"
+
+	"github.com/DataDog/datadog-agent/test/fakeintake/api"
+)
+
+// ContainerPayload type contain all payload from /api/v1/container
+type ContainerPayload struct {
+	agentmodel.CollectorContainer
+	collectedTime time.Time
+}
+
+// name return container payload name based on hostname
+func (p *ContainerPayload) name() string {
+	return p.HostName
+}
+
+// GetTags return the host tags of the container payload
+func (p *ContainerPayload) GetTags() []string {
+	if p.Host == nil {
+		return []string{}
+	}
+	return p.Host.AllTags
+}
+
+// GetCollectedTime return the time when the payload has been collected by the fakeintake server
+func (p *ContainerPayload) GetCollectedTime() time.Time {
+	return p.collectedTime
+}
+
+// decodeCollectorContainer return a CollectorContainer protobuf object from raw bytes
+func decodeCollectorContainer(b []byte) (*agentmodel.CollectorContainer, error) {
+	m, err := agentmodel.DecodeMessage(b)
+	if err != nil {
+		return nil, err
+	}
+	container, ok := m.Body.(*agentmodel.CollectorContainer)
+	if !ok {
+		return nil, fmt.Errorf("not protobuf process.CollectorContainer type")
+	}
+	return container, nil
+}
+
+// ParseContainerPayload return the ContainerPayload from payload
+func ParseContainerPayload(payload api.Payload) ([]*ContainerPayload, error) {
+	enflated, err := enflate(payload.Data, payload.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	container, err := decodeCollectorContainer(enflated)
+	if err != nil {
+		return nil, err
+	}
+	return []*ContainerPayload{{CollectorContainer: *container, collectedTime: payload.Timestamp}}, nil
+}
+
+// ContainerAggregator aggregate container payloads
+type ContainerAggregator struct {
+	Aggregator[*ContainerPayload]
+}
+
+// NewContainerAggregator create a new aggregator
+func NewContainerAggregator() ContainerAggregator {
+	return ContainerAggregator{
+		Aggregator: newAggregator(ParseContainerPayload),
+	}
+}
+
+// GetContainers return the container payloads of all hosts, ordered by hostname
+func (agg *ContainerAggregator) GetContainers() []*ContainerPayload {
+	payloads := []*ContainerPayload{}
+	for _, name := range agg.GetNames() {
+		payloads = append(payloads, agg.GetPayloadsByName(name)...)
+	}
+	return payloads
+}
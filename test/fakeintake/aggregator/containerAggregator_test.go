@@ -0,0 +1,99 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package aggregator
+
+import (
+	"testing"
+
+	agentmodel "github.com/DataDog/agent-payload/v5/process"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/test/fakeintake/api"
+)
+
+func encodeCollectorContainer(t *testing.T, container *agentmodel.CollectorContainer) []byte {
+	data, err := agentmodel.EncodeMessage(agentmodel.Message{
+		Header: agentmodel.MessageHeader{
+			Version:  agentmodel.MessageV3,
+			Encoding: agentmodel.MessageEncodingZstdPB,
+			Type:     agentmodel.TypeCollectorContainer,
+		},
+		Body: container,
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func TestContainerPayload(t *testing.T) {
+	t.Run("ParseContainerPayload should return error on invalid data", func(t *testing.T) {
+		payloads, err := ParseContainerPayload(api.Payload{Data: []byte(""), Encoding: encodingProtobuf})
+		assert.Error(t, err)
+		assert.Empty(t, payloads)
+	})
+
+	t.Run("ParseContainerPayload should return error on unexpected message type", func(t *testing.T) {
+		data := encodeCollectorProc(t, &agentmodel.CollectorProc{HostName: "my-host"})
+		payloads, err := ParseContainerPayload(api.Payload{Data: data, Encoding: encodingProtobuf})
+		assert.ErrorContains(t, err, "not protobuf process.CollectorContainer type")
+		assert.Empty(t, payloads)
+	})
+
+	t.Run("ParseContainerPayload should return valid payloads on valid data", func(t *testing.T) {
+		data := encodeCollectorContainer(t, &agentmodel.CollectorContainer{
+			HostName: "my-host",
+			Host:     &agentmodel.Host{Name: "my-host", AllTags: []string{"env:test"}},
+			Containers: []*agentmodel.Container{
+				{Id: "abcdef", Name: "my-container", State: agentmodel.ContainerState_running},
+				{Id: "012345", Name: "my-other-container", State: agentmodel.ContainerState_exited},
+			},
+		})
+
+		payloads, err := ParseContainerPayload(api.Payload{Data: data, Encoding: encodingProtobuf})
+		require.NoError(t, err)
+		require.Len(t, payloads, 1)
+
+		payload := payloads[0]
+		assert.Equal(t, "my-host", payload.name())
+		assert.Equal(t, []string{"env:test"}, payload.GetTags())
+		require.Len(t, payload.Containers, 2)
+		assert.Equal(t, "my-container", payload.Containers[0].Name)
+		assert.Equal(t, agentmodel.ContainerState_exited, payload.Containers[1].State)
+	})
+
+	t.Run("GetTags is nil-safe on the host", func(t *testing.T) {
+		assert.Empty(t, (&ContainerPayload{}).GetTags())
+	})
+}
+
+func TestContainerAggregator(t *testing.T) {
+	newPayload := func(hostname string, containerIDs ...string) api.Payload {
+		container := &agentmodel.CollectorContainer{HostName: hostname}
+		for _, id := range containerIDs {
+			container.Containers = append(container.Containers, &agentmodel.Container{Id: id})
+		}
+		return api.Payload{Data: encodeCollectorContainer(t, container), Encoding: encodingProtobuf}
+	}
+
+	agg := NewContainerAggregator()
+	err := agg.UnmarshallPayloads([]api.Payload{
+		newPayload("host-2", "web"),
+		newPayload("host-1", "db", "cache"),
+		newPayload("host-1", "db"),
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"host-1", "host-2"}, agg.GetNames())
+	payloads := agg.GetContainers()
+	require.Len(t, payloads, 3)
+	assert.Equal(t, "host-1", payloads[0].HostName)
+	assert.Equal(t, "host-1", payloads[1].HostName)
+	assert.Equal(t, "host-2", payloads[2].HostName)
+	assert.Equal(t, "web", payloads[2].Containers[0].Id)
+
+	agg.Reset()
+	assert.Empty(t, agg.GetContainers())
+}
@@ -0,0 +1,156 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package aggregator
+
+import (
+	"fmt"
+	"time"
+
+	agentmodel "github.com/DataDog/agent-payload/v5/process"
+
+	"github.com/DataDog/datadog-agent/test/fakeintake/api"
+)
+
+// ProcessPayload type contain all payload from /api/v1/collector
+type ProcessPayload struct {
+	agentmodel.CollectorProc
+	collectedTime time.Time
+}
+
+// name return process payload name based on hostname
+func (p *ProcessPayload) name() string {
+	return p.HostName
+}
+
+// GetTags return the host tags of the process payload
+func (p *ProcessPayload) GetTags() []string {
+	if p.Host == nil {
+		return []string{}
+	}
+	return p.Host.AllTags
+}
+
+// GetCollectedTime return the time when the payload has been collected by the fakeintake server
+func (p *ProcessPayload) GetCollectedTime() time.Time {
+	return p.collectedTime
+}
+
+// GetContainers return the containers of the process payload
+func (p *ProcessPayload) GetContainers() []*agentmodel.Container {
+	if p == nil {
+		return nil
+	}
+	return p.Containers
+}
+
+// ContainerCount return the number of containers of the process payload
+func (p *ProcessPayload) ContainerCount() int {
+	return len(p.GetContainers())
+}
+
+// decodeCollectorProc return a CollectorProc protobuf object from raw bytes
+func decodeCollectorProc(b []byte) (*agentmodel.CollectorProc, error) {
+	m, err := agentmodel.DecodeMessage(b)
+	if err != nil {
+		return nil, err
+	}
+	proc, ok := m.Body.(*agentmodel.CollectorProc)
+	if !ok {
+		return nil, fmt.Errorf("not protobuf process.CollectorProc type")
+	}
+	return proc, nil
+}
+
+// ParseProcessPayload return the ProcessPayload from payload
+func ParseProcessPayload(payload api.Payload) ([]*ProcessPayload, error) {
+	enflated, err := enflate(payload.Data, payload.Encoding)
+	if err != nil {
+		return nil, err
+	}
+	proc, err := decodeCollectorProc(enflated)
+	if err != nil {
+		return nil, err
+	}
+	return []*ProcessPayload{{CollectorProc: *proc, collectedTime: payload.Timestamp}}, nil
+}
+
+// ProcessAggregator aggregate process payloads
+type ProcessAggregator struct {
+	Aggregator[*ProcessPayload]
+}
+
+// NewProcessAggregator create a new aggregator
+func NewProcessAggregator() ProcessAggregator {
+	return ProcessAggregator{
+		Aggregator: newAggregator(ParseProcessPayload),
+	}
+}
+
+// GetProcesses return the process payloads of all hosts, ordered by hostname
+func (agg *ProcessAggregator) GetProcesses() []*ProcessPayload {
+	payloads := []*ProcessPayload{}
+	for _, name := range agg.GetNames() {
+		payloads = append(payloads, agg.GetPayloadsByName(name)...)
+	}
+	return payloads
+}
+
+// GetProcessesByHost return the process payloads sent by the given host
+func (agg *ProcessAggregator) GetProcessesByHost(host string) []*ProcessPayload {
+	return append([]*ProcessPayload{}, agg.GetPayloadsByName(host)...)
+}
+
+// GetProcessesByTag return the process payloads of all hosts whose tags contain the given tag
+func (agg *ProcessAggregator) GetProcessesByTag(tag string) []*ProcessPayload {
+	return FilterByTags(agg.GetProcesses(), []string{tag})
+}
+
+// GetProcessesByContainerID return the process payloads describing the given container
+// or a process running in it
+func (agg *ProcessAggregator) GetProcessesByContainerID(containerID string) []*ProcessPayload {
+	payloads := []*ProcessPayload{}
+	for _, payload := range agg.GetProcesses() {
+		if payload.hasContainer(containerID) {
+			payloads = append(payloads, payload)
+		}
+	}
+	return payloads
+}
+
+// GetProcessesByName return the process payloads containing a process whose executable is processName.
+// The returned payloads are copies only listing the matching processes.
+func (agg *ProcessAggregator) GetProcessesByName(processName string) []*ProcessPayload {
+	payloads := []*ProcessPayload{}
+	for _, payload := range agg.GetProcesses() {
+		var processes []*agentmodel.Process
+		for _, process := range payload.Processes {
+			if process.Command != nil && process.Command.Exe == processName {
+				processes = append(processes, process)
+			}
+		}
+		if len(processes) == 0 {
+			continue
+		}
+		filtered := &ProcessPayload{CollectorProc: payload.CollectorProc, collectedTime: payload.collectedTime}
+		filtered.Processes = processes
+		payloads = append(payloads, filtered)
+	}
+	return payloads
+}
+
+func (p *ProcessPayload) hasContainer(containerID string) bool {
+	for _, container := range p.GetContainers() {
+		if container.Id == containerID {
+			return true
+		}
+	}
+	for _, process := range p.Processes {
+		if process.ContainerId == containerID {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,215 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package aggregator
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	agentmodel "github.com/DataDog/agent-payload/v5/process"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/DataDog/datadog-agent/test/fakeintake/api"
+)
+
+func encodeCollectorProc(t *testing.T, proc *agentmodel.CollectorProc) []byte {
+	data, err := agentmodel.EncodeMessage(agentmodel.Message{
+		Header: agentmodel.MessageHeader{
+			Version:  agentmodel.MessageV3,
+			Encoding: agentmodel.MessageEncodingZstdPB,
+			Type:     agentmodel.TypeCollectorProc,
+		},
+		Body: proc,
+	})
+	require.NoError(t, err)
+	return data
+}
+
+func TestProcessPayload(t *testing.T) {
+	t.Run("ParseProcessPayload should return error on invalid data", func(t *testing.T) {
+		payloads, err := ParseProcessPayload(api.Payload{Data: []byte(""), Encoding: encodingProtobuf})
+		assert.Error(t, err)
+		assert.Empty(t, payloads)
+	})
+
+	t.Run("ParseProcessPayload should return valid payloads on valid data", func(t *testing.T) {
+		data := encodeCollectorProc(t, &agentmodel.CollectorProc{
+			HostName: "my-host",
+			Host:     &agentmodel.Host{Name: "my-host", AllTags: []string{"env:test"}},
+			Processes: []*agentmodel.Process{
+				{Pid: 1, Command: &agentmodel.Command{Args: []string{"init"}}},
+				{Pid: 42, ContainerId: "abcdef"},
+			},
+			Containers: []*agentmodel.Container{
+				{Id: "abcdef", Name: "my-container"},
+			},
+		})
+
+		payloads, err := ParseProcessPayload(api.Payload{Data: data, Encoding: encodingProtobuf})
+		require.NoError(t, err)
+		require.Len(t, payloads, 1)
+
+		payload := payloads[0]
+		assert.Equal(t, "my-host", payload.name())
+		assert.Equal(t, []string{"env:test"}, payload.GetTags())
+		assert.Len(t, payload.Processes, 2)
+		assert.Equal(t, 1, payload.ContainerCount())
+		assert.Equal(t, "my-container", payload.GetContainers()[0].Name)
+	})
+
+	t.Run("ParseProcessPayload should decompress gzip encoded payloads", func(t *testing.T) {
+		data := encodeCollectorProc(t, &agentmodel.CollectorProc{HostName: "my-host"})
+		var compressed bytes.Buffer
+		w := gzip.NewWriter(&compressed)
+		_, err := w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+
+		payloads, err := ParseProcessPayload(api.Payload{Data: compressed.Bytes(), Encoding: encodingGzip})
+		require.NoError(t, err)
+		require.Len(t, payloads, 1)
+		assert.Equal(t, "my-host", payloads[0].name())
+	})
+
+	t.Run("container helpers are nil-safe", func(t *testing.T) {
+		var payload *ProcessPayload
+		assert.Nil(t, payload.GetContainers())
+		assert.Equal(t, 0, payload.ContainerCount())
+		assert.Empty(t, (&ProcessPayload{}).GetTags())
+	})
+}
+
+func TestProcessAggregator(t *testing.T) {
+	agg := NewProcessAggregator()
+	err := agg.UnmarshallPayloads([]api.Payload{
+		{Data: encodeCollectorProc(t, &agentmodel.CollectorProc{HostName: "host-1"}), Encoding: encodingProtobuf},
+		{Data: encodeCollectorProc(t, &agentmodel.CollectorProc{HostName: "host-2"}), Encoding: encodingProtobuf},
+		{Data: encodeCollectorProc(t, &agentmodel.CollectorProc{HostName: "host-1"}), Encoding: encodingProtobuf},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"host-1", "host-2"}, agg.GetNames())
+	assert.Len(t, agg.GetPayloadsByName("host-1"), 2)
+}
+
+func TestProcessAggregatorGetProcessesByTag(t *testing.T) {
+	newPayload := func(hostname string, tags ...string) api.Payload {
+		proc := &agentmodel.CollectorProc{
+			HostName: hostname,
+			Host:     &agentmodel.Host{Name: hostname, AllTags: tags},
+		}
+		return api.Payload{Data: encodeCollectorProc(t, proc), Encoding: encodingProtobuf}
+	}
+
+	agg := NewProcessAggregator()
+	err := agg.UnmarshallPayloads([]api.Payload{
+		newPayload("host-2", "env:prod", "service:web"),
+		newPayload("host-1", "env:prod"),
+		newPayload("host-3", "env:staging"),
+		newPayload("host-1", "env:staging"),
+	})
+	require.NoError(t, err)
+
+	hostnames := func(payloads []*ProcessPayload) []string {
+		names := []string{}
+		for _, payload := range payloads {
+			names = append(names, payload.HostName)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"host-1", "host-2"}, hostnames(agg.GetProcessesByTag("env:prod")))
+	assert.Equal(t, []string{"host-1", "host-3"}, hostnames(agg.GetProcessesByTag("env:staging")))
+	assert.Equal(t, []string{"host-2"}, hostnames(agg.GetProcessesByTag("service:web")))
+	assert.Empty(t, agg.GetProcessesByTag("env:dev"))
+}
+
+func TestProcessAggregatorGetProcessesByHostAndContainerID(t *testing.T) {
+	newPayload := func(hostname string, containerIDs []string, processContainerIDs []string) api.Payload {
+		proc := &agentmodel.CollectorProc{HostName: hostname}
+		for _, id := range containerIDs {
+			proc.Containers = append(proc.Containers, &agentmodel.Container{Id: id})
+		}
+		for i, id := range processContainerIDs {
+			proc.Processes = append(proc.Processes, &agentmodel.Process{Pid: int32(i + 1), ContainerId: id})
+		}
+		return api.Payload{Data: encodeCollectorProc(t, proc), Encoding: encodingProtobuf}
+	}
+
+	agg := NewProcessAggregator()
+	err := agg.UnmarshallPayloads([]api.Payload{
+		newPayload("host-2", []string{"web"}, []string{"web", ""}),
+		newPayload("host-1", []string{"db"}, nil),
+		newPayload("host-3", nil, []string{"worker"}),
+		newPayload("host-1", nil, []string{""}),
+	})
+	require.NoError(t, err)
+
+	hostnames := func(payloads []*ProcessPayload) []string {
+		names := []string{}
+		for _, payload := range payloads {
+			names = append(names, payload.HostName)
+		}
+		return names
+	}
+
+	assert.Equal(t, []string{"host-1", "host-1", "host-2", "host-3"}, hostnames(agg.GetProcesses()))
+
+	assert.Equal(t, []string{"host-1", "host-1"}, hostnames(agg.GetProcessesByHost("host-1")))
+	assert.Equal(t, []string{"host-2"}, hostnames(agg.GetProcessesByHost("host-2")))
+	assert.Empty(t, agg.GetProcessesByHost("host-4"))
+
+	assert.Equal(t, []string{"host-2"}, hostnames(agg.GetProcessesByContainerID("web")))
+	assert.Equal(t, []string{"host-1"}, hostnames(agg.GetProcessesByContainerID("db")))
+	// only referenced by a process
+	assert.Equal(t, []string{"host-3"}, hostnames(agg.GetProcessesByContainerID("worker")))
+	assert.Empty(t, agg.GetProcessesByContainerID("cache"))
+}
+
+func TestProcessAggregatorGetProcessesByName(t *testing.T) {
+	newPayload := func(hostname string, exes ...string) api.Payload {
+		proc := &agentmodel.CollectorProc{HostName: hostname}
+		for i, exe := range exes {
+			proc.Processes = append(proc.Processes, &agentmodel.Process{Pid: int32(i + 1), Command: &agentmodel.Command{Exe: exe}})
+		}
+		// processes without a command line are skipped
+		proc.Processes = append(proc.Processes, &agentmodel.Process{Pid: int32(len(exes) + 1)})
+		return api.Payload{Data: encodeCollectorProc(t, proc), Encoding: encodingProtobuf}
+	}
+
+	agg := NewProcessAggregator()
+	err := agg.UnmarshallPayloads([]api.Payload{
+		newPayload("host-2", "/usr/bin/nginx", "/usr/bin/python3", "/usr/bin/nginx"),
+		newPayload("host-1", "/usr/bin/python3"),
+		newPayload("host-3", "/bin/bash"),
+	})
+	require.NoError(t, err)
+
+	type hostPids struct {
+		host string
+		pids []int32
+	}
+	summarize := func(payloads []*ProcessPayload) []hostPids {
+		ret := []hostPids{}
+		for _, payload := range payloads {
+			pids := []int32{}
+			for _, process := range payload.Processes {
+				pids = append(pids, process.Pid)
+			}
+			ret = append(ret, hostPids{host: payload.HostName, pids: pids})
+		}
+		return ret
+	}
+
+	assert.Equal(t, []hostPids{{"host-2", []int32{1, 3}}}, summarize(agg.GetProcessesByName("/usr/bin/nginx")))
+	assert.Equal(t, []hostPids{{"host-1", []int32{1}}, {"host-2", []int32{2}}}, summarize(agg.GetProcessesByName("/usr/bin/python3")))
+	assert.Empty(t, agg.GetProcessesByName("nginx"))
+
+	// the aggregated payloads are left untouched
+	assert.Len(t, agg.GetProcessesByHost("host-2")[0].Processes, 4)
+}
@@ -35,3 +35,20 @@ type RouteStat struct {
 type APIFakeIntakeRouteStatsGETResponse struct {
 	Routes map[string]RouteStat `json:"routes"`
 }
+
+// HistogramBucket is the number of values lower than or equal to LE, and greater than the previous bucket bound
+type HistogramBucket struct {
+	LE    string `json:"le"`
+	Count uint64 `json:"count"`
+}
+
+type Histogram struct {
+	Count   uint64            `json:"count"`
+	Sum     float64           `json:"sum"`
+	Max     float64           `json:"max"`
+	Buckets []HistogramBucket `json:"buckets"`
+}
+
+type APIFakeIntakeStatsGETResponse struct {
+	Histograms map[string]Histogram `json:"histograms"`
+}
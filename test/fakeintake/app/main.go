@@ -18,6 +18,7 @@ import (
 
 func main() {
 	portPtr := flag.Int("port", 80, "fakeintake listening port, default to 80. Using -port=0 will use a random available port")
+	apiKeyPtr := flag.String("api-key", "", "when set, reject the intake requests whose DD-API-KEY header doesn't match this API key")
 	flag.Parse()
 
 	sigs := make(chan os.Signal, 1)
@@ -25,7 +26,7 @@ func main() {
 
 	log.Println("⌛️ Starting fake intake")
 	ready := make(chan bool, 1)
-	fi := fakeintake.NewServer(fakeintake.WithPort(*portPtr), fakeintake.WithReadyChannel(ready))
+	fi := fakeintake.NewServer(fakeintake.WithPort(*portPtr), fakeintake.WithReadyChannel(ready), fakeintake.WithRequireAPIKey(*apiKeyPtr))
 	fi.Start()
 	timeout := time.NewTimer(5 * time.Second)
 
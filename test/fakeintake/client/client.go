@@ -46,6 +46,7 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"sync"
 
 	"github.com/DataDog/datadog-agent/test/fakeintake/aggregator"
 	"github.com/DataDog/datadog-agent/test/fakeintake/api"
@@ -59,6 +60,11 @@ type Client struct {
 	checkRunAggregator   aggregator.CheckRunAggregator
 	logAggregator        aggregator.LogAggregator
 	connectionAggregator aggregator.ConnectionsAggregator
+	processAggregator    aggregator.ProcessAggregator
+
+	subscribersMutex sync.RWMutex
+	subscribers      map[*subscriber]struct{}
+	polling          bool
 }
 
 // NewClient creates a new fake intake client
@@ -70,6 +76,7 @@ func NewClient(fakeIntakeURL string) *Client {
 		checkRunAggregator:   aggregator.NewCheckRunAggregator(),
 		logAggregator:        aggregator.NewLogAggregator(),
 		connectionAggregator: aggregator.NewConnectionsAggregator(),
+		processAggregator:    aggregator.NewProcessAggregator(),
 	}
 }
 
@@ -105,6 +112,14 @@ func (c *Client) getConnections() error {
 	return c.connectionAggregator.UnmarshallPayloads(payloads)
 }
 
+func (c *Client) getProcesses() error {
+	payloads, err := c.getFakePayloads("/api/v1/collector")
+	if err != nil {
+		return err
+	}
+	return c.processAggregator.UnmarshallPayloads(payloads)
+}
+
 // GetLatestFlare queries the Fake Intake to fetch flares that were sent by a Datadog Agent and returns the latest flare as a Flare struct
 // TODO: handle multiple flares / flush when returning latest flare
 func (c *Client) GetLatestFlare() (flare.Flare, error) {
@@ -391,3 +406,13 @@ func (c *Client) GetConnectionsNames() ([]string, error) {
 	}
 	return c.connectionAggregator.GetNames(), nil
 }
+
+// GetProcesses fetches fakeintake on `/api/v1/collector` endpoint and returns
+// all received process payloads
+func (c *Client) GetProcesses() (*aggregator.ProcessAggregator, error) {
+	err := c.getProcesses()
+	if err != nil {
+		return nil, err
+	}
+	return &c.processAggregator, nil
+}
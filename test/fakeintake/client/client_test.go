@@ -8,11 +8,16 @@ package client
 import (
 	_ "embed"
 
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/DataDog/datadog-agent/test/fakeintake/aggregator"
 	"github.com/DataDog/datadog-agent/test/fakeintake/api"
@@ -234,4 +239,128 @@ func TestClient(t *testing.T) {
 		assert.Equal(t, flare.GetAgentVersion(), "7.45.1+commit.102cdaf")
 		assert.Equal(t, flare.GetHostname(), "test-hostname")
 	})
+
+	t.Run("Subscribe", func(t *testing.T) {
+		var mu sync.Mutex
+		payloads := []api.Payload{}
+		addPayload := func(data string) {
+			mu.Lock()
+			defer mu.Unlock()
+			payloads = append(payloads, api.Payload{Timestamp: time.Now(), Data: []byte(data)})
+		}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Query().Get("endpoint") != "/foo/bar" {
+				w.Write([]byte(`{"payloads":[]}`))
+				return
+			}
+			mu.Lock()
+			resp, err := json.Marshal(api.APIFakeIntakePayloadsRawGETResponse{Payloads: payloads})
+			mu.Unlock()
+			require.NoError(t, err)
+			w.Write(resp)
+		}))
+		defer ts.Close()
+
+		addPayload("before")
+		client := NewClient(ts.URL)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		all := client.Subscribe(ctx, "/foo/bar", func(api.Payload) bool { return true })
+		matching := client.Subscribe(ctx, "/foo/bar", func(p api.Payload) bool {
+			return strings.HasPrefix(string(p.Data), "match")
+		})
+
+		receive := func(ch <-chan api.Payload) string {
+			select {
+			case p := <-ch:
+				return string(p.Data)
+			case <-time.After(5 * time.Second):
+				require.Fail(t, "timeout waiting for payload")
+				return ""
+			}
+		}
+
+		assert.Equal(t, "before", receive(all))
+		addPayload("other")
+		addPayload("match-1")
+		assert.Equal(t, "other", receive(all))
+		assert.Equal(t, "match-1", receive(all))
+		assert.Equal(t, "match-1", receive(matching))
+
+		cancel()
+		for _, ch := range []<-chan api.Payload{all, matching} {
+			select {
+			case _, ok := <-ch:
+				assert.False(t, ok)
+			case <-time.After(5 * time.Second):
+				require.Fail(t, "timeout waiting for channel to be closed")
+			}
+		}
+	})
+
+	t.Run("Subscribe with a slow subscriber", func(t *testing.T) {
+		start := time.Now()
+		payloads := []api.Payload{}
+		for i := 0; i < 2*subscribeBufferSize; i++ {
+			payloads = append(payloads, api.Payload{Timestamp: start.Add(time.Duration(i) * time.Millisecond), Data: []byte(strconv.Itoa(i))})
+		}
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resp, err := json.Marshal(api.APIFakeIntakePayloadsRawGETResponse{Payloads: payloads})
+			require.NoError(t, err)
+			w.Write(resp)
+		}))
+		defer ts.Close()
+
+		client := NewClient(ts.URL)
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		slow := client.Subscribe(ctx, "/foo/bar", func(api.Payload) bool { return true })
+		fast := client.Subscribe(ctx, "/foo/bar", func(api.Payload) bool { return true })
+
+		receiveAll := func(ch <-chan api.Payload) {
+			for i := 0; i < len(payloads); i++ {
+				select {
+				case p := <-ch:
+					assert.Equal(t, strconv.Itoa(i), string(p.Data))
+				case <-ctx.Done():
+					require.Fail(t, "timeout waiting for payload")
+				}
+			}
+		}
+
+		// the full buffer of the slow subscriber doesn't block the other
+		// one, and no payload is lost once it starts reading
+		receiveAll(fast)
+		receiveAll(slow)
+	})
+
+	t.Run("Subscribe concurrently", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			resp, err := json.Marshal(api.APIFakeIntakePayloadsRawGETResponse{
+				Payloads: []api.Payload{{Timestamp: time.Now(), Data: []byte("totoro")}},
+			})
+			require.NoError(t, err)
+			w.Write(resp)
+		}))
+		defer ts.Close()
+
+		client := NewClient(ts.URL)
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+				defer cancel()
+				select {
+				case p := <-client.Subscribe(ctx, "/foo/bar", func(api.Payload) bool { return true }):
+					assert.Equal(t, "totoro", string(p.Data))
+				case <-ctx.Done():
+					assert.Fail(t, "timeout waiting for payload")
+				}
+			}()
+		}
+		wg.Wait()
+	})
 }
@@ -0,0 +1,130 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/DataDog/datadog-agent/test/fakeintake/api"
+)
+
+const (
+	subscribePollInterval = 100 * time.Millisecond
+	subscribeBufferSize   = 100
+)
+
+type subscriber struct {
+	ctx      context.Context
+	route    string
+	matcher  func(api.Payload) bool
+	payloads chan api.Payload
+	// lastTimestamp is the timestamp of the latest payload sent to this subscriber,
+	// only accessed by the polling goroutine
+	lastTimestamp time.Time
+}
+
+// Subscribe returns a channel receiving the payloads sent to the fakeintake on `route`
+// for which `matcher` returns true, including payloads received before subscribing.
+// A single goroutine polls the fakeintake for all subscribers of the client.
+// The channel is closed once `ctx` is done.
+func (c *Client) Subscribe(ctx context.Context, route string, matcher func(api.Payload) bool) <-chan api.Payload {
+	sub := &subscriber{
+		ctx:      ctx,
+		route:    route,
+		matcher:  matcher,
+		payloads: make(chan api.Payload, subscribeBufferSize),
+	}
+
+	c.subscribersMutex.Lock()
+	if c.subscribers == nil {
+		c.subscribers = map[*subscriber]struct{}{}
+	}
+	c.subscribers[sub] = struct{}{}
+	if !c.polling {
+		c.polling = true
+		go c.pollSubscriptions()
+	}
+	c.subscribersMutex.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		// the polling goroutine holds the read lock while sending to subscribers,
+		// so the channel cannot be closed during a send
+		c.subscribersMutex.Lock()
+		delete(c.subscribers, sub)
+		close(sub.payloads)
+		c.subscribersMutex.Unlock()
+	}()
+
+	return sub.payloads
+}
+
+func (c *Client) pollSubscriptions() {
+	ticker := time.NewTicker(subscribePollInterval)
+	defer ticker.Stop()
+	for {
+		if !c.dispatchToSubscribers() {
+			return
+		}
+		<-ticker.C
+	}
+}
+
+// dispatchToSubscribers fetches new payloads for every subscribed route and sends
+// the matching ones to subscribers. It returns false once there is no subscriber left.
+// The lock is not held while fetching payloads, and sends never block: once the
+// buffer of a subscriber is full, its remaining payloads are sent on the next poll.
+func (c *Client) dispatchToSubscribers() bool {
+	c.subscribersMutex.Lock()
+	if len(c.subscribers) == 0 {
+		c.polling = false
+		c.subscribersMutex.Unlock()
+		return false
+	}
+	routes := map[string]struct{}{}
+	for sub := range c.subscribers {
+		routes[sub.route] = struct{}{}
+	}
+	c.subscribersMutex.Unlock()
+
+	payloadsByRoute := map[string][]api.Payload{}
+	for route := range routes {
+		payloads, err := c.getFakePayloads(route)
+		if err != nil {
+			// retry on next tick
+			continue
+		}
+		payloadsByRoute[route] = payloads
+	}
+
+	// the read lock prevents the channels from being closed during a send
+	c.subscribersMutex.RLock()
+	defer c.subscribersMutex.RUnlock()
+
+	for sub := range c.subscribers {
+		for _, payload := range payloadsByRoute[sub.route] {
+			if !payload.Timestamp.After(sub.lastTimestamp) {
+				continue
+			}
+			if sub.matcher(payload) && !sub.trySend(payload) {
+				break
+			}
+			sub.lastTimestamp = payload.Timestamp
+		}
+	}
+	return true
+}
+
+// trySend sends the payload to the subscriber, and returns false if its buffer is full
+func (sub *subscriber) trySend(payload api.Payload) bool {
+	select {
+	case sub.payloads <- payload:
+		return true
+	default:
+		return false
+	}
+}
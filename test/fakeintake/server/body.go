@@ -62,6 +62,11 @@ func getResponseFromURLPath(urlPath string) httpResponse {
 			contentType: "application/x-protobuf",
 			data:        getConnectionsResponse(),
 		},
+		"/api/v1/collector": {
+			statusCode:  http.StatusOK,
+			contentType: "application/x-protobuf",
+			data:        getConnectionsResponse(),
+		},
 	}
 
 	if _, found := responses[urlPath]; !found {
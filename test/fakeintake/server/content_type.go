@@ -0,0 +1,35 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package server
+
+import (
+	"fmt"
+	"mime"
+)
+
+// expectedContentTypes are the content types sent by the Agent on the routes parsed by the fakeintake.
+// Requests to other routes are accepted with any content type.
+var expectedContentTypes = map[string]string{
+	"/api/v2/logs":        "application/json",
+	"/api/v2/series":      "application/x-protobuf",
+	"/api/v1/check_run":   "application/json",
+	"/api/v1/connections": "application/x-protobuf",
+	"/api/v1/collector":   "application/x-protobuf",
+}
+
+// validateContentType returns an error if the content type doesn't match the one expected on the route
+func validateContentType(route string, contentType string) error {
+	expected, ok := expectedContentTypes[route]
+	if !ok {
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType != expected {
+		return fmt.Errorf("invalid content type %q for route %s, expected %s", contentType, route, expected)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package server
+
+import (
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/DataDog/datadog-agent/test/fakeintake/api"
+)
+
+const ingestLatencyMetric = "fakeintake.ingest_latency_ms"
+
+// ingestLatencyBucketBounds are the upper bounds, in milliseconds, of the buckets of the ingestion latency histogram
+var ingestLatencyBucketBounds = []float64{1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500}
+
+// latencyHistogram is an in-memory histogram of durations, in milliseconds
+type latencyHistogram struct {
+	mu     sync.Mutex
+	bounds []float64
+	// counts has one more element than bounds, for the values greater than the last bound
+	counts []uint64
+	count  uint64
+	sum    float64
+	max    float64
+}
+
+func newLatencyHistogram(bounds []float64) *latencyHistogram {
+	return &latencyHistogram{
+		bounds: bounds,
+		counts: make([]uint64, len(bounds)+1),
+	}
+}
+
+func (h *latencyHistogram) observe(d time.Duration) {
+	ms := float64(d) / float64(time.Millisecond)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	bucket := len(h.bounds)
+	for i, bound := range h.bounds {
+		if ms <= bound {
+			bucket = i
+			break
+		}
+	}
+	h.counts[bucket]++
+	h.count++
+	h.sum += ms
+	if ms > h.max {
+		h.max = ms
+	}
+}
+
+func (h *latencyHistogram) snapshot() api.Histogram {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	buckets := make([]api.HistogramBucket, 0, len(h.counts))
+	for i, count := range h.counts {
+		le := "+Inf"
+		if i < len(h.bounds) {
+			le = strconv.FormatFloat(h.bounds[i], 'f', -1, 64)
+		}
+		buckets = append(buckets, api.HistogramBucket{LE: le, Count: count})
+	}
+
+	return api.Histogram{
+		Count:   h.count,
+		Sum:     h.sum,
+		Max:     h.max,
+		Buckets: buckets,
+	}
+}
@@ -0,0 +1,34 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package server
+
+import (
+	"testing"
+	"time"
+
+	"github.com/DataDog/datadog-agent/test/fakeintake/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLatencyHistogram(t *testing.T) {
+	h := newLatencyHistogram([]float64{1, 10})
+
+	h.observe(500 * time.Microsecond)
+	h.observe(time.Millisecond)
+	h.observe(5 * time.Millisecond)
+	h.observe(20 * time.Millisecond)
+
+	assert.Equal(t, api.Histogram{
+		Count: 4,
+		Sum:   26.5,
+		Max:   20,
+		Buckets: []api.HistogramBucket{
+			{LE: "1", Count: 2},
+			{LE: "10", Count: 1},
+			{LE: "+Inf", Count: 1},
+		},
+	}, h.snapshot())
+}
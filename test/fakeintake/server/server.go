@@ -9,6 +9,7 @@
 //   - /fakeintake/payloads/<payload_route> returns any received payloads on the specified route as [api.Payload]s
 //   - /fakeintake/health returns current fakeintake server health
 //   - /fakeintake/routestats returns stats for collected payloads, by route
+//   - /fakeintake/stats returns the fakeintake internal stats, like the payload ingestion latency histogram
 //   - /fakeintake/flushPayloads returns all stored payloads and clear them up
 //
 // [api.Payloads]: https://pkg.go.dev/github.com/DataDog/datadog-agent@main/test/fakeintake/api#Payload
@@ -42,6 +43,11 @@ type Server struct {
 	url      string
 
 	store *serverstore.Store
+
+	ingestLatency *latencyHistogram
+
+	// requiredAPIKey, when not empty, is the only API key accepted on the intake routes
+	requiredAPIKey string
 }
 
 // NewServer creates a new fake intake server and starts it on localhost:port
@@ -54,6 +60,8 @@ func NewServer(options ...func(*Server)) *Server {
 		clock:     clock.New(),
 		retention: 15 * time.Minute,
 		store:     serverstore.NewStore(),
+
+		ingestLatency: newLatencyHistogram(ingestLatencyBucketBounds),
 	}
 
 	mux := http.NewServeMux()
@@ -62,6 +70,7 @@ func NewServer(options ...func(*Server)) *Server {
 	mux.HandleFunc("/fakeintake/health/", fi.handleFakeHealth)
 	mux.HandleFunc("/fakeintake/routestats/", fi.handleGetRouteStats)
 	mux.HandleFunc("/fakeintake/flushPayloads/", fi.handleFlushPayloads)
+	mux.HandleFunc("/fakeintake/stats/", fi.handleGetStats)
 
 	fi.server = http.Server{
 		Handler: mux,
@@ -118,6 +127,19 @@ func WithRetention(retention time.Duration) func(*Server) {
 	}
 }
 
+// WithRequireAPIKey makes the server reject the requests to the intake routes
+// whose DD-API-KEY header doesn't match apiKey with a 403 status code.
+// An empty apiKey accepts any request.
+func WithRequireAPIKey(apiKey string) func(*Server) {
+	return func(fi *Server) {
+		if fi.IsRunning() {
+			log.Println("Fake intake is already running. Stop it and try again to change the required API key.")
+			return
+		}
+		fi.requiredAPIKey = apiKey
+	}
+}
+
 // Start Starts a fake intake server in a separate go-routine
 // Notifies when ready to the ready channel
 func (fi *Server) Start() {
@@ -215,6 +237,13 @@ func (fi *Server) handleDatadogRequest(w http.ResponseWriter, req *http.Request)
 
 	log.Printf("Handling Datadog %s request to %s, header %v", req.Method, req.URL.Path, req.Header)
 
+	if fi.requiredAPIKey != "" && req.Header.Get("DD-API-KEY") != fi.requiredAPIKey {
+		response := buildErrorResponse(errors.New("invalid API key"))
+		response.statusCode = http.StatusForbidden
+		writeHTTPResponse(w, response)
+		return
+	}
+
 	if req.Method == http.MethodGet {
 		writeHTTPResponse(w, httpResponse{
 			statusCode: http.StatusOK,
@@ -245,6 +274,18 @@ func (fi *Server) handleDatadogRequest(w http.ResponseWriter, req *http.Request)
 		return
 	}
 
+	start := fi.clock.Now()
+	defer func() {
+		fi.ingestLatency.observe(fi.clock.Since(start))
+	}()
+
+	if err := validateContentType(req.URL.Path, req.Header.Get("Content-Type")); err != nil {
+		response := buildErrorResponse(err)
+		response.statusCode = http.StatusUnsupportedMediaType
+		writeHTTPResponse(w, response)
+		return
+	}
+
 	if req.Body == nil {
 		response := buildErrorResponse(errors.New("invalid request, nil body"))
 		writeHTTPResponse(w, response)
@@ -374,3 +415,28 @@ func (fi *Server) handleGetRouteStats(w http.ResponseWriter, req *http.Request)
 		body:        jsonResp,
 	})
 }
+
+func (fi *Server) handleGetStats(w http.ResponseWriter, _ *http.Request) {
+	log.Print("Handling getStats request")
+	resp := api.APIFakeIntakeStatsGETResponse{
+		Histograms: map[string]api.Histogram{
+			ingestLatencyMetric: fi.ingestLatency.snapshot(),
+		},
+	}
+	jsonResp, err := json.Marshal(resp)
+	if err != nil {
+		writeHTTPResponse(w, httpResponse{
+			contentType: "text/plain",
+			statusCode:  http.StatusInternalServerError,
+			body:        []byte(err.Error()),
+		})
+		return
+	}
+
+	// send response
+	writeHTTPResponse(w, httpResponse{
+		contentType: "application/json",
+		statusCode:  http.StatusOK,
+		body:        jsonResp,
+	})
+}
@@ -52,6 +52,70 @@ func TestServer(t *testing.T) {
 		assert.Equal(t, http.StatusOK, response.Code, "unexpected code")
 	})
 
+	t.Run("should reject requests with an invalid API key when an API key is required", func(t *testing.T) {
+		fi := NewServer(WithClock(clock.NewMock()), WithRequireAPIKey("valid"))
+
+		for _, apiKey := range []string{"", "invalid"} {
+			request, err := http.NewRequest(http.MethodPost, "/totoro", strings.NewReader("totoro|5|tag:valid,owner:pducolin"))
+			assert.NoError(t, err, "Error creating POST request")
+			if apiKey != "" {
+				request.Header.Set("DD-API-KEY", apiKey)
+			}
+			response := httptest.NewRecorder()
+
+			fi.handleDatadogRequest(response, request)
+
+			assert.Equal(t, http.StatusForbidden, response.Code, "unexpected code")
+		}
+		assert.Empty(t, fi.store.GetRouteStats())
+	})
+
+	t.Run("should accept requests with a valid API key when an API key is required", func(t *testing.T) {
+		fi := NewServer(WithClock(clock.NewMock()), WithRequireAPIKey("valid"))
+
+		request, err := http.NewRequest(http.MethodPost, "/totoro", strings.NewReader("totoro|5|tag:valid,owner:pducolin"))
+		assert.NoError(t, err, "Error creating POST request")
+		request.Header.Set("DD-API-KEY", "valid")
+		response := httptest.NewRecorder()
+
+		fi.handleDatadogRequest(response, request)
+
+		assert.Equal(t, http.StatusOK, response.Code, "unexpected code")
+	})
+
+	t.Run("should reject payloads with an unexpected content type", func(t *testing.T) {
+		fi := NewServer(WithClock(clock.NewMock()))
+
+		for _, contentType := range []string{"", "application/x-protobuf", "not a content type"} {
+			request, err := http.NewRequest(http.MethodPost, "/api/v2/logs", bytes.NewBuffer(logBytes))
+			require.NoError(t, err, "Error creating POST request")
+			request.Header.Set("Content-Encoding", "gzip")
+			if contentType != "" {
+				request.Header.Set("Content-Type", contentType)
+			}
+			response := httptest.NewRecorder()
+
+			fi.handleDatadogRequest(response, request)
+
+			assert.Equal(t, http.StatusUnsupportedMediaType, response.Code, "unexpected code for content type %q", contentType)
+		}
+		assert.Empty(t, fi.store.GetRouteStats())
+	})
+
+	t.Run("should accept payloads with the expected content type and parameters", func(t *testing.T) {
+		fi := NewServer(WithClock(clock.NewMock()))
+
+		request, err := http.NewRequest(http.MethodPost, "/api/v2/logs", bytes.NewBuffer(logBytes))
+		require.NoError(t, err, "Error creating POST request")
+		request.Header.Set("Content-Encoding", "gzip")
+		request.Header.Set("Content-Type", "application/json; charset=utf-8")
+		response := httptest.NewRecorder()
+
+		fi.handleDatadogRequest(response, request)
+
+		assert.Equal(t, http.StatusOK, response.Code, "unexpected code")
+	})
+
 	t.Run("should accept GET requests on /fakeintake/payloads route", func(t *testing.T) {
 		fi := NewServer(WithClock(clock.NewMock()))
 
@@ -259,6 +323,31 @@ func TestServer(t *testing.T) {
 		assert.Equal(t, expectedGETResponse, actualGETResponse, "unexpected GET response")
 	})
 
+	t.Run("should return the payload ingestion latency histogram", func(t *testing.T) {
+		fi := NewServer(WithClock(clock.NewMock()))
+
+		postSomeFakePayloads(t, fi)
+
+		request, err := http.NewRequest(http.MethodGet, "/fakeintake/stats", nil)
+		assert.NoError(t, err, "Error creating GET request")
+		getResponse := httptest.NewRecorder()
+
+		fi.handleGetStats(getResponse, request)
+
+		assert.Equal(t, http.StatusOK, getResponse.Code)
+
+		actualGETResponse := api.APIFakeIntakeStatsGETResponse{}
+		body, err := io.ReadAll(getResponse.Body)
+		assert.NoError(t, err, "Error reading GET response")
+		json.Unmarshal(body, &actualGETResponse)
+
+		require.Contains(t, actualGETResponse.Histograms, "fakeintake.ingest_latency_ms")
+		histogram := actualGETResponse.Histograms["fakeintake.ingest_latency_ms"]
+		assert.Equal(t, uint64(3), histogram.Count)
+		assert.Len(t, histogram.Buckets, len(ingestLatencyBucketBounds)+1)
+		assert.Equal(t, api.HistogramBucket{LE: "1", Count: 3}, histogram.Buckets[0])
+	})
+
 	t.Run("should handle flush requests", func(t *testing.T) {
 		clock := clock.NewMock()
 		fi := NewServer(WithClock(clock))
@@ -400,6 +489,7 @@ func postSomeRealisticPayloads(t *testing.T, fi *Server) {
 	request, err := http.NewRequest(http.MethodPost, "/api/v2/logs", bytes.NewBuffer(logBytes))
 	require.NoError(t, err, "Error creating POST request")
 	request.Header.Set("Content-Encoding", "gzip")
+	request.Header.Set("Content-Type", "application/json")
 	postResponse := httptest.NewRecorder()
 	fi.handleDatadogRequest(postResponse, request)
 }
@@ -17,6 +17,7 @@ var parserMap = map[string]parserFunc{
 	"/api/v2/series":      getMetricPayLoadJSON,
 	"/api/v1/check_run":   getCheckRunPayLoadJSON,
 	"/api/v1/connections": getConnectionsPayLoadProtobuf,
+	"/api/v1/collector":   getProcessPayLoadProtobuf,
 }
 
 func getLogPayLoadJSON(payload api.Payload) (interface{}, error) {
@@ -35,6 +36,10 @@ func getConnectionsPayLoadProtobuf(payload api.Payload) (interface{}, error) {
 	return aggregator.ParseConnections(payload)
 }
 
+func getProcessPayLoadProtobuf(payload api.Payload) (interface{}, error) {
+	return aggregator.ParseProcessPayload(payload)
+}
+
 // IsRouteHandled checks if a route is handled by the Datadog parsed store
 func IsRouteHandled(route string) bool {
 	_, ok := parserMap[route]
@@ -69,7 +69,7 @@ func (agent *Agent) initService(t *testing.T, data *agent.ClientData) error {
 	if !agent.shouldWaitForReady {
 		return nil
 	}
-	return agent.waitForReadyTimeout(1 * time.Minute)
+	return agent.WaitForAgentReady(1 * time.Minute)
 }
 
 func (agent *Agent) executeAgentCmdWithError(arguments []string) (string, error) {
@@ -6,6 +6,7 @@
 package client
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strings"
@@ -105,19 +106,46 @@ func (agent *AgentCommandRunner) Status(commandArgs ...AgentArgsOption) *Status
 	return newStatus(agent.executeCommand("status", commandArgs...))
 }
 
-// waitForReadyTimeout blocks up to timeout waiting for agent to be ready.
-// Retries every 100 ms up to timeout.
+// GetAgentVersion returns the version of the running Agent, read from the JSON output of the status command
+func (agent *AgentCommandRunner) GetAgentVersion() (string, error) {
+	status, err := agent.fetchStatusJSON()
+	if err != nil {
+		return "", err
+	}
+	version, ok := status["version"].(string)
+	if !ok || version == "" {
+		return "", errors.New("no agent version found in the status output")
+	}
+	return version, nil
+}
+
+// fetchStatusJSON runs the status command and returns its JSON output
+func (agent *AgentCommandRunner) fetchStatusJSON() (map[string]any, error) {
+	output, err := agent.executeAgentCmdWithError([]string{"status", "--json"})
+	if err != nil {
+		return nil, err
+	}
+	var status map[string]any
+	if err := json.Unmarshal([]byte(output), &status); err != nil {
+		return nil, fmt.Errorf("unable to parse the status output: %w", err)
+	}
+	return status, nil
+}
+
+// WaitForAgentReady blocks up to timeout waiting for the agent to be ready, that is
+// until the status command returns a valid JSON status.
+// Retries with an exponential backoff starting at 100 ms.
 // Returns error on failure.
-func (agent *AgentCommandRunner) waitForReadyTimeout(timeout time.Duration) error {
-	interval := 100 * time.Millisecond
-	maxRetries := timeout.Milliseconds() / interval.Milliseconds()
+func (agent *AgentCommandRunner) WaitForAgentReady(timeout time.Duration) error {
+	expBackoff := backoff.NewExponentialBackOff()
+	expBackoff.InitialInterval = 100 * time.Millisecond
+	expBackoff.MaxElapsedTime = timeout
 	err := backoff.Retry(func() error {
-		_, err := agent.executeAgentCmdWithError([]string{"status"})
-		if err != nil {
-			return errors.New("agent not ready")
+		if _, err := agent.fetchStatusJSON(); err != nil {
+			return fmt.Errorf("agent not ready: %w", err)
 		}
 		return nil
-	}, backoff.WithMaxRetries(backoff.NewConstantBackOff(interval), uint64(maxRetries)))
+	}, expBackoff)
 	return err
 }
 
@@ -0,0 +1,87 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package client
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAgentVersion(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		outputErr error
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:   "version in status",
+			output: `{"version": "7.49.0", "pid": 1234, "runnerStats": {}}`,
+			want:   "7.49.0",
+		},
+		{
+			name:    "no version in status",
+			output:  `{"pid": 1234}`,
+			wantErr: true,
+		},
+		{
+			name:    "invalid status",
+			output:  "Agent is not running",
+			wantErr: true,
+		},
+		{
+			name:      "status command failure",
+			outputErr: errors.New("exit status 1"),
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var arguments []string
+			agent := newAgentCommandRunner(t, func(args []string) (string, error) {
+				arguments = args
+				return tt.output, tt.outputErr
+			})
+
+			version, err := agent.GetAgentVersion()
+			assert.Equal(t, []string{"status", "--json"}, arguments)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, version)
+		})
+	}
+}
+
+func TestWaitForAgentReady(t *testing.T) {
+	calls := 0
+	agent := newAgentCommandRunner(t, func(args []string) (string, error) {
+		calls++
+		if calls <= 2 {
+			return "", errors.New("exit status 1")
+		}
+		return `{"version": "7.49.0"}`, nil
+	})
+
+	require.NoError(t, agent.WaitForAgentReady(10*time.Second))
+	assert.Equal(t, 3, calls)
+}
+
+func TestWaitForAgentReadyTimeout(t *testing.T) {
+	agent := newAgentCommandRunner(t, func(args []string) (string, error) {
+		return "", errors.New("exit status 1")
+	})
+
+	require.Error(t, agent.WaitForAgentReady(500*time.Millisecond))
+}
@@ -0,0 +1,82 @@
+// Unless explicitly stated otherwise all files in this repository are licensed
+// under the Apache License Version 2.0.
+// This product includes software developed at Datadog (https://www.datadoghq.com/).
+// Copyright 2016-present Datadog, Inc.
+
+package process
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DataDog/test-infra-definitions/components/datadog/agentparams"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/DataDog/datadog-agent/test/fakeintake/aggregator"
+	"github.com/DataDog/datadog-agent/test/new-e2e/pkg/utils/e2e"
+)
+
+const processCheckConfig = `
+process_config:
+  process_collection:
+    enabled: true
+`
+
+type processSuite struct {
+	e2e.Suite[e2e.FakeIntakeEnv]
+}
+
+func TestProcessSuite(t *testing.T) {
+	e2e.Run(t, &processSuite{}, e2e.FakeIntakeStackDef(e2e.WithAgentParams(agentparams.WithAgentConfig(processCheckConfig))))
+}
+
+func (s *processSuite) TestProcessCheck() {
+	var payloads []*aggregator.ProcessPayload
+	s.EventuallyWithT(func(c *assert.CollectT) {
+		processes, err := s.Env().Fakeintake.GetProcesses()
+		assert.NoError(c, err, "fakeintake GetProcesses() error")
+		if processes == nil {
+			return
+		}
+		payloads = processes.GetProcesses()
+		assert.NotEmpty(c, payloads, "no process payloads yet")
+	}, 2*time.Minute, 10*time.Second)
+
+	s.Run("payloads contain valid processes", func() {
+		assertValidProcesses(s.T(), payloads)
+	})
+
+	s.Run("payloads contain the agent process", func() {
+		assert.True(s.T(), hasProcess(payloads, "datadog-agent"), "the agent process was not found in the process payloads")
+	})
+}
+
+// assertValidProcesses checks that at least one process of the payloads is populated,
+// to catch checks sending empty messages
+func assertValidProcesses(t *testing.T, payloads []*aggregator.ProcessPayload) {
+	for _, payload := range payloads {
+		for _, process := range payload.Processes {
+			if process.Command != nil && process.Command.Exe != "" && process.Pid > 0 && process.CreateTime != 0 {
+				return
+			}
+		}
+	}
+	assert.Fail(t, "no process with an executable, a pid and a creation time was found in the process payloads")
+}
+
+// hasProcess returns true if a process whose executable or arguments contain name
+// is in the payloads
+func hasProcess(payloads []*aggregator.ProcessPayload, name string) bool {
+	for _, payload := range payloads {
+		for _, process := range payload.Processes {
+			if process.Command == nil {
+				continue
+			}
+			if strings.Contains(process.Command.Exe, name) || strings.Contains(strings.Join(process.Command.Args, " "), name) {
+				return true
+			}
+		}
+	}
+	return false
+}